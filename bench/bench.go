@@ -0,0 +1,430 @@
+// Package bench runs identical workloads against every heap implementation
+// of this module so that they can be compared on the same inputs.
+//
+// The workloads are exposed as Go benchmarks by the package tests and as a
+// comparison table by the heapbench command.
+package bench
+
+import (
+	"cmp"
+	"math/rand"
+	"testing"
+
+	heap "github.com/theodesp/go-heaps"
+	"github.com/theodesp/go-heaps/binomial"
+	"github.com/theodesp/go-heaps/dary"
+	"github.com/theodesp/go-heaps/fibonacci"
+	"github.com/theodesp/go-heaps/leftist"
+	"github.com/theodesp/go-heaps/pairing"
+	rpheap "github.com/theodesp/go-heaps/rank_pairing"
+	"github.com/theodesp/go-heaps/skew"
+	"github.com/theodesp/go-heaps/treap"
+)
+
+// Decreaser is a heap that can decrease the key of an item it returned
+// a reference to.
+type Decreaser interface {
+	heap.Heap[int]
+	// InsertRef inserts v and returns a reference to it for DecreaseRef
+	InsertRef(v int) any
+	// DecreaseRef replaces the item referenced by ref with the smaller v
+	DecreaseRef(ref any, v int)
+}
+
+// handleHeap is a heap with a handle based DecreaseKey, as offered by the
+// pairing, rank pairing, binomial, fibonacci, leftist, skew and d-ary heaps.
+type handleHeap[H any] interface {
+	heap.Heap[int]
+	InsertHandle(v int) H
+	DecreaseKey(h H, v int)
+}
+
+type handles[H any] struct {
+	handleHeap[H]
+}
+
+func (h handles[H]) InsertRef(v int) any {
+	return h.InsertHandle(v)
+}
+
+func (h handles[H]) DecreaseRef(ref any, v int) {
+	h.DecreaseKey(ref.(H), v)
+}
+
+// WithHandles adapts a heap with handle based DecreaseKey to a Decreaser.
+func WithHandles[H any](h handleHeap[H]) Decreaser {
+	return handles[H]{h}
+}
+
+// melder is a heap that can absorb another heap of the same type.
+type melder interface {
+	Meld(a heap.Heap[int]) heap.Heap[int]
+}
+
+// bulkInserter is a heap that can insert a batch of items at once.
+type bulkInserter interface {
+	InsertAll(items []int)
+}
+
+// Impl describes a heap implementation taking part in the benchmarks.
+type Impl struct {
+	Name string
+	// New returns an empty heap ordered by compare
+	New func(compare func(a, b int) int) heap.Heap[int]
+	// NewDecreaser returns an empty heap ordered by compare that supports
+	// DecreaseRef, or is nil if the implementation has no handles
+	NewDecreaser func(compare func(a, b int) int) Decreaser
+}
+
+// CanMeld reports whether the heaps of impl implement Meld.
+func (impl Impl) CanMeld() bool {
+	_, ok := impl.New(cmp.Compare[int]).(melder)
+	return ok
+}
+
+// CanInsertAll reports whether the heaps of impl implement InsertAll.
+func (impl Impl) CanInsertAll() bool {
+	_, ok := impl.New(cmp.Compare[int]).(bulkInserter)
+	return ok
+}
+
+// Impls lists every heap implementation of this module.
+var Impls = []Impl{
+	{
+		Name: "pairing",
+		New:  func(c func(a, b int) int) heap.Heap[int] { return pairing.NewFunc(c) },
+		NewDecreaser: func(c func(a, b int) int) Decreaser {
+			return WithHandles[*pairing.Handle[int]](pairing.NewFunc(c))
+		},
+	},
+	{
+		Name: "pairing_multi_pass",
+		New: func(c func(a, b int) int) heap.Heap[int] {
+			return pairing.NewFunc(c, pairing.WithPairing(pairing.MultiPass))
+		},
+		NewDecreaser: func(c func(a, b int) int) Decreaser {
+			return WithHandles[*pairing.Handle[int]](pairing.NewFunc(c, pairing.WithPairing(pairing.MultiPass)))
+		},
+	},
+	{
+		Name: "pairing_back_to_front",
+		New: func(c func(a, b int) int) heap.Heap[int] {
+			return pairing.NewFunc(c, pairing.WithPairing(pairing.BackToFront))
+		},
+		NewDecreaser: func(c func(a, b int) int) Decreaser {
+			return WithHandles[*pairing.Handle[int]](pairing.NewFunc(c, pairing.WithPairing(pairing.BackToFront)))
+		},
+	},
+	{
+		Name: "pairing_auxiliary",
+		New: func(c func(a, b int) int) heap.Heap[int] {
+			return pairing.NewFunc(c, pairing.WithPairing(pairing.Auxiliary))
+		},
+		NewDecreaser: func(c func(a, b int) int) Decreaser {
+			return WithHandles[*pairing.Handle[int]](pairing.NewFunc(c, pairing.WithPairing(pairing.Auxiliary)))
+		},
+	},
+	{
+		Name: "leftist",
+		New:  func(c func(a, b int) int) heap.Heap[int] { return leftist.NewFunc(c) },
+		NewDecreaser: func(c func(a, b int) int) Decreaser {
+			return WithHandles[*leftist.Handle[int]](leftist.NewFunc(c))
+		},
+	},
+	{
+		Name: "leftist_weight",
+		New: func(c func(a, b int) int) heap.Heap[int] {
+			return leftist.NewBiasedFunc(leftist.WeightBiased, c)
+		},
+		NewDecreaser: func(c func(a, b int) int) Decreaser {
+			return WithHandles[*leftist.Handle[int]](leftist.NewBiasedFunc(leftist.WeightBiased, c))
+		},
+	},
+	{
+		Name: "skew",
+		New:  func(c func(a, b int) int) heap.Heap[int] { return skew.NewFunc(c) },
+		NewDecreaser: func(c func(a, b int) int) Decreaser {
+			return WithHandles[*skew.Handle[int]](skew.NewFunc(c))
+		},
+	},
+	{
+		Name: "skew_bottom_up",
+		New:  func(c func(a, b int) int) heap.Heap[int] { return skew.NewBottomUpFunc(c) },
+	},
+	{
+		Name: "fibonacci",
+		New:  func(c func(a, b int) int) heap.Heap[int] { return fibonacci.NewFunc(c) },
+		NewDecreaser: func(c func(a, b int) int) Decreaser {
+			return WithHandles[*fibonacci.Handle[int]](fibonacci.NewFunc(c))
+		},
+	},
+	{
+		Name: "binomial",
+		New:  func(c func(a, b int) int) heap.Heap[int] { return binomial.NewFunc(c) },
+		NewDecreaser: func(c func(a, b int) int) Decreaser {
+			return WithHandles[*binomial.Handle[int]](binomial.NewFunc(c))
+		},
+	},
+	{
+		Name: "binomial_lazy",
+		New:  func(c func(a, b int) int) heap.Heap[int] { return binomial.NewLazyFunc(c) },
+		NewDecreaser: func(c func(a, b int) int) Decreaser {
+			return WithHandles[*binomial.Handle[int]](binomial.NewLazyFunc(c))
+		},
+	},
+	{
+		Name: "binomial_skew",
+		New:  func(c func(a, b int) int) heap.Heap[int] { return binomial.NewSkewBinomialFunc(c) },
+		NewDecreaser: func(c func(a, b int) int) Decreaser {
+			return WithHandles[*binomial.Handle[int]](binomial.NewSkewBinomialFunc(c))
+		},
+	},
+	{
+		Name: "treap",
+		New:  func(c func(a, b int) int) heap.Heap[int] { return treap.NewFunc(c) },
+	},
+	{
+		Name: "rank_pairing",
+		New:  func(c func(a, b int) int) heap.Heap[int] { return rpheap.NewFunc(c) },
+		NewDecreaser: func(c func(a, b int) int) Decreaser {
+			return WithHandles[*rpheap.Handle[int]](rpheap.NewFunc(c))
+		},
+	},
+	{
+		Name: "rank_pairing_type2",
+		New: func(c func(a, b int) int) heap.Heap[int] {
+			return rpheap.NewFunc(c, rpheap.WithRankRule(rpheap.Type2))
+		},
+		NewDecreaser: func(c func(a, b int) int) Decreaser {
+			return WithHandles[*rpheap.Handle[int]](rpheap.NewFunc(c, rpheap.WithRankRule(rpheap.Type2)))
+		},
+	},
+	{
+		Name: "rank_pairing_one_pass",
+		New: func(c func(a, b int) int) heap.Heap[int] {
+			return rpheap.NewFunc(c, rpheap.WithLinking(rpheap.OnePass))
+		},
+		NewDecreaser: func(c func(a, b int) int) Decreaser {
+			return WithHandles[*rpheap.Handle[int]](rpheap.NewFunc(c, rpheap.WithLinking(rpheap.OnePass)))
+		},
+	},
+	{
+		Name: "rank_pairing_type2_one_pass",
+		New: func(c func(a, b int) int) heap.Heap[int] {
+			return rpheap.NewFunc(c, rpheap.WithRankRule(rpheap.Type2), rpheap.WithLinking(rpheap.OnePass))
+		},
+		NewDecreaser: func(c func(a, b int) int) Decreaser {
+			return WithHandles[*rpheap.Handle[int]](rpheap.NewFunc(c, rpheap.WithRankRule(rpheap.Type2), rpheap.WithLinking(rpheap.OnePass)))
+		},
+	},
+	{
+		Name: "dary2",
+		New:  func(c func(a, b int) int) heap.Heap[int] { return dary.NewFunc(2, c) },
+		NewDecreaser: func(c func(a, b int) int) Decreaser {
+			return WithHandles[*dary.Handle[int]](dary.NewFunc(2, c))
+		},
+	},
+	{
+		Name: "dary4",
+		New:  func(c func(a, b int) int) heap.Heap[int] { return dary.NewFunc(4, c) },
+		NewDecreaser: func(c func(a, b int) int) Decreaser {
+			return WithHandles[*dary.Handle[int]](dary.NewFunc(4, c))
+		},
+	},
+}
+
+// Workload is a sequence of heap operations over an input of n items.
+type Workload struct {
+	Name string
+	// Input returns the items the workload operates on
+	Input func(n int) []int
+	// Supports reports whether impl offers the operations the workload needs
+	Supports func(impl Impl) bool
+	// Run performs the workload once on a heap built by impl
+	Run func(impl Impl, compare func(a, b int) int, input []int)
+}
+
+// Workloads lists the workloads run against every implementation.
+var Workloads = []Workload{
+	{
+		Name:     "random",
+		Input:    randomInput,
+		Supports: always,
+		Run:      insertDeleteAll,
+	},
+	{
+		Name:     "sorted",
+		Input:    sortedInput,
+		Supports: always,
+		Run:      insertDeleteAll,
+	},
+	{
+		Name:     "reverse",
+		Input:    reverseInput,
+		Supports: always,
+		Run:      insertDeleteAll,
+	},
+	{
+		Name:     "decrease_key",
+		Input:    randomInput,
+		Supports: func(impl Impl) bool { return impl.NewDecreaser != nil },
+		Run:      decreaseKey,
+	},
+	{
+		Name:     "meld",
+		Input:    randomInput,
+		Supports: Impl.CanMeld,
+		Run:      meld,
+	},
+	{
+		Name:     "build",
+		Input:    randomInput,
+		Supports: always,
+		Run:      build,
+	},
+	{
+		Name:     "insert_all",
+		Input:    randomInput,
+		Supports: Impl.CanInsertAll,
+		Run:      insertAll,
+	},
+	{
+		Name:     "mixed",
+		Input:    randomInput,
+		Supports: always,
+		Run:      mixed,
+	},
+}
+
+// Benchmark runs w on impl with n items as a Go benchmark. Besides time and
+// allocations it reports the number of comparisons as the cmps/op metric.
+func Benchmark(b *testing.B, impl Impl, w Workload, n int) {
+	input := w.Input(n)
+	var comparisons int64
+	compare := func(a, b int) int {
+		comparisons++
+		return cmp.Compare(a, b)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.Run(impl, compare, input)
+	}
+	b.ReportMetric(float64(comparisons)/float64(b.N), "cmps/op")
+}
+
+func always(Impl) bool { return true }
+
+func randomInput(n int) []int {
+	return rand.New(rand.NewSource(int64(n))).Perm(n)
+}
+
+func sortedInput(n int) []int {
+	input := make([]int, n)
+	for i := range input {
+		input[i] = i
+	}
+	return input
+}
+
+func reverseInput(n int) []int {
+	input := make([]int, n)
+	for i := range input {
+		input[i] = n - 1 - i
+	}
+	return input
+}
+
+// insertDeleteAll inserts every item and then deletes them all.
+func insertDeleteAll(impl Impl, compare func(a, b int) int, input []int) {
+	h := impl.New(compare)
+	for _, v := range input {
+		h.Insert(v)
+	}
+	for range input {
+		h.DeleteMin()
+	}
+}
+
+// decreaseKey inserts every item, decreases each of them twice and then
+// deletes them all, as a Dijkstra style shortest path search does.
+func decreaseKey(impl Impl, compare func(a, b int) int, input []int) {
+	h := impl.NewDecreaser(compare)
+	n := len(input)
+	refs := make([]any, n)
+	keys := make([]int, n)
+	for i, v := range input {
+		keys[i] = v + 2*n
+		refs[i] = h.InsertRef(keys[i])
+	}
+	for round := 1; round <= 2; round++ {
+		for i, v := range input {
+			// visit the items in the order of the input to spread the decreases
+			j := (v + i) % n
+			keys[j] -= round * (input[i]%n + 1) % n
+			h.DecreaseRef(refs[j], keys[j])
+		}
+	}
+	for range input {
+		h.DeleteMin()
+	}
+}
+
+// meld builds one small heap per 8 items and melds them pairwise until a
+// single heap is left, then deletes all items.
+func meld(impl Impl, compare func(a, b int) int, input []int) {
+	var queue []heap.Heap[int]
+	for i := 0; i < len(input); i += 8 {
+		h := impl.New(compare)
+		for j := i; j < i+8 && j < len(input); j++ {
+			h.Insert(input[j])
+		}
+		queue = append(queue, h)
+	}
+	for len(queue) > 1 {
+		a, b := queue[0], queue[1]
+		queue = append(queue[2:], a.(melder).Meld(b))
+	}
+	for _, h := range queue {
+		for !h.IsEmpty() {
+			h.DeleteMin()
+		}
+	}
+}
+
+// build inserts every item one by one and deletes the minimum once, which
+// makes lazy heaps do their deferred work.
+func build(impl Impl, compare func(a, b int) int, input []int) {
+	h := impl.New(compare)
+	for _, v := range input {
+		h.Insert(v)
+	}
+	h.DeleteMin()
+}
+
+// insertAll is build with all items inserted by a single InsertAll.
+func insertAll(impl Impl, compare func(a, b int) int, input []int) {
+	h := impl.New(compare)
+	h.(bulkInserter).InsertAll(input)
+	h.DeleteMin()
+}
+
+// mixed interleaves inserts with finds and deletes of the minimum.
+func mixed(impl Impl, compare func(a, b int) int, input []int) {
+	h := impl.New(compare)
+	for _, v := range input {
+		switch v % 4 {
+		case 0, 1:
+			h.Insert(v)
+		case 2:
+			h.Insert(v)
+			h.DeleteMin()
+		case 3:
+			h.FindMin()
+			h.Insert(v)
+		}
+	}
+	for !h.IsEmpty() {
+		h.DeleteMin()
+	}
+}
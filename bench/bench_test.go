@@ -0,0 +1,32 @@
+package bench
+
+import (
+	"cmp"
+	"testing"
+)
+
+func TestWorkloads(t *testing.T) {
+	for _, w := range Workloads {
+		for _, impl := range Impls {
+			if !w.Supports(impl) {
+				continue
+			}
+			t.Run(w.Name+"/"+impl.Name, func(t *testing.T) {
+				w.Run(impl, cmp.Compare[int], w.Input(100))
+			})
+		}
+	}
+}
+
+func BenchmarkHeaps(b *testing.B) {
+	for _, w := range Workloads {
+		for _, impl := range Impls {
+			if !w.Supports(impl) {
+				continue
+			}
+			b.Run(w.Name+"/"+impl.Name, func(b *testing.B) {
+				Benchmark(b, impl, w, 1000)
+			})
+		}
+	}
+}
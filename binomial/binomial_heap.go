@@ -6,137 +6,408 @@
 package binomial
 
 import (
-	"math"
-	
+	"cmp"
+	"fmt"
+
 	heap "github.com/theodesp/go-heaps"
 )
 
+// BinomialHeap implements the Extended interface
+var _ heap.Extended = (*BinomialHeap)(nil)
+
+// Heap implements the ExtendedHeap interface
+var _ heap.ExtendedHeap[int] = (*Heap[int])(nil)
+
+// Heap is an implementation of a Binomial Heap over values of type T.
+// The zero value for Heap is an empty Heap ordered by go_heaps.Compare.
+type Heap[T any] struct {
+	root *node[T]
+	// min is the root holding the smallest item
+	min  *node[T]
+	size int
+	cmp  func(a, b T) int
+	// owner is set once the Heap has snapshots
+	owner *owner
+}
+
 // BinomialHeap is an implementation of a Binomial Heap.
 type BinomialHeap struct {
-	root *node
+	Heap[heap.Item]
 }
 
-//node is a leaf in the heap
-type node struct {
-	item    heap.Item
-	parent  *node
-	child   *node
-	sibling *node
+// node is a leaf in the heap
+type node[T any] struct {
+	item T
+	// not kept on a heap with snapshots, except on a path copied by
+	// copyPath
+	parent  *node[T]
+	child   *node[T]
+	sibling *node[T]
 	degree  int
+	// set when the item was inserted with InsertHandle
+	handle *Handle[T]
+	owner  *owner
+}
+
+// Handle is an opaque reference to an item inserted with InsertHandle.
+// It stays valid until the item is removed from the heap.
+type Handle[T any] struct {
+	n *node[T]
+}
+
+// Item returns the item referenced by the Handle.
+func (h *Handle[T]) Item() T {
+	return h.n.item
+}
+
+// swapWithParent exchanges the items of n and its parent, keeping any
+// handles pointing at the node that holds their item.
+func (n *node[T]) swapWithParent() {
+	n.swapItems(n.parent)
+}
+
+// swapItems exchanges the items of n and m, keeping any handles pointing at
+// the node that holds their item.
+func (n *node[T]) swapItems(m *node[T]) {
+	n.item, m.item = m.item, n.item
+	n.handle, m.handle = m.handle, n.handle
+	if n.handle != nil {
+		n.handle.n = n
+	}
+	if m.handle != nil {
+		m.handle.n = m
+	}
 }
 
-//Insert inserts the value to the BinomialHeap and returns the item
+// siftUp moves the item of n up while it is smaller than the item of its
+// parent and returns the node that holds it.
+func siftUp[T any](n *node[T], compare func(a, b T) int) *node[T] {
+	for n.parent != nil && compare(n.item, n.parent.item) < 0 {
+		n.swapWithParent()
+		n = n.parent
+	}
+	return n
+}
+
+// bubbleUp moves the item of n up to the root of its tree as if it were
+// negative infinity, without comparing it, and returns that root.
+func bubbleUp[T any](n *node[T]) *node[T] {
+	for n.parent != nil {
+		n.swapWithParent()
+		n = n.parent
+	}
+	return n
+}
+
+// New returns an empty BinomialHeap.
+func New() *BinomialHeap { return &BinomialHeap{} }
+
+// NewWithComparator returns an empty BinomialHeap ordered by compare
+// instead of Item.Compare.
+func NewWithComparator(compare func(a, b heap.Item) int) *BinomialHeap {
+	b := &BinomialHeap{}
+	b.cmp = compare
+	return b
+}
+
+// NewFunc returns an empty Heap ordered by compare.
+func NewFunc[T any](compare func(a, b T) int) *Heap[T] {
+	return &Heap[T]{cmp: compare}
+}
+
+// NewOrdered returns an empty Heap of ordered values.
+func NewOrdered[T cmp.Ordered]() *Heap[T] { return NewFunc(cmp.Compare[T]) }
+
+func (b *Heap[T]) compare(x, y T) int {
+	if b.cmp == nil {
+		return heap.Compare(x, y)
+	}
+	return b.cmp(x, y)
+}
+
+// Insert inserts the value to the Heap and returns the item
 // The complexity is O(log n).
-func (b *BinomialHeap) Insert(v heap.Item) heap.Item {
-	n := node{item: v}
-	tempHeap := &BinomialHeap{root: &n}
-	b.root = b.union(tempHeap)
-	return n.item
+func (b *Heap[T]) Insert(v T) T {
+	b.insert(&node[T]{item: v, owner: b.owner})
+	return v
+}
+
+// insert adds the single node n to the Heap.
+func (b *Heap[T]) insert(n *node[T]) {
+	b.root = b.union(&Heap[T]{root: n})
+	b.updateMin()
+	b.size++
+	heap.DebugValidate(b)
+}
+
+// updateMin finds the root holding the smallest item.
+func (b *Heap[T]) updateMin() {
+	b.min = b.root
+	for next := b.root; next != nil; next = next.sibling {
+		if b.compare(next.item, b.min.item) < 0 {
+			b.min = next
+		}
+	}
 }
 
-// DeleteMin removes the smallest item from the BinomialHeap and returns it
+// DeleteMin removes the smallest item from the Heap and returns it
 // The complexity is O(log n).
-func (b *BinomialHeap) DeleteMin() heap.Item {
+func (b *Heap[T]) DeleteMin() T {
 	if b.root == nil {
-		return nil
+		var zero T
+		return zero
 	}
 
-	min := b.root
-	var minPrev *node
-	next := min.sibling
-	nextPrev := min
-
-	for next != nil {
-		if next.item.Compare(min.item) < 0 {
-			min = next
-			minPrev = nextPrev
-		}
-		nextPrev = next
-		next = next.sibling
+	if b.owner != nil {
+		b.root = ownList(b.owner, b.root)
+		b.updateMin()
 	}
-	b.removeTreeRoot(min, minPrev)
+	min := b.min
+	var prev *node[T]
+	for curr := b.root; curr != min; curr = curr.sibling {
+		prev = curr
+	}
+	b.removeTreeRoot(min, prev)
+	heap.DebugValidate(b)
 	return min.item
 }
 
-// Deletes passed item from the heap.
+// InsertHandle inserts the value to the Heap and returns a Handle to it
+// that can be passed to DecreaseKey, IncreaseKey and Remove.
 // The complexity is O(log n).
-func (p *BinomialHeap) Delete(item heap.Item) {
-	found := p.findAny(item)
-	next := found
-	for next.parent != nil {
-		temp := next.item
-		next.item = next.parent.item
-		next.parent.item = temp
-		
-		next = next.parent
-	}
-	next.item = heap.Integer(math.Inf(-1))
-	p.DeleteMin()
-}
-
-// FindAny returns the address of item in the heap.
-func (b *BinomialHeap) findAny(item heap.Item) *node {
-	next := b.root
-	backToParent := false
-	
-	for next != nil {
-		if next.child != nil && !backToParent {
-			if next.item == item {
-				return next
-			}
-			next = next.child
-			backToParent = false
-		} else if next.sibling != nil  {
-			if next.item == item {
-				return next
-			}
-			next = next.sibling
-			backToParent = false
-		} else if next.parent != nil {
-			if next.item == item {
-				return next
-			}
-			next = next.parent
-			backToParent = true
-		} else {
-			next = nil
-		}
+func (b *Heap[T]) InsertHandle(v T) *Handle[T] {
+	checkHandles(b.owner)
+	n := &node[T]{item: v}
+	h := &Handle[T]{n: n}
+	n.handle = h
+	b.insert(n)
+	return h
+}
+
+// DecreaseKey replaces the item referenced by h with a smaller item.
+// The complexity is O(log n).
+func (b *Heap[T]) DecreaseKey(h *Handle[T], v T) {
+	checkHandles(b.owner)
+	if b.compare(v, h.n.item) > 0 {
+		panic("new item is greater than the previous one")
 	}
-	return b.root
+	b.decrease(h.n, v)
 }
 
-// FindMin returns the smallest item in the heap.
+// decrease replaces the item of n with the smaller v and sifts it up.
+func (b *Heap[T]) decrease(n *node[T], v T) {
+	n.item = v
+	n = siftUp(n, b.compare)
+	if b.compare(n.item, b.min.item) < 0 {
+		b.min = n
+	}
+	heap.DebugValidate(b)
+}
+
+// IncreaseKey replaces the item referenced by h with a greater item.
 // The complexity is O(log n).
-func (b *BinomialHeap) FindMin() heap.Item {
-	if b.root == nil {
+func (b *Heap[T]) IncreaseKey(h *Handle[T], v T) {
+	checkHandles(b.owner)
+	if b.compare(v, h.n.item) < 0 {
+		panic("new item is smaller than the previous one")
+	}
+	b.increase(h.n, v)
+}
+
+// increase removes n and inserts v in a new node, which takes over the
+// handle of n.
+func (b *Heap[T]) increase(n *node[T], v T) {
+	h := n.handle
+	b.remove(n)
+	n = &node[T]{item: v, handle: h, owner: b.owner}
+	if h != nil {
+		h.n = n
+	}
+	b.insert(n)
+}
+
+// Remove deletes the item referenced by h from the heap and returns it.
+// The complexity is O(log n).
+func (b *Heap[T]) Remove(h *Handle[T]) T {
+	checkHandles(b.owner)
+	return b.remove(h.n)
+}
+
+// Delete removes item from the heap and returns it.
+// It returns the zero value of T if the item is not found.
+// The complexity is O(n) to find the item plus O(log n).
+func (b *Heap[T]) Delete(item T) T {
+	found := b.findAny(item)
+	if found == nil {
+		var zero T
+		return zero
+	}
+	return b.remove(found)
+}
+
+// Adjust replaces the item old with new and returns new.
+// It returns the zero value of T if old is not found.
+// The complexity is O(n) to find the item plus O(log n).
+func (b *Heap[T]) Adjust(old, new T) T {
+	found := b.findAny(old)
+	if found == nil {
+		var zero T
+		return zero
+	}
+	if b.compare(new, found.item) <= 0 {
+		b.decrease(found, new)
+	} else {
+		b.increase(found, new)
+	}
+	return new
+}
+
+// Meld moves all items of a into the heap by uniting their root lists.
+// If a has snapshots and the heap does not, Meld melds a Clone of a, so
+// that the heap keeps its handles; this takes O(m).
+// The complexity is O(log n + log m).
+func (b *Heap[T]) Meld(a heap.Heap[T]) heap.Heap[T] {
+	if a == nil {
+		return b
+	}
+	switch o := a.(type) {
+	case *Heap[T]:
+		if o == b || o.root == nil {
+			return b
+		}
+		size := b.size + o.size
+		src := o
+		if o.owner != nil && b.owner == nil {
+			src = o.Clone()
+		}
+		b.root = b.union(src)
+		b.updateMin()
+		b.size = size
+		o.Clear()
+		heap.DebugValidate(b)
+	default:
+		panic(fmt.Sprintf("unexpected type %T", a))
+	}
+	return b
+}
+
+// Meld moves all items of a into the heap by uniting their root lists.
+// The complexity is O(log n + log m).
+func (b *BinomialHeap) Meld(a heap.Interface) heap.Interface {
+	if a == nil {
+		return b
+	}
+	switch o := a.(type) {
+	case *BinomialHeap:
+		b.Heap.Meld(&o.Heap)
+	default:
+		panic(fmt.Sprintf("unexpected type %T", a))
+	}
+	return b
+}
+
+// remove bubbles the item of n up to the root of its tree as if it were
+// negative infinity, without comparing it, and drops that root from the
+// heap. This works for items of any type.
+func (b *Heap[T]) remove(n *node[T]) T {
+	item := n.item
+	n = bubbleUp(n)
+	var prev *node[T]
+	for curr := b.root; curr != n; curr = curr.sibling {
+		prev = curr
+	}
+	b.removeTreeRoot(n, prev)
+	heap.DebugValidate(b)
+	return item
+}
+
+// findAny returns the node holding item or nil if there is none. On a heap
+// with snapshots it copies the path to the node first, so that decrease
+// and remove can move its item up.
+func (b *Heap[T]) findAny(item T) *node[T] {
+	if b.owner == nil {
+		return findNode(b.root, item, b.compare)
+	}
+	path := findPath(b.root, item, b.compare)
+	if path == nil {
 		return nil
 	}
-	min := b.root
-	next := min.sibling
+	var n *node[T]
+	b.root, n = copyPath(b.owner, path)
+	b.updateMin()
+	return n
+}
 
-	for next != nil {
-		if next.item.Compare(min.item) < 0 {
-			min = next
+// findNode returns the node holding item in the trees of the root list
+// roots or nil if there is none. It skips the children of nodes greater
+// than item, which cannot hold it.
+func findNode[T any](roots *node[T], item T, compare func(a, b T) int) *node[T] {
+	var stack []*node[T]
+	for next := roots; next != nil || len(stack) > 0; {
+		if next == nil {
+			next, stack = stack[len(stack)-1], stack[:len(stack)-1]
+		}
+		c := compare(next.item, item)
+		if c == 0 {
+			return next
+		}
+		if next.sibling != nil {
+			stack = append(stack, next.sibling)
+		}
+		if c < 0 {
+			next = next.child
+		} else {
+			next = nil
 		}
-		next = next.sibling
 	}
+	return nil
+}
 
-	return min.item
+// FindMin returns the smallest item in the heap.
+// The complexity is O(1).
+func (b *Heap[T]) FindMin() T {
+	if b.min == nil {
+		var zero T
+		return zero
+	}
+	return b.min.item
 }
 
-// Clear resets the current BinomialHeap
-func (b *BinomialHeap) Clear() {
+// Clear resets the current Heap
+func (b *Heap[T]) Clear() {
 	b.root = nil
+	b.min = nil
+	b.size = 0
+	b.owner = nil
+}
+
+// Len returns the number of items in the heap.
+// The complexity is O(1).
+func (b *Heap[T]) Len() int {
+	return b.size
 }
 
-func (b *BinomialHeap) union(heap *BinomialHeap) *node {
-	newRoot := merge(b, heap)
+// IsEmpty returns true if the heap has no items.
+// The complexity is O(1).
+func (b *Heap[T]) IsEmpty() bool {
+	return b.root == nil
+}
+
+func (b *Heap[T]) union(heap *Heap[T]) *node[T] {
+	newRoot := unionRoots(ownList(b.owner, b.root), ownList(b.owner, heap.root), b.compare)
 	b.root = nil
 	heap.root = nil
+	return newRoot
+}
+
+// unionRoots merges the root lists a and b, which are sorted by increasing
+// degree, and links the trees of equal degree.
+func unionRoots[T any](a, b *node[T], compare func(x, y T) int) *node[T] {
+	newRoot := merge(a, b)
 	if newRoot == nil {
 		return nil
 	}
-	var prev *node
+	var prev *node[T]
 	curr := newRoot
 	next := newRoot.sibling
 	for next != nil {
@@ -145,7 +416,7 @@ func (b *BinomialHeap) union(heap *BinomialHeap) *node {
 			prev = curr
 			curr = next
 		} else {
-			if curr.item.Compare(next.item) < 0 {
+			if compare(curr.item, next.item) < 0 {
 				curr.sibling = next.sibling
 				linkNodes(curr, next)
 			} else {
@@ -163,7 +434,7 @@ func (b *BinomialHeap) union(heap *BinomialHeap) *node {
 	return newRoot
 }
 
-func (b *BinomialHeap) removeTreeRoot(root, prev *node) {
+func (b *Heap[T]) removeTreeRoot(root, prev *node[T]) {
 	// Remove root from the heap
 	if root == b.root {
 		b.root = root.sibling
@@ -171,7 +442,17 @@ func (b *BinomialHeap) removeTreeRoot(root, prev *node) {
 		prev.sibling = root.sibling
 	}
 
-	var newRoot *node
+	root.child = ownList(b.owner, root.child)
+	newHeap := &Heap[T]{root: detachChildren(root)}
+	b.root = b.union(newHeap)
+	b.updateMin()
+	b.size--
+}
+
+// detachChildren removes the children of root and returns them as a root
+// list in reverse order, which is by increasing degree.
+func detachChildren[T any](root *node[T]) *node[T] {
+	var newRoot *node[T]
 	child := root.child
 	for child != nil {
 		next := child.sibling
@@ -180,21 +461,21 @@ func (b *BinomialHeap) removeTreeRoot(root, prev *node) {
 		newRoot = child
 		child = next
 	}
-	newHeap := &BinomialHeap{root: newRoot}
-	b.root = b.union(newHeap)
+	root.child = nil
+	return newRoot
 }
 
-func merge(a *BinomialHeap, b *BinomialHeap) *node {
-	if a.root == nil {
-		return b.root
+func merge[T any](a, b *node[T]) *node[T] {
+	if a == nil {
+		return b
 	}
-	if b.root == nil {
-		return a.root
+	if b == nil {
+		return a
 	}
 
-	var root *node
-	aNext := a.root
-	bNext := b.root
+	var root *node[T]
+	aNext := a
+	bNext := b
 	if aNext.degree <= bNext.degree {
 		root = aNext
 		aNext = aNext.sibling
@@ -226,7 +507,7 @@ func merge(a *BinomialHeap, b *BinomialHeap) *node {
 	return root
 }
 
-func linkNodes(a, b *node) {
+func linkNodes[T any](a, b *node[T]) {
 	b.parent = a
 	b.sibling = a.child
 	a.child = b
@@ -1,10 +1,15 @@
 package binomial
 
 import (
+	"encoding"
+	"fmt"
+	"iter"
+	"slices"
 	"sort"
 	"testing"
 
 	"github.com/theodesp/go-heaps"
+	"github.com/theodesp/go-heaps/heaptest"
 )
 
 func TestLeftistHeapInteger(t *testing.T) {
@@ -87,6 +92,112 @@ func TestLeftistHeapString(t *testing.T) {
 	}
 }
 
+func TestBinomialHeapLen(t *testing.T) {
+	heap := &BinomialHeap{}
+
+	if !heap.IsEmpty() || heap.Len() != 0 || heap.DeleteMin() != nil {
+		t.Fail()
+	}
+
+	for _, number := range []int{4, 3, 2, 5} {
+		heap.Insert(Int(number))
+	}
+	heap.DeleteMin()
+
+	if heap.IsEmpty() || heap.Len() != 3 {
+		t.Fail()
+	}
+
+	heap.Clear()
+	if !heap.IsEmpty() || heap.Len() != 0 {
+		t.Fail()
+	}
+}
+
+func TestBinomialHeapMaxHeap(t *testing.T) {
+	heap := NewWithComparator(func(a, b go_heaps.Item) int { return b.Compare(a) })
+	reversed := New()
+
+	for _, number := range []int{4, 3, 7, 5} {
+		heap.Insert(go_heaps.Integer(number))
+		reversed.Insert(go_heaps.Reverse(go_heaps.Integer(number)))
+	}
+
+	for _, number := range []int{7, 5, 4, 3} {
+		if go_heaps.Integer(number) != heap.DeleteMin() {
+			t.Fail()
+		}
+		if go_heaps.Integer(number) != reversed.DeleteMin().(go_heaps.Reversed).Item {
+			t.Fail()
+		}
+	}
+}
+
+func TestHeapOrdered(t *testing.T) {
+	heap := NewOrdered[int]()
+
+	numbers := []int{14, 112, 15, 16, 71, 91, 1, 12, 23, 56, 34}
+
+	for _, number := range numbers {
+		heap.Insert(number)
+	}
+
+	heap.Delete(15)
+	numbers = RemoveInts(numbers, 15)
+	sort.Ints(numbers)
+
+	for _, number := range numbers {
+		if number != heap.DeleteMin() {
+			t.Fail()
+		}
+	}
+	if heap.FindMin() != 0 {
+		t.Fail()
+	}
+}
+
+func TestHeapFunc(t *testing.T) {
+	heap := NewFunc(func(a, b string) int { return len(b) - len(a) })
+
+	for _, str := range []string{"a", "ccc", "bb", "dddd"} {
+		heap.Insert(str)
+	}
+
+	for _, str := range []string{"dddd", "ccc", "bb", "a"} {
+		if str != heap.DeleteMin() {
+			t.Fail()
+		}
+	}
+}
+
+func TestHandle(t *testing.T) {
+	heap := NewOrdered[int]()
+	handles := make([]*Handle[int], 10)
+	for i := range handles {
+		handles[i] = heap.InsertHandle(i * 10)
+	}
+
+	heap.DecreaseKey(handles[9], -5)
+	if heap.FindMin() != -5 {
+		t.Fail()
+	}
+	heap.IncreaseKey(handles[9], 95)
+	heap.IncreaseKey(handles[0], 55)
+	if heap.Remove(handles[5]) != 50 {
+		t.Fail()
+	}
+	heap.DecreaseKey(handles[8], 15)
+	if handles[0].Item() != 55 || handles[8].Item() != 15 {
+		t.Fail()
+	}
+
+	for _, number := range []int{10, 15, 20, 30, 40, 55, 60, 70, 95} {
+		if number != heap.DeleteMin() {
+			t.Fail()
+		}
+	}
+}
+
 func RemoveInts(s []int, hay int) []int {
 	sort.Ints(s)
 	i := sort.SearchInts(s, hay)
@@ -108,3 +219,389 @@ func Int(value int) go_heaps.Integer {
 func Str(value string) go_heaps.String {
 	return go_heaps.String(value)
 }
+
+func TestBinomialHeapMeld(t *testing.T) {
+	heap := New()
+	other := New()
+	for _, number := range []int{4, 8, 1} {
+		heap.Insert(Int(number))
+	}
+	for _, number := range []int{7, 0, 3, 5} {
+		other.Insert(Int(number))
+	}
+
+	heap.Meld(other)
+	if heap.Len() != 7 || !other.IsEmpty() || heap.FindMin() != Int(0) {
+		t.Fatalf("Len() = %d, other.Len() = %d", heap.Len(), other.Len())
+	}
+	for _, number := range []int{0, 1, 3, 4, 5, 7, 8} {
+		if Int(number) != heap.DeleteMin() {
+			t.Fail()
+		}
+	}
+}
+
+func TestBinomialHeapNotFound(t *testing.T) {
+	heap := New()
+	for _, str := range []string{"b", "d", "a"} {
+		heap.Insert(Str(str))
+	}
+
+	if heap.Delete(Str("c")) != nil || heap.Adjust(Str("c"), Str("e")) != nil || heap.Len() != 3 {
+		t.Fail()
+	}
+	if heap.Adjust(Str("d"), Str("0")) != Str("0") || heap.FindMin() != Str("0") {
+		t.Fail()
+	}
+	if heap.Delete(Str("0")) != Str("0") || heap.FindMin() != Str("a") {
+		t.Fail()
+	}
+}
+
+func TestExtended(t *testing.T) {
+	heaptest.RunExtendedTests(t, func() go_heaps.Extended { return New() })
+}
+
+func FuzzHeap(f *testing.F) {
+	heaptest.AddSeeds(f)
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		heaptest.FuzzExtended(t, func() go_heaps.Extended { return New() }, ops)
+		heaptest.FuzzExtended(t, func() go_heaps.Extended { return NewLazy() }, ops)
+		heaptest.FuzzExtended(t, func() go_heaps.Extended { return NewSkewBinomial() }, ops)
+		heaptest.FuzzHandles(t, func() heaptest.HandleHeap[*Handle[int]] { return NewOrdered[int]() }, ops)
+		heaptest.FuzzHandles(t, func() heaptest.HandleHeap[*Handle[int]] { return NewLazyOrdered[int]() }, ops)
+		heaptest.FuzzHandles(t, func() heaptest.HandleHeap[*Handle[int]] { return NewSkewBinomialOrdered[int]() }, ops)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	h := NewOrdered[int]()
+	for _, number := range []int{5, 3, 8, 1, 9, 4} {
+		h.Insert(number)
+	}
+	if err := h.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	h.root.degree++
+	if h.Validate() == nil {
+		t.Fail()
+	}
+}
+
+func TestValidateMin(t *testing.T) {
+	h := NewOrdered[int]()
+	for _, number := range []int{5, 3, 8, 1, 9, 4} {
+		h.Insert(number)
+	}
+	h.min = h.root
+	if h.Validate() == nil {
+		t.Fail()
+	}
+}
+
+func TestVariants(t *testing.T) {
+	factories := map[string]heaptest.ExtendedFactory{
+		"lazy": func() go_heaps.Extended { return NewLazy() },
+		"skew": func() go_heaps.Extended { return NewSkewBinomial() },
+	}
+	for name, factory := range factories {
+		t.Run(name, func(t *testing.T) {
+			heaptest.RunExtendedTests(t, factory)
+		})
+	}
+}
+
+// handleHeap is the handle API shared by all binomial heap variants.
+type handleHeap interface {
+	go_heaps.ExtendedHeap[int]
+	InsertHandle(v int) *Handle[int]
+	DecreaseKey(h *Handle[int], v int)
+	IncreaseKey(h *Handle[int], v int)
+	Remove(h *Handle[int]) int
+	Validate() error
+}
+
+func TestVariantHandles(t *testing.T) {
+	heaps := map[string]func() handleHeap{
+		"eager": func() handleHeap { return NewOrdered[int]() },
+		"lazy":  func() handleHeap { return NewLazyOrdered[int]() },
+		"skew":  func() handleHeap { return NewSkewBinomialOrdered[int]() },
+	}
+	for name, newHeap := range heaps {
+		t.Run(name, func(t *testing.T) {
+			heap := newHeap()
+			handles := make([]*Handle[int], 100)
+			// descending items make skew links swap items with the roots
+			for i := len(handles) - 1; i >= 0; i-- {
+				handles[i] = heap.InsertHandle(i * 10)
+			}
+			for i, h := range handles {
+				if h.Item() != i*10 {
+					t.Fatalf("handles[%d].Item() = %d", i, h.Item())
+				}
+			}
+			// consolidate the lazy heap into larger trees
+			heap.Insert(-1)
+			heap.DeleteMin()
+
+			heap.DecreaseKey(handles[99], -5)
+			heap.IncreaseKey(handles[0], 555)
+			heap.Remove(handles[50])
+			heap.DecreaseKey(handles[60], 45)
+			if err := heap.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			if handles[0].Item() != 555 || handles[60].Item() != 45 || heap.FindMin() != -5 {
+				t.Fatalf("FindMin() = %d", heap.FindMin())
+			}
+
+			other := newHeap()
+			other.Insert(7)
+			heap.Meld(other)
+			if heap.Len() != 100 {
+				t.Fatalf("Len() = %d, want 100", heap.Len())
+			}
+			prev := heap.DeleteMin()
+			for !heap.IsEmpty() {
+				next := heap.DeleteMin()
+				if next < prev {
+					t.Fatalf("DeleteMin() = %d after %d", next, prev)
+				}
+				prev = next
+			}
+		})
+	}
+}
+
+func TestValidateVariants(t *testing.T) {
+	lazy := NewLazyOrdered[int]()
+	skew := NewSkewBinomialOrdered[int]()
+	for _, number := range []int{5, 3, 8, 1, 9, 4, 7} {
+		lazy.Insert(number)
+		skew.Insert(number)
+	}
+	lazy.DeleteMin()
+	skew.DeleteMin()
+	if err := lazy.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := skew.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	lazy.last = lazy.root
+	skew.root.degree++
+	if lazy.Validate() == nil || skew.Validate() == nil {
+		t.Fail()
+	}
+}
+
+func TestIterable(t *testing.T) {
+	heaptest.RunIterableTests(t, func() heaptest.IterableHeap { return NewOrdered[int]() })
+	t.Run("Lazy", func(t *testing.T) {
+		heaptest.RunIterableTests(t, func() heaptest.IterableHeap { return NewLazyOrdered[int]() })
+	})
+	t.Run("SkewBinomial", func(t *testing.T) {
+		heaptest.RunIterableTests(t, func() heaptest.IterableHeap { return NewSkewBinomialOrdered[int]() })
+	})
+}
+
+func TestInsertAll(t *testing.T) {
+	heaptest.RunBulkTests(t, func() heaptest.BulkHeap { return NewOrdered[int]() })
+	t.Run("Lazy", func(t *testing.T) {
+		heaptest.RunBulkTests(t, func() heaptest.BulkHeap { return NewLazyOrdered[int]() })
+	})
+	t.Run("SkewBinomial", func(t *testing.T) {
+		heaptest.RunBulkTests(t, func() heaptest.BulkHeap { return NewSkewBinomialOrdered[int]() })
+	})
+}
+
+func TestFromSlice(t *testing.T) {
+	h := FromSliceOrdered([]int{5, 3, 8, 1, 9, 2, 7})
+	if got := slices.Collect(h.Sorted()); !slices.Equal(got, []int{1, 2, 3, 5, 7, 8, 9}) {
+		t.Errorf("expected %v, got %v", []int{1, 2, 3, 5, 7, 8, 9}, got)
+	}
+	b := FromSlice([]go_heaps.Item{go_heaps.Integer(2), go_heaps.Integer(1)})
+	if res := b.DeleteMin(); res != go_heaps.Integer(1) {
+		t.Errorf("expected 1, got %v", res)
+	}
+}
+
+func TestClone(t *testing.T) {
+	heaptest.RunCloneTests(t, NewOrdered[int], (*Heap[int]).Clone)
+	t.Run("Lazy", func(t *testing.T) {
+		heaptest.RunCloneTests(t, NewLazyOrdered[int], (*Lazy[int]).Clone)
+	})
+	t.Run("SkewBinomial", func(t *testing.T) {
+		heaptest.RunCloneTests(t, NewSkewBinomialOrdered[int], (*SkewBinomial[int]).Clone)
+	})
+	b := New()
+	b.Insert(go_heaps.Integer(2))
+	c := b.Clone()
+	b.Insert(go_heaps.Integer(1))
+	if res := c.FindMin(); res != go_heaps.Integer(2) {
+		t.Errorf("expected 2, got %v", res)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	heaptest.RunSnapshotTests[*Heap[int], *Handle[int]](t, NewOrdered[int])
+	testSnapshotSharing(t, FromSliceOrdered[int], func(b *Heap[int]) *node[int] { return b.root })
+	t.Run("Lazy", func(t *testing.T) {
+		heaptest.RunSnapshotTests[*Lazy[int], *Handle[int]](t, NewLazyOrdered[int])
+		testSnapshotSharing(t, func(items []int) *Lazy[int] {
+			b := NewLazyOrdered[int]()
+			b.InsertAll(items)
+			return b
+		}, func(b *Lazy[int]) *node[int] { return b.root })
+	})
+	t.Run("SkewBinomial", func(t *testing.T) {
+		heaptest.RunSnapshotTests[*SkewBinomial[int], *Handle[int]](t, NewSkewBinomialOrdered[int])
+		testSnapshotSharing(t, func(items []int) *SkewBinomial[int] {
+			b := NewSkewBinomialOrdered[int]()
+			b.InsertAll(items)
+			return b
+		}, func(b *SkewBinomial[int]) *node[int] { return b.root })
+	})
+	b := New()
+	b.Insert(go_heaps.Integer(2))
+	s := b.Snapshot()
+	b.Insert(go_heaps.Integer(1))
+	if res := s.FindMin(); res != go_heaps.Integer(2) {
+		t.Errorf("expected 2, got %v", res)
+	}
+}
+
+// snapshotHeap is a heap of ints with snapshots that testSnapshotSharing
+// can check.
+type snapshotHeap[H any] interface {
+	go_heaps.ExtendedHeap[int]
+	go_heaps.Validator
+	Snapshot() H
+	Sorted() iter.Seq[int]
+}
+
+// testSnapshotSharing checks that a snapshot shares the roots of a heap
+// built by fromSlice and keeps its items while the heap deletes, adjusts,
+// melds and deletes its minimum.
+func testSnapshotSharing[H snapshotHeap[H]](t *testing.T, fromSlice func(items []int) H, root func(H) *node[int]) {
+	t.Helper()
+	h := fromSlice([]int{1, 2, 3, 4, 5, 6, 7, 8})
+	s := h.Snapshot()
+	if root(h) != root(s) {
+		t.Fatal("expected the snapshot to share the roots")
+	}
+	h.Delete(6)
+	h.Adjust(3, 9)
+	h.Meld(fromSlice([]int{0, 10}))
+	h.DeleteMin()
+	if got := slices.Collect(s.Sorted()); !slices.Equal(got, []int{1, 2, 3, 4, 5, 6, 7, 8}) {
+		t.Errorf("expected the snapshot to keep %v, got %v", []int{1, 2, 3, 4, 5, 6, 7, 8}, got)
+	}
+	if got := slices.Collect(h.Sorted()); !slices.Equal(got, []int{1, 2, 4, 5, 7, 8, 9, 10}) {
+		t.Errorf("expected %v, got %v", []int{1, 2, 4, 5, 7, 8, 9, 10}, got)
+	}
+	for _, x := range []H{h, s} {
+		if err := x.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	heaptest.RunEncodingTests(t, NewOrdered[int], (*Heap[int]).MarshalBinary)
+	t.Run("Shape", func(t *testing.T) {
+		heaptest.RunEncodingTests(t, NewOrdered[int], (*Heap[int]).MarshalShape)
+	})
+	t.Run("Lazy", func(t *testing.T) {
+		heaptest.RunEncodingTests(t, NewLazyOrdered[int], (*Lazy[int]).MarshalBinary)
+		heaptest.RunEncodingTests(t, NewLazyOrdered[int], (*Lazy[int]).MarshalShape)
+	})
+	t.Run("SkewBinomial", func(t *testing.T) {
+		heaptest.RunEncodingTests(t, NewSkewBinomialOrdered[int], (*SkewBinomial[int]).MarshalBinary)
+	})
+}
+
+func TestUnmarshalShape(t *testing.T) {
+	compares := 0
+	compare := func(a, b int) int {
+		compares++
+		return a - b
+	}
+	h := NewFunc(compare)
+	for i := range 100 {
+		h.Insert(i * 7 % 100)
+	}
+	data, err := h.MarshalShape()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the trees of a Heap fit both kinds of heap as they are
+	for _, loaded := range []go_heaps.Heap[int]{NewFunc(compare), NewLazyFunc(compare)} {
+		compares = 0
+		if err := loaded.(encoding.BinaryUnmarshaler).UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		// heapdebug builds validate the heap, which compares items
+		if compares != 0 && !go_heaps.Debug {
+			t.Errorf("%T: expected no comparisons, got %d", loaded, compares)
+		}
+		if res := loaded.FindMin(); res != 0 || loaded.Len() != 100 {
+			t.Errorf("%T: expected 100 items from 0, got %d from %d", loaded, loaded.Len(), res)
+		}
+	}
+	// a lazy heap may have roots of equal degree, which a Heap rebuilds
+	lazy := NewLazyFunc(compare)
+	for i := range 4 {
+		lazy.Insert(i)
+	}
+	if data, err = lazy.MarshalShape(); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Validate(); err != nil || h.Len() != 4 {
+		t.Errorf("expected a valid heap of 4 items, got %d items: %v", h.Len(), err)
+	}
+	b := New()
+	b.Insert(go_heaps.Integer(2))
+	b.Insert(go_heaps.Integer(1))
+	if data, err = b.MarshalShape(); err != nil {
+		t.Fatal(err)
+	}
+	c := New()
+	if err := c.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if res := c.DeleteMin(); res != go_heaps.Integer(1) {
+		t.Errorf("expected 1, got %v", res)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	// binomial trees have a child of every degree below their own
+	binomial := func(n *go_heaps.TreeNode[int]) error {
+		if n.Attrs[0].Name != "degree" || n.Attrs[0].Value != len(n.Children) {
+			return fmt.Errorf("attributes %v with %d children", n.Attrs, len(n.Children))
+		}
+		for i, c := range n.Children {
+			if c.Attrs[0].Value != len(n.Children)-1-i {
+				return fmt.Errorf("child %d has attributes %v", i, c.Attrs)
+			}
+		}
+		return nil
+	}
+	heaptest.RunJSONTests(t, NewOrdered[int], binomial)
+	t.Run("Lazy", func(t *testing.T) {
+		heaptest.RunJSONTests(t, NewLazyOrdered[int], binomial)
+	})
+	t.Run("SkewBinomial", func(t *testing.T) {
+		heaptest.RunJSONTests(t, NewSkewBinomialOrdered[int], func(n *go_heaps.TreeNode[int]) error {
+			rank := n.Attrs[0].Value.(int)
+			if n.Attrs[0].Name != "rank" || len(n.Children) < rank || len(n.Children) > 2*rank {
+				return fmt.Errorf("attributes %v with %d children", n.Attrs, len(n.Children))
+			}
+			return nil
+		})
+	})
+}
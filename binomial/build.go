@@ -0,0 +1,97 @@
+package binomial
+
+import (
+	"cmp"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// FromSliceFunc returns a Heap ordered by compare that holds items.
+// The complexity is O(n).
+func FromSliceFunc[T any](items []T, compare func(a, b T) int) *Heap[T] {
+	b := NewFunc(compare)
+	b.InsertAll(items)
+	return b
+}
+
+// FromSliceOrdered returns a Heap of ordered values that holds items.
+// The complexity is O(n).
+func FromSliceOrdered[T cmp.Ordered](items []T) *Heap[T] {
+	return FromSliceFunc(items, cmp.Compare[T])
+}
+
+// FromSlice returns a BinomialHeap that holds items.
+// The complexity is O(n).
+func FromSlice(items []heap.Item) *BinomialHeap {
+	b := New()
+	b.InsertAll(items)
+	return b
+}
+
+// InsertAll inserts items into the Heap. It adds them one by one to a
+// binary counter of trees, where adding a tree to a taken degree links the
+// two and carries the result to the next degree, and then unites the
+// trees of the counter with the Heap.
+// The complexity is O(k + log n) for k items.
+func (b *Heap[T]) InsertAll(items []T) {
+	if len(items) == 0 {
+		return
+	}
+	// trees[d] is the tree of degree d in the counter or nil
+	var trees []*node[T]
+	for _, item := range items {
+		t := &node[T]{item: item, owner: b.owner}
+		d := 0
+		for ; d < len(trees) && trees[d] != nil; d++ {
+			u := trees[d]
+			trees[d] = nil
+			if b.compare(u.item, t.item) < 0 {
+				t, u = u, t
+			}
+			linkNodes(t, u)
+		}
+		if d == len(trees) {
+			trees = append(trees, t)
+		} else {
+			trees[d] = t
+		}
+	}
+	var roots, last *node[T]
+	for _, t := range trees {
+		if t == nil {
+			continue
+		}
+		if roots == nil {
+			roots = t
+		} else {
+			last.sibling = t
+		}
+		last = t
+	}
+	b.root = unionRoots(ownList(b.owner, b.root), roots, b.compare)
+	b.updateMin()
+	b.size += len(items)
+	heap.DebugValidate(b)
+}
+
+// InsertAll inserts items into the heap. Insert is O(1), so it appends
+// them to the root list one by one.
+// The complexity is O(k) for k items.
+func (b *Lazy[T]) InsertAll(items []T) {
+	for _, item := range items {
+		b.appendRoot(&node[T]{item: item, owner: b.owner})
+	}
+	b.size += len(items)
+	heap.DebugValidate(b)
+}
+
+// InsertAll inserts items into the heap. Insert is O(1) in the worst case,
+// so it skew links them in one by one.
+// The complexity is O(k) for k items.
+func (b *SkewBinomial[T]) InsertAll(items []T) {
+	for _, item := range items {
+		b.skewInsert(&node[T]{item: item, owner: b.owner})
+	}
+	b.size += len(items)
+	heap.DebugValidate(b)
+}
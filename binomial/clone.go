@@ -0,0 +1,245 @@
+package binomial
+
+// owner identifies the heap that may modify a node in place. Heaps that
+// share nodes after a Snapshot have different owners, so each of them
+// copies a shared node before it modifies it.
+type owner struct {
+	_ byte
+}
+
+// mutable returns n if o is nil, which means that the heap has no
+// snapshots, or if o owns n, and a copy of n owned by o otherwise. The
+// copy has no handle.
+func mutable[T any](o *owner, n *node[T]) *node[T] {
+	if o == nil || n.owner == o {
+		return n
+	}
+	c := *n
+	c.owner, c.handle = o, nil
+	return &c
+}
+
+// ownList returns the sibling list starting at first with every node owned
+// by o, copying the nodes o does not own.
+func ownList[T any](o *owner, first *node[T]) *node[T] {
+	if o == nil {
+		return first
+	}
+	var head, prev *node[T]
+	for n := first; n != nil; n = n.sibling {
+		c := mutable(o, n)
+		if prev == nil {
+			head = c
+		} else {
+			prev.sibling = c
+		}
+		prev = c
+	}
+	return head
+}
+
+// checkHandles panics if o is set, since handles need the parent pointers
+// that heaps with snapshots do not keep.
+func checkHandles(o *owner) {
+	if o != nil {
+		panic("handles are disabled on a heap with snapshots")
+	}
+}
+
+// findPath returns the path from the first root of the root list roots
+// along sibling and child pointers to a node holding item, or nil if there
+// is none. It skips the children of nodes greater than item, like findNode.
+func findPath[T any](roots *node[T], item T, compare func(a, b T) int) []*node[T] {
+	type visit struct {
+		n     *node[T]
+		depth int
+	}
+	var path []*node[T]
+	stack := []visit{{roots, 0}}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if v.n == nil {
+			continue
+		}
+		path = append(path[:v.depth], v.n)
+		c := compare(v.n.item, item)
+		if c == 0 {
+			return path
+		}
+		stack = append(stack, visit{v.n.sibling, v.depth + 1})
+		if c < 0 {
+			stack = append(stack, visit{v.n.child, v.depth + 1})
+		}
+	}
+	return nil
+}
+
+// copyPath replaces the nodes of a path returned by findPath with copies
+// owned by o and returns the new root list and the copy of the last node.
+// The copies point to their parents, so siftUp and bubbleUp can move an
+// item up the copied path.
+func copyPath[T any](o *owner, path []*node[T]) (roots, n *node[T]) {
+	var parent *node[T]
+	for _, x := range path {
+		c := mutable(o, x)
+		switch {
+		case n == nil:
+			roots = c
+		case n.child == x:
+			n.child, parent = c, n
+		default:
+			n.sibling = c
+		}
+		c.parent = parent
+		n = c
+	}
+	return roots, n
+}
+
+// Clone returns a deep copy of the Heap. Handles of the Heap do not refer
+// to items of the copy. The copy has no snapshots, so it supports handles
+// even if the Heap does not.
+// The complexity is O(n).
+func (b *Heap[T]) Clone() *Heap[T] {
+	c := &Heap[T]{size: b.size, cmp: b.cmp}
+	c.root, _, c.min = cloneRoots(b.root, b.min)
+	return c
+}
+
+// Snapshot returns a copy of the Heap that shares all nodes with it.
+// Afterwards both heaps copy a shared node before they modify it: Insert,
+// DeleteMin and Meld copy the O(log n) roots they link, and DeleteMin also
+// copies the children of the minimum. Shared nodes cannot point to a
+// single parent, so both heaps disable handles until they are cleared:
+// InsertHandle, Remove, DecreaseKey and IncreaseKey panic, and Delete and
+// Adjust copy the path from the first root to the item instead.
+// The complexity is O(1).
+func (b *Heap[T]) Snapshot() *Heap[T] {
+	b.owner = new(owner)
+	return &Heap[T]{root: b.root, min: b.min, size: b.size, cmp: b.cmp, owner: new(owner)}
+}
+
+// Clone returns a deep copy of the BinomialHeap.
+// The complexity is O(n).
+func (b *BinomialHeap) Clone() *BinomialHeap {
+	return &BinomialHeap{*b.Heap.Clone()}
+}
+
+// Snapshot returns a copy of the BinomialHeap that shares all nodes with
+// it, like Heap.Snapshot.
+// The complexity is O(1).
+func (b *BinomialHeap) Snapshot() *BinomialHeap {
+	return &BinomialHeap{*b.Heap.Snapshot()}
+}
+
+// Clone returns a deep copy of the heap. Handles of the heap do not refer
+// to items of the copy. The copy has no snapshots, so it supports handles
+// even if the heap does not.
+// The complexity is O(n).
+func (b *Lazy[T]) Clone() *Lazy[T] {
+	c := &Lazy[T]{size: b.size, cmp: b.cmp}
+	c.root, c.last, c.min = cloneRoots(b.root, b.min)
+	return c
+}
+
+// Snapshot returns a copy of the heap that shares all nodes with it.
+// Afterwards both heaps copy a shared node before they modify it. Insert
+// prepends new roots instead of appending them once the last root is
+// shared, and DeleteMin copies the roots and the children of the minimum
+// that it consolidates, which it visits anyway. Shared nodes cannot point
+// to a single parent, so both heaps disable handles until they are
+// cleared: InsertHandle, Remove, DecreaseKey and IncreaseKey panic, and
+// Delete and Adjust copy the path from the first root to the item instead.
+// The complexity is O(1).
+func (b *Lazy[T]) Snapshot() *Lazy[T] {
+	b.owner = new(owner)
+	return &Lazy[T]{root: b.root, last: b.last, min: b.min, size: b.size, cmp: b.cmp, owner: new(owner)}
+}
+
+// Clone returns a deep copy of the LazyBinomialHeap.
+// The complexity is O(n).
+func (b *LazyBinomialHeap) Clone() *LazyBinomialHeap {
+	return &LazyBinomialHeap{*b.Lazy.Clone()}
+}
+
+// Snapshot returns a copy of the LazyBinomialHeap that shares all nodes
+// with it, like Lazy.Snapshot.
+// The complexity is O(1).
+func (b *LazyBinomialHeap) Snapshot() *LazyBinomialHeap {
+	return &LazyBinomialHeap{*b.Lazy.Snapshot()}
+}
+
+// Clone returns a deep copy of the heap. Handles of the heap do not refer
+// to items of the copy. The copy has no snapshots, so it supports handles
+// even if the heap does not.
+// The complexity is O(n).
+func (b *SkewBinomial[T]) Clone() *SkewBinomial[T] {
+	c := &SkewBinomial[T]{size: b.size, cmp: b.cmp}
+	c.root, _, c.min = cloneRoots(b.root, b.min)
+	return c
+}
+
+// Snapshot returns a copy of the heap that shares all nodes with it.
+// Afterwards both heaps copy a shared node before they modify it: Insert
+// copies the two roots it skew links, and DeleteMin and Meld copy the
+// O(log n) roots they link and the children of the minimum. Shared nodes
+// cannot point to a single parent, so both heaps disable handles until
+// they are cleared: InsertHandle, Remove, DecreaseKey and IncreaseKey
+// panic, and Delete and Adjust copy the path from the first root to the
+// item instead.
+// The complexity is O(1).
+func (b *SkewBinomial[T]) Snapshot() *SkewBinomial[T] {
+	b.owner = new(owner)
+	return &SkewBinomial[T]{root: b.root, min: b.min, size: b.size, cmp: b.cmp, owner: new(owner)}
+}
+
+// Clone returns a deep copy of the SkewBinomialHeap.
+// The complexity is O(n).
+func (b *SkewBinomialHeap) Clone() *SkewBinomialHeap {
+	return &SkewBinomialHeap{*b.SkewBinomial.Clone()}
+}
+
+// Snapshot returns a copy of the SkewBinomialHeap that shares all nodes
+// with it, like SkewBinomial.Snapshot.
+// The complexity is O(1).
+func (b *SkewBinomialHeap) Snapshot() *SkewBinomialHeap {
+	return &SkewBinomialHeap{*b.SkewBinomial.Snapshot()}
+}
+
+// cloneRoots returns a copy of the root list starting at root together
+// with its last root and the copy of the root min.
+func cloneRoots[T any](root, min *node[T]) (first, last, minCopy *node[T]) {
+	// pairs of a node and its copy whose children are still to copy
+	var stack []*node[T]
+	first = cloneSiblings(root, nil, &stack)
+	for from, to := root, first; from != nil; from, to = from.sibling, to.sibling {
+		if from == min {
+			minCopy = to
+		}
+		last = to
+	}
+	for len(stack) > 0 {
+		from, to := stack[len(stack)-2], stack[len(stack)-1]
+		stack = stack[:len(stack)-2]
+		to.child = cloneSiblings(from.child, to, &stack)
+	}
+	return first, last, minCopy
+}
+
+// cloneSiblings returns a copy of first and its siblings, whose nodes get
+// parent, and pushes every node with its copy on stack.
+func cloneSiblings[T any](first, parent *node[T], stack *[]*node[T]) *node[T] {
+	var head, last *node[T]
+	for n := first; n != nil; n = n.sibling {
+		t := &node[T]{item: n.item, parent: parent, degree: n.degree}
+		if head == nil {
+			head = t
+		} else {
+			last.sibling = t
+		}
+		last = t
+		*stack = append(*stack, n, t)
+	}
+	return head
+}
@@ -0,0 +1,183 @@
+package binomial
+
+import (
+	"encoding"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+var (
+	// Heap implements the BinaryMarshaler and BinaryUnmarshaler interfaces
+	_ encoding.BinaryMarshaler   = (*Heap[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*Heap[int])(nil)
+	// Lazy implements the BinaryMarshaler and BinaryUnmarshaler interfaces
+	_ encoding.BinaryMarshaler   = (*Lazy[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*Lazy[int])(nil)
+	// SkewBinomial implements the BinaryMarshaler and BinaryUnmarshaler
+	// interfaces
+	_ encoding.BinaryMarshaler   = (*SkewBinomial[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*SkewBinomial[int])(nil)
+)
+
+// shapeKind is the Kind of the shape encodings of binomial heaps, which
+// Heap and Lazy both restore.
+const shapeKind = "binomial"
+
+// MarshalBinary encodes the items of the Heap in no particular order with
+// the go_heaps.Codec for T.
+// The complexity is O(n).
+func (b *Heap[T]) MarshalBinary() ([]byte, error) {
+	return heap.MarshalItems(b.All())
+}
+
+// MarshalShape encodes the items of the Heap together with its binomial
+// trees, which UnmarshalBinary of a Heap or Lazy heap restores without
+// comparing items.
+// The complexity is O(n).
+func (b *Heap[T]) MarshalShape() ([]byte, error) {
+	return marshalShape(b.roots, b.min)
+}
+
+// UnmarshalBinary replaces the items of the Heap with the items encoded in
+// data, keeping its order. It restores the trees of an encoding made by
+// MarshalShape if their degrees increase, and otherwise builds itself with
+// InsertAll. Restored trees are trusted to be ordered like the Heap.
+// The complexity is O(n).
+func (b *Heap[T]) UnmarshalBinary(data []byte) error {
+	var e heap.Encoding[T]
+	if err := e.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	b.Clear()
+	if roots, min, ok := decodeShape(&e); ok && increasing(roots) {
+		if len(roots) > 0 {
+			b.root = roots[0]
+		}
+		b.min, b.size = min, len(e.Items)
+		heap.DebugValidate(b)
+		return nil
+	}
+	b.InsertAll(e.Items)
+	return nil
+}
+
+// MarshalBinary encodes the items of the heap in no particular order with
+// the go_heaps.Codec for T.
+// The complexity is O(n).
+func (b *Lazy[T]) MarshalBinary() ([]byte, error) {
+	return heap.MarshalItems(b.All())
+}
+
+// MarshalShape encodes the items of the heap together with its binomial
+// trees, which UnmarshalBinary of a Heap or Lazy heap restores without
+// comparing items.
+// The complexity is O(n).
+func (b *Lazy[T]) MarshalShape() ([]byte, error) {
+	return marshalShape(b.roots, b.min)
+}
+
+// UnmarshalBinary replaces the items of the heap with the items encoded in
+// data, keeping its order. It restores the trees of an encoding made by
+// MarshalShape and otherwise builds itself with InsertAll. Restored trees
+// are trusted to be ordered like the heap.
+// The complexity is O(n).
+func (b *Lazy[T]) UnmarshalBinary(data []byte) error {
+	var e heap.Encoding[T]
+	if err := e.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	b.Clear()
+	if roots, min, ok := decodeShape(&e); ok {
+		if len(roots) > 0 {
+			b.root, b.last = roots[0], roots[len(roots)-1]
+		}
+		b.min, b.size = min, len(e.Items)
+		heap.DebugValidate(b)
+		return nil
+	}
+	b.InsertAll(e.Items)
+	return nil
+}
+
+// MarshalBinary encodes the items of the heap in no particular order with
+// the go_heaps.Codec for T.
+// The complexity is O(n).
+func (b *SkewBinomial[T]) MarshalBinary() ([]byte, error) {
+	return heap.MarshalItems(b.All())
+}
+
+// UnmarshalBinary replaces the items of the heap with the items encoded in
+// data. The heap keeps its order and builds itself with InsertAll.
+// The complexity is O(n).
+func (b *SkewBinomial[T]) UnmarshalBinary(data []byte) error {
+	items, err := heap.UnmarshalItems[T](data)
+	if err != nil {
+		return err
+	}
+	b.Clear()
+	b.InsertAll(items)
+	return nil
+}
+
+// marshalShape encodes the trees whose roots visits, keeping the position
+// of min among the roots in the parameters.
+func marshalShape[T any](roots func(visit func(n *node[T])), min *node[T]) ([]byte, error) {
+	e := heap.Encoding[T]{Kind: shapeKind}
+	i := 0
+	roots(func(n *node[T]) {
+		if n == min {
+			e.Params = []int{i}
+		}
+		i++
+	})
+	e.Items, e.Shape = heap.EncodeForest(roots, children[T], itemOf[T])
+	return e.MarshalBinary()
+}
+
+// decodeShape rebuilds the trees of e and returns their roots and the root
+// holding the smallest item. It reports false if e does not encode
+// binomial trees, in which a node of degree k has children of degree k-1,
+// ..., 0.
+func decodeShape[T any](e *heap.Encoding[T]) (roots []*node[T], min *node[T], ok bool) {
+	if e.Kind != shapeKind || len(e.Items) == 0 {
+		return nil, nil, e.Kind == shapeKind
+	}
+	nodes := make([]*node[T], len(e.Items))
+	for i, item := range e.Items {
+		nodes[i] = &node[T]{item: item, degree: e.Shape[i]}
+	}
+	ok = true
+	err := heap.DecodeForest(e.Shape, func(i, parent, prev int) {
+		n := nodes[i]
+		if parent < 0 {
+			if prev >= 0 {
+				nodes[prev].sibling = n
+			}
+			roots = append(roots, n)
+			return
+		}
+		n.parent = nodes[parent]
+		degree := n.parent.degree - 1
+		if prev < 0 {
+			n.parent.child = n
+		} else {
+			nodes[prev].sibling = n
+			degree = nodes[prev].degree - 1
+		}
+		ok = ok && n.degree == degree
+	})
+	if err != nil || !ok || len(e.Params) != 1 || e.Params[0] < 0 || e.Params[0] >= len(roots) {
+		return nil, nil, false
+	}
+	return roots, roots[e.Params[0]], true
+}
+
+// increasing reports whether the degrees of roots strictly increase.
+func increasing[T any](roots []*node[T]) bool {
+	for i := 1; i < len(roots); i++ {
+		if roots[i].degree <= roots[i-1].degree {
+			return false
+		}
+	}
+	return true
+}
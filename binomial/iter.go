@@ -0,0 +1,121 @@
+package binomial
+
+import (
+	"iter"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// Heap implements the Iterable interface
+var _ heap.Iterable[int] = (*Heap[int])(nil)
+
+// Lazy implements the Iterable interface
+var _ heap.Iterable[int] = (*Lazy[int])(nil)
+
+// SkewBinomial implements the Iterable interface
+var _ heap.Iterable[int] = (*SkewBinomial[int])(nil)
+
+// Do calls it on each item of the heap in no particular order until it
+// returns false.
+// The complexity is O(n).
+func (b *Heap[T]) Do(it func(item T) bool) {
+	heap.DoForest(b.roots, children[T], itemOf[T], it)
+}
+
+// Ascend calls it on each item of the heap in ascending order until it
+// returns false, without modifying the heap.
+// The complexity is O(n log n) to visit all n items.
+func (b *Heap[T]) Ascend(it func(item T) bool) {
+	heap.AscendForest(b.roots, children[T], itemOf[T], b.compare, it)
+}
+
+// All returns an iterator over the items of the heap in no particular
+// order.
+func (b *Heap[T]) All() iter.Seq[T] {
+	return b.Do
+}
+
+// Sorted returns an iterator over the items of the heap in ascending
+// order.
+func (b *Heap[T]) Sorted() iter.Seq[T] {
+	return b.Ascend
+}
+
+// Do calls it on each item of the heap in no particular order until it
+// returns false.
+// The complexity is O(n).
+func (b *Lazy[T]) Do(it func(item T) bool) {
+	heap.DoForest(b.roots, children[T], itemOf[T], it)
+}
+
+// Ascend calls it on each item of the heap in ascending order until it
+// returns false, without modifying the heap.
+// The complexity is O(n log n) to visit all n items.
+func (b *Lazy[T]) Ascend(it func(item T) bool) {
+	heap.AscendForest(b.roots, children[T], itemOf[T], b.compare, it)
+}
+
+// All returns an iterator over the items of the heap in no particular
+// order.
+func (b *Lazy[T]) All() iter.Seq[T] {
+	return b.Do
+}
+
+// Sorted returns an iterator over the items of the heap in ascending
+// order.
+func (b *Lazy[T]) Sorted() iter.Seq[T] {
+	return b.Ascend
+}
+
+// Do calls it on each item of the heap in no particular order until it
+// returns false.
+// The complexity is O(n).
+func (b *SkewBinomial[T]) Do(it func(item T) bool) {
+	heap.DoForest(b.roots, children[T], itemOf[T], it)
+}
+
+// Ascend calls it on each item of the heap in ascending order until it
+// returns false, without modifying the heap.
+// The complexity is O(n log n) to visit all n items.
+func (b *SkewBinomial[T]) Ascend(it func(item T) bool) {
+	heap.AscendForest(b.roots, children[T], itemOf[T], b.compare, it)
+}
+
+// All returns an iterator over the items of the heap in no particular
+// order.
+func (b *SkewBinomial[T]) All() iter.Seq[T] {
+	return b.Do
+}
+
+// Sorted returns an iterator over the items of the heap in ascending
+// order.
+func (b *SkewBinomial[T]) Sorted() iter.Seq[T] {
+	return b.Ascend
+}
+
+func (b *Heap[T]) roots(visit func(n *node[T])) {
+	siblings(b.root, visit)
+}
+
+func (b *Lazy[T]) roots(visit func(n *node[T])) {
+	siblings(b.root, visit)
+}
+
+func (b *SkewBinomial[T]) roots(visit func(n *node[T])) {
+	siblings(b.root, visit)
+}
+
+func children[T any](n *node[T], visit func(c *node[T])) {
+	siblings(n.child, visit)
+}
+
+// siblings visits first and the siblings that follow it.
+func siblings[T any](first *node[T], visit func(n *node[T])) {
+	for n := first; n != nil; n = n.sibling {
+		visit(n)
+	}
+}
+
+func itemOf[T any](n *node[T]) T {
+	return n.item
+}
@@ -0,0 +1,117 @@
+package binomial
+
+import (
+	"encoding/json"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+var (
+	// Heap implements the Marshaler and Unmarshaler interfaces
+	_ json.Marshaler   = (*Heap[int])(nil)
+	_ json.Unmarshaler = (*Heap[int])(nil)
+	// Lazy implements the Marshaler and Unmarshaler interfaces
+	_ json.Marshaler   = (*Lazy[int])(nil)
+	_ json.Unmarshaler = (*Lazy[int])(nil)
+	// SkewBinomial implements the Marshaler and Unmarshaler interfaces
+	_ json.Marshaler   = (*SkewBinomial[int])(nil)
+	_ json.Unmarshaler = (*SkewBinomial[int])(nil)
+	// Heap, Lazy and SkewBinomial have a tree view
+	_ heap.TreeViewer[int] = (*Heap[int])(nil)
+	_ heap.TreeViewer[int] = (*Lazy[int])(nil)
+	_ heap.TreeViewer[int] = (*SkewBinomial[int])(nil)
+)
+
+// MarshalJSON encodes the items of the Heap in no particular order as a
+// go_heaps.JSONView without the tree view.
+// The complexity is O(n).
+func (b *Heap[T]) MarshalJSON() ([]byte, error) {
+	return heap.ViewOf[T](b, false).MarshalJSON()
+}
+
+// UnmarshalJSON replaces the items of the Heap with the items of the
+// go_heaps.JSONView encoded in data. The Heap keeps its order and builds
+// itself with InsertAll.
+// The complexity is O(n).
+func (b *Heap[T]) UnmarshalJSON(data []byte) error {
+	var v heap.JSONView[T]
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	b.Clear()
+	b.InsertAll(v.Items)
+	return nil
+}
+
+// Tree returns the binomial trees of the Heap in the order of the root
+// list. Every node has its "degree".
+// The complexity is O(n).
+func (b *Heap[T]) Tree() []*heap.TreeNode[T] {
+	return heap.TreeOf(b.roots, children[T], itemOf[T], attrs[T]("degree"))
+}
+
+// MarshalJSON encodes the items of the heap in no particular order as a
+// go_heaps.JSONView without the tree view.
+// The complexity is O(n).
+func (b *Lazy[T]) MarshalJSON() ([]byte, error) {
+	return heap.ViewOf[T](b, false).MarshalJSON()
+}
+
+// UnmarshalJSON replaces the items of the heap with the items of the
+// go_heaps.JSONView encoded in data. The heap keeps its order and builds
+// itself with InsertAll.
+// The complexity is O(n).
+func (b *Lazy[T]) UnmarshalJSON(data []byte) error {
+	var v heap.JSONView[T]
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	b.Clear()
+	b.InsertAll(v.Items)
+	return nil
+}
+
+// Tree returns the binomial trees of the heap in the order of the root
+// list, which may hold several trees of a degree. Every node has its
+// "degree".
+// The complexity is O(n).
+func (b *Lazy[T]) Tree() []*heap.TreeNode[T] {
+	return heap.TreeOf(b.roots, children[T], itemOf[T], attrs[T]("degree"))
+}
+
+// MarshalJSON encodes the items of the heap in no particular order as a
+// go_heaps.JSONView without the tree view.
+// The complexity is O(n).
+func (b *SkewBinomial[T]) MarshalJSON() ([]byte, error) {
+	return heap.ViewOf[T](b, false).MarshalJSON()
+}
+
+// UnmarshalJSON replaces the items of the heap with the items of the
+// go_heaps.JSONView encoded in data. The heap keeps its order and builds
+// itself with InsertAll.
+// The complexity is O(n).
+func (b *SkewBinomial[T]) UnmarshalJSON(data []byte) error {
+	var v heap.JSONView[T]
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	b.Clear()
+	b.InsertAll(v.Items)
+	return nil
+}
+
+// Tree returns the skew binomial trees of the heap by increasing rank.
+// Every node has its "rank"; the extra children that skew links added
+// have rank 0.
+// The complexity is O(n).
+func (b *SkewBinomial[T]) Tree() []*heap.TreeNode[T] {
+	return heap.TreeOf(b.roots, children[T], itemOf[T], attrs[T]("rank"))
+}
+
+// attrs returns the attributes of a node, which show its degree under
+// name.
+func attrs[T any](name string) func(n *node[T]) []heap.Attr {
+	return func(n *node[T]) []heap.Attr {
+		return []heap.Attr{{Name: name, Value: n.degree}}
+	}
+}
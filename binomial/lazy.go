@@ -0,0 +1,398 @@
+package binomial
+
+import (
+	"cmp"
+	"fmt"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// LazyBinomialHeap implements the Extended interface
+var _ heap.Extended = (*LazyBinomialHeap)(nil)
+
+// Lazy implements the ExtendedHeap interface
+var _ heap.ExtendedHeap[int] = (*Lazy[int])(nil)
+
+// Lazy implements the Validator interface
+var _ heap.Validator = (*Lazy[int])(nil)
+
+// Lazy is a lazy binomial heap over values of type T. It keeps an unordered
+// list of binomial trees, so Insert and Meld only add trees to the list.
+// DeleteMin consolidates the list by linking trees of equal degree until
+// every degree appears at most once.
+// The zero value for Lazy is an empty heap ordered by go_heaps.Compare.
+type Lazy[T any] struct {
+	// root and last are the head and tail of the root list
+	root, last *node[T]
+	// min is the root holding the smallest item
+	min  *node[T]
+	size int
+	cmp  func(a, b T) int
+	// owner is set once the heap has snapshots
+	owner *owner
+}
+
+// LazyBinomialHeap is an implementation of a lazy Binomial Heap.
+type LazyBinomialHeap struct {
+	Lazy[heap.Item]
+}
+
+// NewLazy returns an empty LazyBinomialHeap.
+func NewLazy() *LazyBinomialHeap { return &LazyBinomialHeap{} }
+
+// NewLazyWithComparator returns an empty LazyBinomialHeap ordered by
+// compare instead of Item.Compare.
+func NewLazyWithComparator(compare func(a, b heap.Item) int) *LazyBinomialHeap {
+	b := &LazyBinomialHeap{}
+	b.cmp = compare
+	return b
+}
+
+// NewLazyFunc returns an empty Lazy heap ordered by compare.
+func NewLazyFunc[T any](compare func(a, b T) int) *Lazy[T] {
+	return &Lazy[T]{cmp: compare}
+}
+
+// NewLazyOrdered returns an empty Lazy heap of ordered values.
+func NewLazyOrdered[T cmp.Ordered]() *Lazy[T] { return NewLazyFunc(cmp.Compare[T]) }
+
+func (b *Lazy[T]) compare(x, y T) int {
+	if b.cmp == nil {
+		return heap.Compare(x, y)
+	}
+	return b.cmp(x, y)
+}
+
+// Insert inserts the value to the heap and returns the item.
+// The complexity is O(1).
+func (b *Lazy[T]) Insert(v T) T {
+	b.insert(&node[T]{item: v, owner: b.owner})
+	return v
+}
+
+// InsertHandle inserts the value to the heap and returns a Handle to it
+// that can be passed to DecreaseKey, IncreaseKey and Remove.
+// The complexity is O(1).
+func (b *Lazy[T]) InsertHandle(v T) *Handle[T] {
+	checkHandles(b.owner)
+	n := &node[T]{item: v}
+	h := &Handle[T]{n: n}
+	n.handle = h
+	b.insert(n)
+	return h
+}
+
+// insert adds the single node n to the root list.
+func (b *Lazy[T]) insert(n *node[T]) {
+	b.appendRoot(n)
+	b.size++
+	heap.DebugValidate(b)
+}
+
+// appendRoot appends the tree rooted at n, which the heap owns, to the
+// root list. If the last root is shared with a snapshot, it prepends the
+// tree instead.
+func (b *Lazy[T]) appendRoot(n *node[T]) {
+	switch {
+	case b.root == nil:
+		b.root, b.last = n, n
+	case b.owner != nil && b.last.owner != b.owner:
+		n.sibling = b.root
+		b.root = n
+	default:
+		b.last.sibling = n
+		b.last = n
+	}
+	if b.min == nil || b.compare(n.item, b.min.item) < 0 {
+		b.min = n
+	}
+}
+
+// DeleteMin removes the smallest item from the heap and returns it.
+// The complexity is O(log n) amortized.
+func (b *Lazy[T]) DeleteMin() T {
+	if b.min == nil {
+		var zero T
+		return zero
+	}
+	min := b.min
+	b.removeRoot(min)
+	heap.DebugValidate(b)
+	return min.item
+}
+
+// removeRoot drops the root r from the heap and consolidates the remaining
+// roots and the children of r. On a heap with snapshots it copies the
+// roots it does not own before linking them.
+func (b *Lazy[T]) removeRoot(r *node[T]) {
+	var degrees []*node[T]
+	add := func(t *node[T]) {
+		t = mutable(b.owner, t)
+		t.parent, t.sibling = nil, nil
+		for t.degree < len(degrees) && degrees[t.degree] != nil {
+			u := degrees[t.degree]
+			degrees[t.degree] = nil
+			if b.compare(u.item, t.item) < 0 {
+				t, u = u, t
+			}
+			linkNodes(t, u)
+		}
+		for t.degree >= len(degrees) {
+			degrees = append(degrees, nil)
+		}
+		degrees[t.degree] = t
+	}
+	for t := b.root; t != nil; {
+		next := t.sibling
+		if t != r {
+			add(t)
+		}
+		t = next
+	}
+	for c := r.child; c != nil; {
+		next := c.sibling
+		add(c)
+		c = next
+	}
+	if b.owner == nil {
+		r.child, r.sibling = nil, nil
+	}
+
+	b.root, b.last, b.min = nil, nil, nil
+	for _, t := range degrees {
+		if t != nil {
+			b.appendRoot(t)
+		}
+	}
+	b.size--
+}
+
+// FindMin returns the smallest item in the heap.
+// The complexity is O(1).
+func (b *Lazy[T]) FindMin() T {
+	if b.min == nil {
+		var zero T
+		return zero
+	}
+	return b.min.item
+}
+
+// DecreaseKey replaces the item referenced by h with a smaller item.
+// The complexity is O(log n).
+func (b *Lazy[T]) DecreaseKey(h *Handle[T], v T) {
+	checkHandles(b.owner)
+	if b.compare(v, h.n.item) > 0 {
+		panic("new item is greater than the previous one")
+	}
+	b.decrease(h.n, v)
+}
+
+// decrease replaces the item of n with the smaller v and sifts it up.
+func (b *Lazy[T]) decrease(n *node[T], v T) {
+	n.item = v
+	n = siftUp(n, b.compare)
+	if b.compare(n.item, b.min.item) < 0 {
+		b.min = n
+	}
+	heap.DebugValidate(b)
+}
+
+// IncreaseKey replaces the item referenced by h with a greater item.
+// The complexity is O(log n) amortized.
+func (b *Lazy[T]) IncreaseKey(h *Handle[T], v T) {
+	checkHandles(b.owner)
+	if b.compare(v, h.n.item) < 0 {
+		panic("new item is smaller than the previous one")
+	}
+	b.increase(h.n, v)
+}
+
+// increase removes n and inserts v in a new node, which takes over the
+// handle of n.
+func (b *Lazy[T]) increase(n *node[T], v T) {
+	h := n.handle
+	b.remove(n)
+	n = &node[T]{item: v, handle: h, owner: b.owner}
+	if h != nil {
+		h.n = n
+	}
+	b.insert(n)
+}
+
+// Remove deletes the item referenced by h from the heap and returns it.
+// The complexity is O(log n) amortized.
+func (b *Lazy[T]) Remove(h *Handle[T]) T {
+	checkHandles(b.owner)
+	return b.remove(h.n)
+}
+
+// remove bubbles the item of n up to the root of its tree and drops that
+// root from the heap.
+func (b *Lazy[T]) remove(n *node[T]) T {
+	item := n.item
+	b.removeRoot(bubbleUp(n))
+	heap.DebugValidate(b)
+	return item
+}
+
+// Delete removes item from the heap and returns it.
+// It returns the zero value of T if the item is not found.
+// The complexity is O(n) to find the item plus O(log n) amortized.
+func (b *Lazy[T]) Delete(item T) T {
+	found := b.find(item)
+	if found == nil {
+		var zero T
+		return zero
+	}
+	return b.remove(found)
+}
+
+// Adjust replaces the item old with new and returns new.
+// It returns the zero value of T if old is not found.
+// The complexity is O(n) to find the item plus O(log n) amortized.
+func (b *Lazy[T]) Adjust(old, new T) T {
+	found := b.find(old)
+	if found == nil {
+		var zero T
+		return zero
+	}
+	if b.compare(new, found.item) <= 0 {
+		b.decrease(found, new)
+	} else {
+		b.increase(found, new)
+	}
+	return new
+}
+
+// find returns the node holding item or nil if there is none. On a heap
+// with snapshots it copies the path to the node first, so that decrease
+// and remove can move its item up.
+func (b *Lazy[T]) find(item T) *node[T] {
+	if b.owner == nil {
+		return findNode(b.root, item, b.compare)
+	}
+	path := findPath(b.root, item, b.compare)
+	if path == nil {
+		return nil
+	}
+	var n *node[T]
+	b.root, n = copyPath(b.owner, path)
+	b.last, b.min = nil, nil
+	for t := b.root; t != nil; t = t.sibling {
+		if b.min == nil || b.compare(t.item, b.min.item) < 0 {
+			b.min = t
+		}
+		b.last = t
+	}
+	return n
+}
+
+// Meld moves all items of a into the heap by appending its root list.
+// If a has snapshots and the heap does not, Meld melds a Clone of a, so
+// that the heap keeps its handles; this takes O(m). If the last roots of
+// both heaps are shared with snapshots, Meld prepends copies of the r
+// roots of a instead; this takes O(r).
+// The complexity is O(1).
+func (b *Lazy[T]) Meld(a heap.Heap[T]) heap.Heap[T] {
+	if a == nil {
+		return b
+	}
+	switch o := a.(type) {
+	case *Lazy[T]:
+		if o == b || o.root == nil {
+			return b
+		}
+		src := o
+		if o.owner != nil && b.owner == nil {
+			src = o.Clone()
+		}
+		switch {
+		case b.root == nil:
+			b.root, b.last, b.min = src.root, src.last, src.min
+		case b.owner == nil || b.last.owner == b.owner:
+			b.last.sibling = src.root
+			b.last = src.last
+		case src.owner == nil || src.last.owner == src.owner:
+			src.last.sibling = b.root
+			b.root = src.root
+		default:
+			for t := src.root; t != nil; t = t.sibling {
+				c := mutable(b.owner, t)
+				c.sibling = b.root
+				b.root = c
+				if t == src.min {
+					src.min = c
+				}
+			}
+		}
+		if b.compare(src.min.item, b.min.item) < 0 {
+			b.min = src.min
+		}
+		b.size += o.size
+		o.Clear()
+		heap.DebugValidate(b)
+	default:
+		panic(fmt.Sprintf("unexpected type %T", a))
+	}
+	return b
+}
+
+// Meld moves all items of a into the heap by appending its root list.
+// The complexity is O(1).
+func (b *LazyBinomialHeap) Meld(a heap.Interface) heap.Interface {
+	if a == nil {
+		return b
+	}
+	switch o := a.(type) {
+	case *LazyBinomialHeap:
+		b.Lazy.Meld(&o.Lazy)
+	default:
+		panic(fmt.Sprintf("unexpected type %T", a))
+	}
+	return b
+}
+
+// Clear resets the heap.
+func (b *Lazy[T]) Clear() {
+	b.root, b.last, b.min = nil, nil, nil
+	b.size = 0
+	b.owner = nil
+}
+
+// Len returns the number of items in the heap.
+// The complexity is O(1).
+func (b *Lazy[T]) Len() int {
+	return b.size
+}
+
+// IsEmpty returns true if the heap has no items.
+// The complexity is O(1).
+func (b *Lazy[T]) IsEmpty() bool {
+	return b.root == nil
+}
+
+// Validate checks that every root is the root of a binomial tree, whose
+// children point to their parents unless the heap has snapshots, that last
+// is the tail of the root list, that min holds the smallest root and that
+// the heap holds Len items.
+// The complexity is O(n).
+func (b *Lazy[T]) Validate() error {
+	count := 0
+	var last *node[T]
+	for root := b.root; root != nil; root = root.sibling {
+		if root.parent != nil {
+			return fmt.Errorf("binomial: root %v has a parent", root.item)
+		}
+		if err := validateTree(root, &count, b.size, b.compare, b.owner != nil); err != nil {
+			return err
+		}
+		last = root
+	}
+	if last != b.last {
+		return fmt.Errorf("binomial: last does not point to the tail of the root list")
+	}
+	if count != b.size {
+		return fmt.Errorf("binomial: heap has %d nodes but size %d", count, b.size)
+	}
+	return validateMin(b.root, b.min, b.compare)
+}
@@ -0,0 +1,477 @@
+package binomial
+
+import (
+	"cmp"
+	"fmt"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// SkewBinomialHeap implements the Extended interface
+var _ heap.Extended = (*SkewBinomialHeap)(nil)
+
+// SkewBinomial implements the ExtendedHeap interface
+var _ heap.ExtendedHeap[int] = (*SkewBinomial[int])(nil)
+
+// SkewBinomial implements the Validator interface
+var _ heap.Validator = (*SkewBinomial[int])(nil)
+
+// SkewBinomial is a skew binomial heap over values of type T. Its root list
+// is sorted by increasing rank, which is kept in degree, and only the first
+// two trees may have the same rank. Insert skew links a new node with these
+// two trees instead of carrying through the list, so it never links more
+// than once.
+//
+// A tree of rank r has children of rank r-1, ..., 0 like a binomial tree,
+// plus up to r extra children of rank 0 that skew links added.
+// The zero value for SkewBinomial is an empty heap ordered by
+// go_heaps.Compare.
+//
+// Reference: G. S. Brodal and C. Okasaki, "Optimal Purely Functional
+// Priority Queues", Journal of Functional Programming 6(6), 1996.
+type SkewBinomial[T any] struct {
+	root *node[T]
+	// min is the root holding the smallest item
+	min  *node[T]
+	size int
+	cmp  func(a, b T) int
+	// owner is set once the heap has snapshots
+	owner *owner
+}
+
+// SkewBinomialHeap is an implementation of a skew Binomial Heap.
+type SkewBinomialHeap struct {
+	SkewBinomial[heap.Item]
+}
+
+// NewSkewBinomial returns an empty SkewBinomialHeap.
+func NewSkewBinomial() *SkewBinomialHeap { return &SkewBinomialHeap{} }
+
+// NewSkewBinomialWithComparator returns an empty SkewBinomialHeap ordered
+// by compare instead of Item.Compare.
+func NewSkewBinomialWithComparator(compare func(a, b heap.Item) int) *SkewBinomialHeap {
+	b := &SkewBinomialHeap{}
+	b.cmp = compare
+	return b
+}
+
+// NewSkewBinomialFunc returns an empty SkewBinomial heap ordered by compare.
+func NewSkewBinomialFunc[T any](compare func(a, b T) int) *SkewBinomial[T] {
+	return &SkewBinomial[T]{cmp: compare}
+}
+
+// NewSkewBinomialOrdered returns an empty SkewBinomial heap of ordered
+// values.
+func NewSkewBinomialOrdered[T cmp.Ordered]() *SkewBinomial[T] {
+	return NewSkewBinomialFunc(cmp.Compare[T])
+}
+
+func (b *SkewBinomial[T]) compare(x, y T) int {
+	if b.cmp == nil {
+		return heap.Compare(x, y)
+	}
+	return b.cmp(x, y)
+}
+
+// link makes the root with the greater item a child of the other one and
+// returns the new root.
+func (b *SkewBinomial[T]) link(x, y *node[T]) *node[T] {
+	if b.compare(y.item, x.item) < 0 {
+		x, y = y, x
+	}
+	linkNodes(x, y)
+	return x
+}
+
+// Insert inserts the value to the heap and returns the item.
+// The complexity is O(1) in the worst case.
+func (b *SkewBinomial[T]) Insert(v T) T {
+	b.insert(&node[T]{item: v, owner: b.owner})
+	return v
+}
+
+// InsertHandle inserts the value to the heap and returns a Handle to it
+// that can be passed to DecreaseKey, IncreaseKey and Remove.
+// The complexity is O(1) in the worst case.
+func (b *SkewBinomial[T]) InsertHandle(v T) *Handle[T] {
+	checkHandles(b.owner)
+	n := &node[T]{item: v}
+	h := &Handle[T]{n: n}
+	n.handle = h
+	b.insert(n)
+	return h
+}
+
+func (b *SkewBinomial[T]) insert(n *node[T]) {
+	b.skewInsert(n)
+	b.size++
+	heap.DebugValidate(b)
+}
+
+// skewInsert adds the single node n, which the heap owns, to the root
+// list. If the first two trees have the same rank they are linked and n
+// becomes an extra child of rank 0, swapping items with the root if it is
+// smaller.
+func (b *SkewBinomial[T]) skewInsert(n *node[T]) {
+	first := b.root
+	linked := false
+	if first == nil || first.sibling == nil || first.degree != first.sibling.degree {
+		n.sibling = b.root
+		b.root = n
+	} else {
+		second := first.sibling
+		rest := second.sibling
+		linked = b.min == first || b.min == second
+		first, second = mutable(b.owner, first), mutable(b.owner, second)
+		first.sibling, second.sibling = nil, nil
+		t := b.link(first, second)
+		if b.compare(n.item, t.item) <= 0 {
+			n.swapItems(t)
+		}
+		n.parent = t
+		n.sibling = t.child
+		t.child = n
+		t.sibling = rest
+		b.root = t
+	}
+	// the old min may have been linked below the new root, which then
+	// holds an item that is not greater
+	if b.min == nil || linked || b.compare(b.root.item, b.min.item) < 0 {
+		b.min = b.root
+	}
+}
+
+// normalize links the first two trees of roots if they have the same rank,
+// carrying on until every rank appears at most once.
+func (b *SkewBinomial[T]) normalize(roots *node[T]) *node[T] {
+	if roots == nil || roots.sibling == nil || roots.sibling.degree != roots.degree {
+		return roots
+	}
+	t, rest := roots, roots.sibling
+	for rest != nil && rest.degree == t.degree {
+		next := rest.sibling
+		t, rest = mutable(b.owner, t), mutable(b.owner, rest)
+		t.sibling, rest.sibling = nil, nil
+		t = b.link(t, rest)
+		rest = next
+	}
+	t.sibling = rest
+	return t
+}
+
+// updateMin finds the root holding the smallest item.
+func (b *SkewBinomial[T]) updateMin() {
+	b.min = b.root
+	for next := b.root; next != nil; next = next.sibling {
+		if b.compare(next.item, b.min.item) < 0 {
+			b.min = next
+		}
+	}
+}
+
+// DeleteMin removes the smallest item from the heap and returns it.
+// The complexity is O(log n).
+func (b *SkewBinomial[T]) DeleteMin() T {
+	if b.min == nil {
+		var zero T
+		return zero
+	}
+	if b.owner != nil {
+		b.root = ownList(b.owner, b.root)
+		b.updateMin()
+	}
+	min := b.min
+	b.removeRoot(min)
+	heap.DebugValidate(b)
+	return min.item
+}
+
+// removeRoot drops the root r, which the heap owns, from the heap. The
+// children of r with a rank above 0 form a root list that is united with
+// the heap, while the children of rank 0 are inserted again one by one.
+func (b *SkewBinomial[T]) removeRoot(r *node[T]) {
+	b.root = ownList(b.owner, b.root)
+	r.child = ownList(b.owner, r.child)
+	var prev *node[T]
+	for curr := b.root; curr != r; curr = curr.sibling {
+		prev = curr
+	}
+	if prev == nil {
+		b.root = r.sibling
+	} else {
+		prev.sibling = r.sibling
+	}
+	r.sibling = nil
+
+	var trees, singles *node[T]
+	for c := detachChildren(r); c != nil; {
+		next := c.sibling
+		if c.degree == 0 {
+			c.sibling = singles
+			singles = c
+		} else {
+			c.sibling = trees
+			trees = c
+		}
+		c = next
+	}
+	// detachChildren reversed the children, so reverse the trees back to
+	// increasing rank
+	var children *node[T]
+	for trees != nil {
+		next := trees.sibling
+		trees.sibling = children
+		children = trees
+		trees = next
+	}
+	b.root = unionRoots(b.normalize(b.root), children, b.compare)
+	b.min = nil
+	b.updateMin()
+	for singles != nil {
+		next := singles.sibling
+		singles.sibling = nil
+		b.skewInsert(singles)
+		singles = next
+	}
+	b.size--
+}
+
+// FindMin returns the smallest item in the heap.
+// The complexity is O(1).
+func (b *SkewBinomial[T]) FindMin() T {
+	if b.min == nil {
+		var zero T
+		return zero
+	}
+	return b.min.item
+}
+
+// DecreaseKey replaces the item referenced by h with a smaller item.
+// The complexity is O(log n).
+func (b *SkewBinomial[T]) DecreaseKey(h *Handle[T], v T) {
+	checkHandles(b.owner)
+	if b.compare(v, h.n.item) > 0 {
+		panic("new item is greater than the previous one")
+	}
+	b.decrease(h.n, v)
+}
+
+// decrease replaces the item of n with the smaller v and sifts it up.
+func (b *SkewBinomial[T]) decrease(n *node[T], v T) {
+	n.item = v
+	n = siftUp(n, b.compare)
+	if b.compare(n.item, b.min.item) < 0 {
+		b.min = n
+	}
+	heap.DebugValidate(b)
+}
+
+// IncreaseKey replaces the item referenced by h with a greater item.
+// The complexity is O(log n).
+func (b *SkewBinomial[T]) IncreaseKey(h *Handle[T], v T) {
+	checkHandles(b.owner)
+	if b.compare(v, h.n.item) < 0 {
+		panic("new item is smaller than the previous one")
+	}
+	b.increase(h.n, v)
+}
+
+// increase removes n and inserts v in a new node, which takes over the
+// handle of n.
+func (b *SkewBinomial[T]) increase(n *node[T], v T) {
+	h := n.handle
+	b.remove(n)
+	n = &node[T]{item: v, handle: h, owner: b.owner}
+	if h != nil {
+		h.n = n
+	}
+	b.insert(n)
+}
+
+// Remove deletes the item referenced by h from the heap and returns it.
+// The complexity is O(log n).
+func (b *SkewBinomial[T]) Remove(h *Handle[T]) T {
+	checkHandles(b.owner)
+	return b.remove(h.n)
+}
+
+// remove bubbles the item of n up to the root of its tree and drops that
+// root from the heap.
+func (b *SkewBinomial[T]) remove(n *node[T]) T {
+	item := n.item
+	b.removeRoot(bubbleUp(n))
+	heap.DebugValidate(b)
+	return item
+}
+
+// Delete removes item from the heap and returns it.
+// It returns the zero value of T if the item is not found.
+// The complexity is O(n) to find the item plus O(log n).
+func (b *SkewBinomial[T]) Delete(item T) T {
+	found := b.find(item)
+	if found == nil {
+		var zero T
+		return zero
+	}
+	return b.remove(found)
+}
+
+// Adjust replaces the item old with new and returns new.
+// It returns the zero value of T if old is not found.
+// The complexity is O(n) to find the item plus O(log n).
+func (b *SkewBinomial[T]) Adjust(old, new T) T {
+	found := b.find(old)
+	if found == nil {
+		var zero T
+		return zero
+	}
+	if b.compare(new, found.item) <= 0 {
+		b.decrease(found, new)
+	} else {
+		b.increase(found, new)
+	}
+	return new
+}
+
+// find returns the node holding item or nil if there is none. On a heap
+// with snapshots it copies the path to the node first, so that decrease
+// and remove can move its item up.
+func (b *SkewBinomial[T]) find(item T) *node[T] {
+	if b.owner == nil {
+		return findNode(b.root, item, b.compare)
+	}
+	path := findPath(b.root, item, b.compare)
+	if path == nil {
+		return nil
+	}
+	var n *node[T]
+	b.root, n = copyPath(b.owner, path)
+	b.updateMin()
+	return n
+}
+
+// Meld moves all items of a into the heap by uniting their root lists.
+// If a has snapshots and the heap does not, Meld melds a Clone of a, so
+// that the heap keeps its handles; this takes O(m).
+// The complexity is O(log n + log m).
+func (b *SkewBinomial[T]) Meld(a heap.Heap[T]) heap.Heap[T] {
+	if a == nil {
+		return b
+	}
+	switch o := a.(type) {
+	case *SkewBinomial[T]:
+		if o == b || o.root == nil {
+			return b
+		}
+		src := o
+		if o.owner != nil && b.owner == nil {
+			src = o.Clone()
+		}
+		b.root = unionRoots(ownList(b.owner, b.normalize(b.root)),
+			ownList(b.owner, b.normalize(src.root)), b.compare)
+		b.updateMin()
+		b.size += o.size
+		o.Clear()
+		heap.DebugValidate(b)
+	default:
+		panic(fmt.Sprintf("unexpected type %T", a))
+	}
+	return b
+}
+
+// Meld moves all items of a into the heap by uniting their root lists.
+// The complexity is O(log n + log m).
+func (b *SkewBinomialHeap) Meld(a heap.Interface) heap.Interface {
+	if a == nil {
+		return b
+	}
+	switch o := a.(type) {
+	case *SkewBinomialHeap:
+		b.SkewBinomial.Meld(&o.SkewBinomial)
+	default:
+		panic(fmt.Sprintf("unexpected type %T", a))
+	}
+	return b
+}
+
+// Clear resets the heap.
+func (b *SkewBinomial[T]) Clear() {
+	b.root, b.min = nil, nil
+	b.size = 0
+	b.owner = nil
+}
+
+// Len returns the number of items in the heap.
+// The complexity is O(1).
+func (b *SkewBinomial[T]) Len() int {
+	return b.size
+}
+
+// IsEmpty returns true if the heap has no items.
+// The complexity is O(1).
+func (b *SkewBinomial[T]) IsEmpty() bool {
+	return b.root == nil
+}
+
+// Validate checks that the roots have increasing ranks except for the
+// first two, that every tree is a skew binomial tree in heap order, that
+// min holds the smallest root and that the heap holds Len items.
+// The complexity is O(n).
+func (b *SkewBinomial[T]) Validate() error {
+	count := 0
+	prev := -1
+	for root := b.root; root != nil; root = root.sibling {
+		if root.parent != nil {
+			return fmt.Errorf("binomial: root %v has a parent", root.item)
+		}
+		if root.degree < prev || root.degree == prev && root != b.root.sibling {
+			return fmt.Errorf("binomial: root %v of rank %d follows a root of rank %d",
+				root.item, root.degree, prev)
+		}
+		prev = root.degree
+		if err := b.validate(root, &count); err != nil {
+			return err
+		}
+	}
+	if count != b.size {
+		return fmt.Errorf("binomial: heap has %d nodes but size %d", count, b.size)
+	}
+	return validateMin(b.root, b.min, b.compare)
+}
+
+// validate checks that the node n of rank r has children of rank r-1, ...,
+// 1 in this order and between 1 and r+1 children of rank 0, or none if r
+// is 0, which point to n unless the heap has snapshots.
+func (b *SkewBinomial[T]) validate(n *node[T], count *int) error {
+	if *count++; *count > b.size {
+		return fmt.Errorf("binomial: more than %d nodes or a cycle at %v", b.size, n.item)
+	}
+	if n.handle != nil && n.handle.n != n {
+		return fmt.Errorf("binomial: handle of %v points at another node", n.item)
+	}
+	rank, singles := n.degree, 0
+	for c := n.child; c != nil; c = c.sibling {
+		if c.degree == 0 {
+			singles++
+		} else if rank--; c.degree != rank {
+			return fmt.Errorf("binomial: child %v of %v has rank %d, want %d",
+				c.item, n.item, c.degree, rank)
+		}
+		if c.parent != n && b.owner == nil {
+			return fmt.Errorf("binomial: child %v of %v does not point to its parent", c.item, n.item)
+		}
+		if b.compare(c.item, n.item) < 0 {
+			return fmt.Errorf("binomial: child %v is smaller than its parent %v", c.item, n.item)
+		}
+		if err := b.validate(c, count); err != nil {
+			return err
+		}
+	}
+	if n.degree > 0 && rank != 1 {
+		return fmt.Errorf("binomial: node %v of rank %d has no child of rank %d", n.item, n.degree, rank-1)
+	}
+	if max := n.degree + 1; n.degree > 0 && (singles < 1 || singles > max) || n.degree == 0 && singles > 0 {
+		return fmt.Errorf("binomial: node %v of rank %d has %d children of rank 0", n.item, n.degree, singles)
+	}
+	return nil
+}
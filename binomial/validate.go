@@ -0,0 +1,91 @@
+package binomial
+
+import (
+	"fmt"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// Heap implements the Validator interface
+var _ heap.Validator = (*Heap[int])(nil)
+
+// Validate checks that the roots have strictly increasing degrees, that
+// every node of degree k has children of degree k-1, ..., 0 that are not
+// smaller than it and point to it, unless the Heap has snapshots, that min
+// holds the smallest root and that the Heap holds Len items.
+// The complexity is O(n).
+func (b *Heap[T]) Validate() error {
+	count := 0
+	prev := -1
+	for root := b.root; root != nil; root = root.sibling {
+		if root.parent != nil {
+			return fmt.Errorf("binomial: root %v has a parent", root.item)
+		}
+		if root.degree <= prev {
+			return fmt.Errorf("binomial: root %v of degree %d follows a root of degree %d",
+				root.item, root.degree, prev)
+		}
+		prev = root.degree
+		if err := validateTree(root, &count, b.size, b.compare, b.owner != nil); err != nil {
+			return err
+		}
+	}
+	if count != b.size {
+		return fmt.Errorf("binomial: heap has %d nodes but size %d", count, b.size)
+	}
+	return validateMin(b.root, b.min, b.compare)
+}
+
+// validateMin checks that min is a root holding the smallest item of the
+// root list roots.
+func validateMin[T any](roots, min *node[T], compare func(a, b T) int) error {
+	if roots == nil {
+		if min != nil {
+			return fmt.Errorf("binomial: empty heap has min %v", min.item)
+		}
+		return nil
+	}
+	if min == nil || min.parent != nil {
+		return fmt.Errorf("binomial: min is not a root")
+	}
+	for root := roots; root != nil; root = root.sibling {
+		if compare(root.item, min.item) < 0 {
+			return fmt.Errorf("binomial: root %v is smaller than min %v", root.item, min.item)
+		}
+	}
+	return nil
+}
+
+// validateTree checks the binomial tree rooted at n. It counts its nodes
+// in count, which may not exceed size, and skips the parent pointers if
+// the heap has snapshots.
+func validateTree[T any](n *node[T], count *int, size int, compare func(a, b T) int, snapshots bool) error {
+	if *count++; *count > size {
+		return fmt.Errorf("binomial: more than %d nodes or a cycle at %v", size, n.item)
+	}
+	if n.handle != nil && n.handle.n != n {
+		return fmt.Errorf("binomial: handle of %v points at another node", n.item)
+	}
+	degree := n.degree
+	for c := n.child; c != nil; c = c.sibling {
+		degree--
+		if c.degree != degree {
+			return fmt.Errorf("binomial: child %v of %v has degree %d, want %d",
+				c.item, n.item, c.degree, degree)
+		}
+		if c.parent != n && !snapshots {
+			return fmt.Errorf("binomial: child %v of %v does not point to its parent", c.item, n.item)
+		}
+		if compare(c.item, n.item) < 0 {
+			return fmt.Errorf("binomial: child %v is smaller than its parent %v", c.item, n.item)
+		}
+		if err := validateTree(c, count, size, compare, snapshots); err != nil {
+			return err
+		}
+	}
+	if degree != 0 {
+		return fmt.Errorf("binomial: node %v of degree %d has %d children",
+			n.item, n.degree, n.degree-degree)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+// Command heapbench runs the workloads of the bench package against every
+// heap implementation and prints a comparison table.
+//
+// Usage:
+//
+//	heapbench [-n items] [-workload name] [-heap name]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/theodesp/go-heaps/bench"
+)
+
+func main() {
+	n := flag.Int("n", 10000, "number of items per workload")
+	workload := flag.String("workload", "", "only run workloads containing this name")
+	heapName := flag.String("heap", "", "only run heaps containing this name")
+	flag.Parse()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "workload\theap\tns/op\tallocs/op\tB/op\tcmps/op\t")
+	for _, wl := range bench.Workloads {
+		if !strings.Contains(wl.Name, *workload) {
+			continue
+		}
+		for _, impl := range bench.Impls {
+			if !strings.Contains(impl.Name, *heapName) || !wl.Supports(impl) {
+				continue
+			}
+			r := testing.Benchmark(func(b *testing.B) {
+				bench.Benchmark(b, impl, wl, *n)
+			})
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%.0f\t\n", wl.Name, impl.Name,
+				r.NsPerOp(), r.AllocsPerOp(), r.AllocedBytesPerOp(), r.Extra["cmps/op"])
+		}
+	}
+	w.Flush()
+}
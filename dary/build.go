@@ -0,0 +1,44 @@
+package dary
+
+import (
+	"cmp"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// FromSliceFunc returns a Heap of arity d ordered by compare that holds
+// items.
+// The complexity is O(n).
+func FromSliceFunc[T any](d int, items []T, compare func(a, b T) int) *Heap[T] {
+	h := NewFunc(d, compare)
+	h.Heapify(items)
+	return h
+}
+
+// FromSliceOrdered returns a Heap of arity d of ordered values that holds
+// items.
+// The complexity is O(n).
+func FromSliceOrdered[T cmp.Ordered](d int, items []T) *Heap[T] {
+	return FromSliceFunc(d, items, cmp.Compare[T])
+}
+
+// FromSlice returns a DaryHeap of arity d that holds items.
+// The complexity is O(n).
+func FromSlice(d int, items []heap.Item) *DaryHeap {
+	h := New(d)
+	h.Heapify(items)
+	return h
+}
+
+// InsertAll inserts items into the Heap. It appends them to the slice and
+// restores the heap order bottom-up like Heapify.
+// The complexity is O(n + k) for k items.
+func (h *Heap[T]) InsertAll(items []T) {
+	if len(items) == 0 {
+		return
+	}
+	h.items = append(h.items, items...)
+	h.handles = append(h.handles, make([]*Handle[T], len(items))...)
+	h.heapify()
+	heap.DebugValidate(h)
+}
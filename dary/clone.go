@@ -0,0 +1,21 @@
+package dary
+
+import "slices"
+
+// Clone returns a deep copy of the Heap with the same arity. Handles of
+// the Heap do not refer to items of the copy.
+// The complexity is O(n).
+func (h *Heap[T]) Clone() *Heap[T] {
+	return &Heap[T]{
+		items:   slices.Clone(h.items),
+		handles: make([]*Handle[T], len(h.items)),
+		d:       h.d,
+		cmp:     h.cmp,
+	}
+}
+
+// Clone returns a deep copy of the DaryHeap with the same arity.
+// The complexity is O(n).
+func (h *DaryHeap) Clone() *DaryHeap {
+	return &DaryHeap{*h.Heap.Clone()}
+}
@@ -0,0 +1,374 @@
+// Package dary implements an implicit d-ary heap Data structure stored
+// in a slice. A binary heap is the d = 2 case.
+//
+// Structure is not thread safe.
+//
+// Reference: https://en.wikipedia.org/wiki/D-ary_heap
+package dary
+
+import (
+	"cmp"
+	"fmt"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// DaryHeap implements the Extended interface
+var _ heap.Extended = (*DaryHeap)(nil)
+
+// Heap implements the ExtendedHeap interface
+var _ heap.ExtendedHeap[int] = (*Heap[int])(nil)
+
+// Heap is an implementation of a d-ary Heap over values of type T.
+// The zero value for Heap is an empty binary Heap ordered by go_heaps.Compare.
+type Heap[T any] struct {
+	items []T
+	// handles[i] is the Handle of items[i] or nil if it has none
+	handles []*Handle[T]
+	d       int
+	cmp     func(a, b T) int
+}
+
+// DaryHeap is an implementation of a d-ary Heap.
+// The zero value for DaryHeap is an empty binary Heap.
+type DaryHeap struct {
+	Heap[heap.Item]
+}
+
+// Handle is an opaque reference to an item inserted with InsertHandle.
+// It stays valid until the item is removed from the heap.
+type Handle[T any] struct {
+	heap  *Heap[T]
+	index int
+}
+
+// Item returns the item referenced by the Handle.
+func (h *Handle[T]) Item() T {
+	return h.heap.items[h.index]
+}
+
+// Init initializes or clears the Heap
+func (h *Heap[T]) Init() *Heap[T] {
+	for _, hd := range h.handles {
+		if hd != nil {
+			hd.index = -1
+		}
+	}
+	h.items = nil
+	h.handles = nil
+	return h
+}
+
+// NewFunc returns an initialized Heap of arity d ordered by compare.
+func NewFunc[T any](d int, compare func(a, b T) int) *Heap[T] {
+	if d < 2 {
+		panic(fmt.Sprintf("invalid arity %d", d))
+	}
+	return (&Heap[T]{d: d, cmp: compare}).Init()
+}
+
+// NewOrdered returns an initialized Heap of arity d of ordered values.
+func NewOrdered[T cmp.Ordered](d int) *Heap[T] { return NewFunc(d, cmp.Compare[T]) }
+
+// Init initializes or clears the DaryHeap
+func (h *DaryHeap) Init() *DaryHeap {
+	h.Heap.Init()
+	return h
+}
+
+// New returns an initialized DaryHeap of arity d.
+func New(d int) *DaryHeap {
+	return NewWithComparator(d, nil)
+}
+
+// NewWithComparator returns an initialized DaryHeap of arity d ordered by
+// compare instead of Item.Compare.
+func NewWithComparator(d int, compare func(a, b heap.Item) int) *DaryHeap {
+	return &DaryHeap{Heap: *NewFunc(d, compare)}
+}
+
+func (h *Heap[T]) compare(a, b T) int {
+	if h.cmp == nil {
+		return heap.Compare(a, b)
+	}
+	return h.cmp(a, b)
+}
+
+func (h *Heap[T]) arity() int {
+	if h.d == 0 {
+		return 2
+	}
+	return h.d
+}
+
+// Len returns the number of items in the Heap.
+// The complexity is O(1).
+func (h *Heap[T]) Len() int {
+	return len(h.items)
+}
+
+// IsEmpty returns true if the Heap has no items.
+// The complexity is O(1).
+func (h *Heap[T]) IsEmpty() bool {
+	return len(h.items) == 0
+}
+
+// Clear removes all items from the Heap.
+func (h *Heap[T]) Clear() {
+	h.Init()
+}
+
+// FindMin returns the smallest item in the Heap.
+// The complexity is O(1).
+func (h *Heap[T]) FindMin() T {
+	if h.IsEmpty() {
+		var zero T
+		return zero
+	}
+	return h.items[0]
+}
+
+// Insert adds an item into the Heap and returns it.
+// The complexity is O(log_d n).
+func (h *Heap[T]) Insert(item T) T {
+	h.push(item, nil)
+	return item
+}
+
+// InsertHandle adds an item into the Heap and returns a Handle to it
+// that can be passed to DecreaseKey, IncreaseKey and Remove.
+// The complexity is O(log_d n).
+func (h *Heap[T]) InsertHandle(item T) *Handle[T] {
+	hd := &Handle[T]{heap: h}
+	h.push(item, hd)
+	return hd
+}
+
+func (h *Heap[T]) push(item T, hd *Handle[T]) {
+	h.items = append(h.items, item)
+	h.handles = append(h.handles, hd)
+	i := len(h.items) - 1
+	if hd != nil {
+		hd.index = i
+	}
+	h.up(i)
+	heap.DebugValidate(h)
+}
+
+// DeleteMin removes the smallest item from the Heap and returns it.
+// The complexity is O(d log_d n).
+func (h *Heap[T]) DeleteMin() T {
+	if h.IsEmpty() {
+		var zero T
+		return zero
+	}
+	return h.removeAt(0)
+}
+
+// Heapify replaces the contents of the Heap with items.
+// The complexity is O(n).
+func (h *Heap[T]) Heapify(items []T) {
+	h.Init()
+	h.items = append(h.items, items...)
+	h.handles = make([]*Handle[T], len(items))
+	h.heapify()
+	heap.DebugValidate(h)
+}
+
+func (h *Heap[T]) heapify() {
+	n := len(h.items)
+	if n < 2 {
+		return
+	}
+	for i := (n - 2) / h.arity(); i >= 0; i-- {
+		h.down(i)
+	}
+}
+
+// Remove deletes the item referenced by hd from the Heap and returns it.
+// The complexity is O(d log_d n).
+func (h *Heap[T]) Remove(hd *Handle[T]) T {
+	return h.removeAt(hd.index)
+}
+
+// DecreaseKey replaces the item referenced by hd with a smaller item.
+// The complexity is O(log_d n).
+func (h *Heap[T]) DecreaseKey(hd *Handle[T], item T) {
+	if h.compare(item, h.items[hd.index]) > 0 {
+		panic("new item is greater than the previous one")
+	}
+	h.items[hd.index] = item
+	h.up(hd.index)
+	heap.DebugValidate(h)
+}
+
+// IncreaseKey replaces the item referenced by hd with a greater item.
+// The complexity is O(d log_d n).
+func (h *Heap[T]) IncreaseKey(hd *Handle[T], item T) {
+	if h.compare(item, h.items[hd.index]) < 0 {
+		panic("new item is smaller than the previous one")
+	}
+	h.items[hd.index] = item
+	h.down(hd.index)
+	heap.DebugValidate(h)
+}
+
+// Adjust replaces the item old with new and returns new.
+// The complexity is O(n) to find the item plus O(d log_d n).
+func (h *Heap[T]) Adjust(old, new T) T {
+	i := h.find(old)
+	if i < 0 {
+		var zero T
+		return zero
+	}
+	h.items[i] = new
+	if !h.up(i) {
+		h.down(i)
+	}
+	heap.DebugValidate(h)
+	return new
+}
+
+// Delete removes item from the Heap and returns it.
+// The complexity is O(n) to find the item plus O(d log_d n).
+func (h *Heap[T]) Delete(item T) T {
+	i := h.find(item)
+	if i < 0 {
+		var zero T
+		return zero
+	}
+	return h.removeAt(i)
+}
+
+// Meld moves all items of a into the Heap by concatenating both arrays
+// and heapifying the result.
+// The complexity is O(n + m).
+func (h *Heap[T]) Meld(a heap.Heap[T]) heap.Heap[T] {
+	if a == nil {
+		return h
+	}
+	switch o := a.(type) {
+	case *Heap[T]:
+		if o == h || o.IsEmpty() {
+			return h
+		}
+		offset := len(h.items)
+		for i, hd := range o.handles {
+			if hd != nil {
+				hd.heap, hd.index = h, offset+i
+			}
+		}
+		h.items = append(h.items, o.items...)
+		h.handles = append(h.handles, o.handles...)
+		o.items, o.handles = nil, nil
+		h.heapify()
+		heap.DebugValidate(h)
+	default:
+		panic(fmt.Sprintf("unexpected type %T", a))
+	}
+	return h
+}
+
+// Meld moves all items of a into the DaryHeap by concatenating both arrays
+// and heapifying the result.
+// The complexity is O(n + m).
+func (h *DaryHeap) Meld(a heap.Interface) heap.Interface {
+	if a == nil {
+		return h
+	}
+	switch o := a.(type) {
+	case *DaryHeap:
+		h.Heap.Meld(&o.Heap)
+	default:
+		panic(fmt.Sprintf("unexpected type %T", a))
+	}
+	return h
+}
+
+// find returns the index of item or -1.
+func (h *Heap[T]) find(item T) int {
+	for i := range h.items {
+		if h.compare(h.items[i], item) == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+func (h *Heap[T]) removeAt(i int) T {
+	item := h.items[i]
+	if hd := h.handles[i]; hd != nil {
+		hd.index = -1
+	}
+	last := len(h.items) - 1
+	if i != last {
+		h.move(last, i)
+	}
+	var zero T
+	h.items[last] = zero
+	h.items = h.items[:last]
+	h.handles[last] = nil
+	h.handles = h.handles[:last]
+	if i != last && !h.up(i) {
+		h.down(i)
+	}
+	heap.DebugValidate(h)
+	return item
+}
+
+// up moves the item at i towards the root and reports whether it moved.
+func (h *Heap[T]) up(i int) bool {
+	start := i
+	d := h.arity()
+	for i > 0 {
+		parent := (i - 1) / d
+		if h.compare(h.items[i], h.items[parent]) >= 0 {
+			break
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+	return i != start
+}
+
+func (h *Heap[T]) down(i int) {
+	d := h.arity()
+	n := len(h.items)
+	for {
+		first := d*i + 1
+		if first >= n {
+			return
+		}
+		min := first
+		for c := first + 1; c < first+d && c < n; c++ {
+			if h.compare(h.items[c], h.items[min]) < 0 {
+				min = c
+			}
+		}
+		if h.compare(h.items[min], h.items[i]) >= 0 {
+			return
+		}
+		h.swap(i, min)
+		i = min
+	}
+}
+
+// move copies the item and handle at from to to.
+func (h *Heap[T]) move(from, to int) {
+	h.items[to] = h.items[from]
+	h.handles[to] = h.handles[from]
+	if hd := h.handles[to]; hd != nil {
+		hd.index = to
+	}
+}
+
+func (h *Heap[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.handles[i], h.handles[j] = h.handles[j], h.handles[i]
+	if hd := h.handles[i]; hd != nil {
+		hd.index = i
+	}
+	if hd := h.handles[j]; hd != nil {
+		hd.index = j
+	}
+}
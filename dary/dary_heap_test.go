@@ -0,0 +1,242 @@
+package dary
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"strconv"
+	"testing"
+
+	heap "github.com/theodesp/go-heaps"
+	"github.com/theodesp/go-heaps/heaptest"
+)
+
+var arities = []int{2, 4, 8}
+
+func TestDaryHeapInteger(t *testing.T) {
+	for _, d := range arities {
+		h := New(d)
+		numbers := rand.New(rand.NewSource(int64(d))).Perm(100)
+		for _, number := range numbers {
+			h.Insert(Int(number))
+		}
+		sort.Ints(numbers)
+		for _, number := range numbers {
+			if Int(number) != h.DeleteMin().(heap.Integer) {
+				t.Errorf("d=%d: expected %d", d, number)
+			}
+		}
+		if h.DeleteMin() != nil || !h.IsEmpty() {
+			t.Fail()
+		}
+	}
+}
+
+func TestDaryHeapString(t *testing.T) {
+	h := &DaryHeap{}
+	strs := []string{"a", "ccc", "bb", "d"}
+	for _, str := range strs {
+		h.Insert(Str(str))
+	}
+	sort.Strings(strs)
+	for _, str := range strs {
+		if Str(str) != h.DeleteMin().(heap.String) {
+			t.Fail()
+		}
+	}
+}
+
+func TestDaryHeapAdjustDelete(t *testing.T) {
+	for _, d := range arities {
+		h := New(d)
+		for i := 0; i < 20; i++ {
+			h.Insert(Int(i * 10))
+		}
+		h.Adjust(Int(190), Int(5))
+		h.Adjust(Int(0), Int(200))
+		if h.Delete(Int(100)) != Int(100) || h.Delete(Int(7)) != nil {
+			t.Fail()
+		}
+		numbers := []int{5, 10, 20, 30, 40, 50, 60, 70, 80, 90, 110, 120, 130, 140, 150, 160, 170, 180, 200}
+		for _, number := range numbers {
+			if Int(number) != h.DeleteMin().(heap.Integer) {
+				t.Errorf("d=%d: expected %d", d, number)
+			}
+		}
+	}
+}
+
+func TestDaryHeapMeld(t *testing.T) {
+	h := New(4)
+	other := New(4)
+	for _, number := range []int{4, 3, 9} {
+		h.Insert(Int(number))
+	}
+	for _, number := range []int{8, 1, 5} {
+		other.Insert(Int(number))
+	}
+	h.Meld(other)
+	if !other.IsEmpty() || h.Len() != 6 {
+		t.Fail()
+	}
+	for _, number := range []int{1, 3, 4, 5, 8, 9} {
+		if Int(number) != h.DeleteMin().(heap.Integer) {
+			t.Fail()
+		}
+	}
+}
+
+func TestHeapify(t *testing.T) {
+	for _, d := range arities {
+		h := NewOrdered[int](d)
+		numbers := rand.New(rand.NewSource(int64(d))).Perm(1000)
+		h.Heapify(numbers)
+		sorted := append([]int(nil), numbers...)
+		sort.Ints(sorted)
+		for _, number := range sorted {
+			if res := h.DeleteMin(); number != res {
+				t.Fatalf("d=%d: expected %d, got %d", d, number, res)
+			}
+		}
+	}
+}
+
+func TestHandle(t *testing.T) {
+	for _, d := range arities {
+		h := NewOrdered[int](d)
+		handles := make([]*Handle[int], 10)
+		for i := range handles {
+			handles[i] = h.InsertHandle(i * 10)
+		}
+		other := NewOrdered[int](d)
+		moved := other.InsertHandle(35)
+		h.Meld(other)
+
+		h.DecreaseKey(handles[9], -5)
+		h.IncreaseKey(handles[0], 55)
+		h.DecreaseKey(moved, 33)
+		if h.Remove(handles[5]) != 50 || handles[0].Item() != 55 || moved.Item() != 33 {
+			t.Fail()
+		}
+		for _, number := range []int{-5, 10, 20, 30, 33, 40, 55, 60, 70, 80} {
+			if res := h.DeleteMin(); number != res {
+				t.Errorf("d=%d: expected %d, got %d", d, number, res)
+			}
+		}
+	}
+}
+
+func TestInvalidArity(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fail()
+		}
+	}()
+	New(1)
+}
+
+func Int(value int) heap.Integer {
+	return heap.Integer(value)
+}
+
+func Str(value string) heap.String {
+	return heap.String(value)
+}
+
+func TestExtended(t *testing.T) {
+	heaptest.RunExtendedTests(t, func() heap.Extended { return New(4) })
+}
+
+func FuzzHeap(f *testing.F) {
+	heaptest.AddSeeds(f)
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		heaptest.FuzzExtended(t, func() heap.Extended { return New(4) }, ops)
+		heaptest.FuzzHandles(t, func() heaptest.HandleHeap[*Handle[int]] { return NewOrdered[int](4) }, ops)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	h := NewOrdered[int](4)
+	for _, number := range []int{5, 3, 8, 1, 9, 4} {
+		h.Insert(number)
+	}
+	if err := h.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	h.items[h.Len()-1] = -1
+	if h.Validate() == nil {
+		t.Fail()
+	}
+}
+
+func TestIterable(t *testing.T) {
+	for _, d := range arities {
+		t.Run(strconv.Itoa(d), func(t *testing.T) {
+			heaptest.RunIterableTests(t, func() heaptest.IterableHeap { return NewOrdered[int](d) })
+		})
+	}
+}
+
+func TestInsertAll(t *testing.T) {
+	for _, d := range arities {
+		t.Run(strconv.Itoa(d), func(t *testing.T) {
+			heaptest.RunBulkTests(t, func() heaptest.BulkHeap { return NewOrdered[int](d) })
+		})
+	}
+}
+
+func TestFromSlice(t *testing.T) {
+	h := FromSliceOrdered(3, []int{5, 3, 8, 1})
+	if got := slices.Collect(h.Sorted()); !slices.Equal(got, []int{1, 3, 5, 8}) {
+		t.Errorf("expected [1 3 5 8], got %v", got)
+	}
+	d := FromSlice(2, []heap.Item{heap.Integer(2), heap.Integer(1)})
+	if res := d.DeleteMin(); res != heap.Integer(1) {
+		t.Errorf("expected 1, got %v", res)
+	}
+}
+
+func TestClone(t *testing.T) {
+	for _, d := range arities {
+		t.Run(strconv.Itoa(d), func(t *testing.T) {
+			heaptest.RunCloneTests(t, func() *Heap[int] { return NewOrdered[int](d) }, (*Heap[int]).Clone)
+		})
+	}
+	h := New(3)
+	h.Insert(heap.Integer(2))
+	c := h.Clone()
+	h.Insert(heap.Integer(1))
+	if c.d != 3 {
+		t.Errorf("expected arity 3, got %d", c.d)
+	}
+	if res := c.FindMin(); res != heap.Integer(2) {
+		t.Errorf("expected 2, got %v", res)
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	for _, d := range arities {
+		t.Run(strconv.Itoa(d), func(t *testing.T) {
+			heaptest.RunEncodingTests(t, func() *Heap[int] { return NewOrdered[int](d) }, (*Heap[int]).MarshalBinary)
+		})
+	}
+}
+
+func TestJSON(t *testing.T) {
+	for _, d := range arities {
+		t.Run(strconv.Itoa(d), func(t *testing.T) {
+			heaptest.RunJSONTests(t, func() *Heap[int] { return NewOrdered[int](d) }, func(n *heap.TreeNode[int]) error {
+				if len(n.Children) > d {
+					return fmt.Errorf("%d children", len(n.Children))
+				}
+				for _, c := range n.Children {
+					if c.Item < n.Item {
+						return fmt.Errorf("child %d is less than its parent", c.Item)
+					}
+				}
+				return nil
+			})
+		})
+	}
+}
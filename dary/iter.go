@@ -0,0 +1,59 @@
+package dary
+
+import (
+	"iter"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// Heap implements the Iterable interface
+var _ heap.Iterable[int] = (*Heap[int])(nil)
+
+// Do calls it on each item of the heap in the order of the underlying
+// slice until it returns false.
+// The complexity is O(n).
+func (h *Heap[T]) Do(it func(item T) bool) {
+	for _, v := range h.items {
+		if !it(v) {
+			return
+		}
+	}
+}
+
+// Ascend calls it on each item of the heap in ascending order until it
+// returns false, without modifying the heap. It walks the implicit tree
+// with an auxiliary heap of indexes.
+// The complexity is O(n d log n) to visit all n items.
+func (h *Heap[T]) Ascend(it func(item T) bool) {
+	heap.AscendForest(h.roots, h.children, h.itemAt, h.compare, it)
+}
+
+// All returns an iterator over the items of the heap in the order of the
+// underlying slice.
+func (h *Heap[T]) All() iter.Seq[T] {
+	return h.Do
+}
+
+// Sorted returns an iterator over the items of the heap in ascending
+// order.
+func (h *Heap[T]) Sorted() iter.Seq[T] {
+	return h.Ascend
+}
+
+func (h *Heap[T]) roots(visit func(i int)) {
+	if len(h.items) > 0 {
+		visit(0)
+	}
+}
+
+func (h *Heap[T]) children(i int, visit func(c int)) {
+	d := h.arity()
+	first := d*i + 1
+	for c := first; c < first+d && c < len(h.items); c++ {
+		visit(c)
+	}
+}
+
+func (h *Heap[T]) itemAt(i int) T {
+	return h.items[i]
+}
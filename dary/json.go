@@ -0,0 +1,43 @@
+package dary
+
+import (
+	"encoding/json"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+var (
+	// Heap implements the Marshaler and Unmarshaler interfaces
+	_ json.Marshaler   = (*Heap[int])(nil)
+	_ json.Unmarshaler = (*Heap[int])(nil)
+	// Heap has a tree view
+	_ heap.TreeViewer[int] = (*Heap[int])(nil)
+)
+
+// MarshalJSON encodes the items of the heap in the order of its slice as a
+// go_heaps.JSONView without the tree view.
+// The complexity is O(n).
+func (h *Heap[T]) MarshalJSON() ([]byte, error) {
+	return heap.ViewOf[T](h, false).MarshalJSON()
+}
+
+// UnmarshalJSON replaces the items of the heap with the items of the
+// go_heaps.JSONView encoded in data. The heap keeps its order and
+// configuration and builds itself with InsertAll.
+// The complexity is O(n).
+func (h *Heap[T]) UnmarshalJSON(data []byte) error {
+	var v heap.JSONView[T]
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	h.Clear()
+	h.InsertAll(v.Items)
+	return nil
+}
+
+// Tree returns the implicit d-ary tree of the slice of the heap. The nodes
+// have no attributes.
+// The complexity is O(n).
+func (h *Heap[T]) Tree() []*heap.TreeNode[T] {
+	return heap.TreeOf(h.roots, h.children, h.itemAt, nil)
+}
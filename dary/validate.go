@@ -0,0 +1,36 @@
+package dary
+
+import (
+	"fmt"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// Heap implements the Validator interface
+var _ heap.Validator = (*Heap[int])(nil)
+
+// Validate checks that no item is smaller than its parent and that every
+// Handle points at the position of its item.
+// The complexity is O(n).
+func (h *Heap[T]) Validate() error {
+	if h.d != 0 && h.d < 2 {
+		return fmt.Errorf("dary: invalid arity %d", h.d)
+	}
+	if len(h.handles) != len(h.items) {
+		return fmt.Errorf("dary: %d handles for %d items", len(h.handles), len(h.items))
+	}
+	d := h.arity()
+	for i := 1; i < len(h.items); i++ {
+		parent := (i - 1) / d
+		if h.compare(h.items[i], h.items[parent]) < 0 {
+			return fmt.Errorf("dary: item %v at %d is smaller than its parent %v at %d",
+				h.items[i], i, h.items[parent], parent)
+		}
+	}
+	for i, hd := range h.handles {
+		if hd != nil && (hd.heap != h || hd.index != i) {
+			return fmt.Errorf("dary: handle of item %v at %d points at %d", h.items[i], i, hd.index)
+		}
+	}
+	return nil
+}
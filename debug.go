@@ -0,0 +1,7 @@
+//go:build heapdebug
+
+package go_heaps
+
+// Debug reports whether the module was built with the heapdebug tag. Heaps
+// then validate their structure after every modification.
+const Debug = true
@@ -0,0 +1,265 @@
+package go_heaps
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+func init() {
+	gob.Register(Integer(0))
+	gob.Register(String(""))
+	gob.Register(Reversed{})
+}
+
+// Codec encodes and decodes the items of a heap. Heaps marshal their items
+// with the Codec registered for their item type, or with GobCodec if there
+// is none.
+type Codec[T any] interface {
+	// EncodeItems returns the encoding of items
+	EncodeItems(items []T) ([]byte, error)
+
+	// DecodeItems returns the items encoded in data
+	DecodeItems(data []byte) ([]T, error)
+}
+
+// GobCodec encodes items with encoding/gob. Items of an interface type
+// such as Item are encoded with their concrete types, which have to be
+// registered with gob.Register. Integer, String and Reversed are.
+type GobCodec[T any] struct{}
+
+// EncodeItems returns the gob encoding of items.
+func (GobCodec[T]) EncodeItems(items []T) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(items); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeItems returns the items gob encoded in data.
+func (GobCodec[T]) DecodeItems(data []byte) ([]T, error) {
+	var items []T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+var codecs sync.Map // reflect.Type -> Codec[T]
+
+// RegisterCodec makes heaps with items of type T marshal them with codec.
+// It replaces the Codec registered before for T, if any.
+func RegisterCodec[T any](codec Codec[T]) {
+	codecs.Store(reflect.TypeFor[T](), codec)
+}
+
+// CodecFor returns the Codec registered for items of type T, or GobCodec
+// if there is none.
+func CodecFor[T any]() Codec[T] {
+	if c, ok := codecs.Load(reflect.TypeFor[T]()); ok {
+		return c.(Codec[T])
+	}
+	return GobCodec[T]{}
+}
+
+// encodingMagic starts every marshaled heap, followed by encodingVersion.
+const (
+	encodingMagic   = "heap"
+	encodingVersion = 1
+)
+
+// Encoding is the marshaled form of a heap. Heaps implement
+// encoding.BinaryMarshaler and encoding.BinaryUnmarshaler by converting
+// themselves to and from an Encoding, which gob uses as well.
+//
+// An Encoding without Kind holds only the items, so it can be loaded into
+// any heap of the same item type, which orders them again. An Encoding
+// with Kind holds the exact shape of a heap of that kind as a forest, so
+// it can be loaded into such a heap in O(n) without comparing any items.
+type Encoding[T any] struct {
+	// Kind names the kind of heap whose shape is encoded, or is empty
+	Kind string
+	// Params holds the configuration of the heap the shape belongs to,
+	// such as its linking strategy
+	Params []int
+	// Items holds the items, in preorder of the forest if there is a shape
+	Items []T
+	// Shape holds the number of children of every node of the forest in
+	// preorder
+	Shape []int
+}
+
+// MarshalBinary encodes e, and its items with the Codec for T.
+func (e *Encoding[T]) MarshalBinary() ([]byte, error) {
+	if e.Kind != "" && len(e.Shape) != len(e.Items) {
+		return nil, fmt.Errorf("heap: shape of %d nodes for %d items", len(e.Shape), len(e.Items))
+	}
+	items, err := CodecFor[T]().EncodeItems(e.Items)
+	if err != nil {
+		return nil, err
+	}
+	b := append([]byte(encodingMagic), encodingVersion)
+	b = binary.AppendUvarint(b, uint64(len(e.Kind)))
+	b = append(b, e.Kind...)
+	b = binary.AppendUvarint(b, uint64(len(e.Params)))
+	for _, p := range e.Params {
+		b = binary.AppendVarint(b, int64(p))
+	}
+	b = binary.AppendUvarint(b, uint64(len(e.Items)))
+	if e.Kind != "" {
+		for _, n := range e.Shape {
+			b = binary.AppendUvarint(b, uint64(n))
+		}
+	}
+	return append(b, items...), nil
+}
+
+var errEncoding = errors.New("heap: malformed encoding")
+
+// UnmarshalBinary decodes data into e, and its items with the Codec for T.
+func (e *Encoding[T]) UnmarshalBinary(data []byte) error {
+	if len(data) <= len(encodingMagic) || string(data[:len(encodingMagic)]) != encodingMagic {
+		return errEncoding
+	}
+	if v := data[len(encodingMagic)]; v != encodingVersion {
+		return fmt.Errorf("heap: unknown encoding version %d", v)
+	}
+	r := bytes.NewReader(data[len(encodingMagic)+1:])
+	// length reads a count of values that take at least a byte each
+	length := func() (int, error) {
+		n, err := binary.ReadUvarint(r)
+		if err != nil || n > uint64(r.Len()) {
+			return 0, errEncoding
+		}
+		return int(n), nil
+	}
+
+	n, err := length()
+	if err != nil {
+		return err
+	}
+	kind := make([]byte, n)
+	r.Read(kind)
+	if n, err = length(); err != nil {
+		return err
+	}
+	params := make([]int, n)
+	for i := range params {
+		p, err := binary.ReadVarint(r)
+		if err != nil {
+			return errEncoding
+		}
+		params[i] = int(p)
+	}
+
+	count, err := binary.ReadUvarint(r)
+	if err != nil {
+		return errEncoding
+	}
+	var shape []int
+	if len(kind) > 0 {
+		if count > uint64(r.Len()) {
+			return errEncoding
+		}
+		shape = make([]int, count)
+		for i := range shape {
+			c, err := binary.ReadUvarint(r)
+			if err != nil || c >= count {
+				return errEncoding
+			}
+			shape[i] = int(c)
+		}
+	}
+	items, err := CodecFor[T]().DecodeItems(data[len(data)-r.Len():])
+	if err != nil {
+		return err
+	}
+	if uint64(len(items)) != count {
+		return fmt.Errorf("heap: decoded %d items, want %d", len(items), count)
+	}
+	e.Kind, e.Params, e.Items, e.Shape = string(kind), params, items, shape
+	return nil
+}
+
+// MarshalItems returns an Encoding of items without a shape. Heaps that
+// do not encode their shape implement MarshalBinary with it.
+func MarshalItems[T any](items iter.Seq[T]) ([]byte, error) {
+	return (&Encoding[T]{Items: slices.Collect(items)}).MarshalBinary()
+}
+
+// UnmarshalItems returns the items of an Encoding, ignoring its shape if
+// it has one. Heaps that do not decode shapes implement UnmarshalBinary by
+// inserting them.
+func UnmarshalItems[T any](data []byte) ([]T, error) {
+	var e Encoding[T]
+	if err := e.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return e.Items, nil
+}
+
+// EncodeForest returns the items of the forest whose nodes roots and
+// children visit in preorder, together with the number of children of
+// every node, as the Items and Shape of an Encoding.
+// The complexity is O(n).
+func EncodeForest[N, T any](roots func(visit func(n N)), children func(n N, visit func(c N)),
+	item func(n N) T) (items []T, shape []int) {
+	var stack []N
+	push := func(n N) { stack = append(stack, n) }
+	roots(push)
+	slices.Reverse(stack)
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		top := len(stack)
+		children(n, push)
+		slices.Reverse(stack[top:])
+		items = append(items, item(n))
+		shape = append(shape, len(stack)-top)
+	}
+	return items, shape
+}
+
+// DecodeForest rebuilds the forest of the Shape of an Encoding. It calls
+// link for every node in preorder with the index of its parent and of its
+// previous sibling, either of which is -1 if there is none. The roots are
+// the nodes without a parent. It returns an error if shape does not
+// describe a forest.
+// The complexity is O(n).
+func DecodeForest(shape []int, link func(i, parent, prev int)) error {
+	// open holds the nodes still missing children, with the number of
+	// them and their last child so far
+	type open struct{ node, missing, last int }
+	var stack []open
+	lastRoot := -1
+	for i, n := range shape {
+		if n < 0 {
+			return errEncoding
+		}
+		if len(stack) == 0 {
+			link(i, -1, lastRoot)
+			lastRoot = i
+		} else {
+			top := &stack[len(stack)-1]
+			link(i, top.node, top.last)
+			top.last = i
+			if top.missing--; top.missing == 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+		if n > 0 {
+			stack = append(stack, open{node: i, missing: n, last: -1})
+		}
+	}
+	if len(stack) > 0 {
+		return errEncoding
+	}
+	return nil
+}
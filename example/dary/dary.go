@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/theodesp/go-heaps"
+	"github.com/theodesp/go-heaps/dary"
+)
+
+func main() {
+	heap := dary.New(4)
+	heap.Insert(Int(4))
+	heap.Insert(Int(19))
+	heap.Insert(Int(8))
+	heap.Insert(Int(27))
+	heap.Insert(Int(20))
+
+	fmt.Println(heap.DeleteMin()) // 4
+	fmt.Println(heap.DeleteMin()) // 8
+
+	ints := dary.NewOrdered[int](2)
+	ints.Heapify([]int{9, 2, 7, 1})
+
+	fmt.Println(ints.DeleteMin()) // 1
+	fmt.Println(ints.DeleteMin()) // 2
+}
+
+func Int(value int) go_heaps.Integer {
+	return go_heaps.Integer(value)
+}
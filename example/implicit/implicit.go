@@ -0,0 +1,23 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/theodesp/go-heaps/treap/implicit"
+)
+
+func main() {
+	seq := implicit.NewFunc(implicit.Sum[int])
+	seq.Append(1, 2, 3, 4, 5)
+	seq.Insert(2, 10)
+
+	fmt.Println(seq.Values())   // [1 2 10 3 4 5]
+	fmt.Println(seq.Fold(1, 4)) // 15
+
+	seq.Reverse(0, 3)
+	fmt.Println(seq.Values()) // [10 2 1 3 4 5]
+
+	tail := seq.Split(4)
+	fmt.Println(tail.Values()) // [4 5]
+	fmt.Println(seq.Delete(0)) // 10
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/theodesp/go-heaps/persistent"
+)
+
+func main() {
+	empty := persistent.NewSkewBinomialOrdered[int]()
+	v1 := empty.Insert(4).Insert(1).Insert(3)
+	min, v2 := v1.DeleteMin()
+	v3 := v2.Meld(empty.Insert(2))
+
+	fmt.Println(min)                    // 1
+	fmt.Println(v1.Len(), v1.FindMin()) // 3 1
+	fmt.Println(v2.Len(), v2.FindMin()) // 2 3
+	fmt.Println(v3.Len(), v3.FindMin()) // 3 2
+}
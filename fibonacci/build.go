@@ -0,0 +1,40 @@
+package fibonacci
+
+import (
+	"cmp"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// FromSliceFunc returns a Heap ordered by compare that holds items.
+// The complexity is O(n).
+func FromSliceFunc[T any](items []T, compare func(a, b T) int) *Heap[T] {
+	fh := NewFunc(compare)
+	fh.InsertAll(items)
+	return fh
+}
+
+// FromSliceOrdered returns a Heap of ordered values that holds items.
+// The complexity is O(n).
+func FromSliceOrdered[T cmp.Ordered](items []T) *Heap[T] {
+	return FromSliceFunc(items, cmp.Compare[T])
+}
+
+// FromSlice returns a FibonacciHeap that holds items.
+// The complexity is O(n).
+func FromSlice(items []heap.Item) *FibonacciHeap {
+	fh := New()
+	fh.InsertAll(items)
+	return fh
+}
+
+// InsertAll inserts items into the heap. Every item becomes a tree in the
+// root list, which DeleteMin consolidates later.
+// The complexity is O(k) for k items.
+func (fh *Heap[T]) InsertAll(items []T) {
+	for _, item := range items {
+		fh.insertRoot(&node[T]{item: item, owner: fh.owner})
+	}
+	fh.size += len(items)
+	heap.DebugValidate(fh)
+}
@@ -0,0 +1,195 @@
+package fibonacci
+
+// owner identifies the heap that may modify a node in place. Heaps that
+// share nodes after a Snapshot have different owners, so each of them
+// copies a shared node before it modifies it.
+type owner struct {
+	_ byte
+}
+
+// mutable returns n if the heap owns it or has no snapshots and a copy of
+// n owned by the heap otherwise.
+func (fh *Heap[T]) mutable(n *node[T]) *node[T] {
+	if fh.owner == nil || n.owner == fh.owner {
+		return n
+	}
+	c := *n
+	c.owner = fh.owner
+	return &c
+}
+
+// ownRing returns the circular list of children of parent starting at
+// first with every node owned by the heap. The nodes of a list are either
+// all owned by the heap or none of them, so ownRing copies the whole list
+// if it does not own first.
+func (fh *Heap[T]) ownRing(first, parent *node[T]) *node[T] {
+	if fh.owner == nil || first.owner == fh.owner {
+		return first
+	}
+	var head, last *node[T]
+	ring(first, func(n *node[T]) {
+		c := *n
+		c.owner, c.parent = fh.owner, parent
+		t := &c
+		if head == nil {
+			head = t
+		} else {
+			last.next, t.prev = t, last
+		}
+		last = t
+	})
+	last.next, head.prev = head, last
+	return head
+}
+
+// checkHandles panics if the heap has snapshots, since handles need the
+// parent pointers that such heaps do not keep.
+func (fh *Heap[T]) checkHandles() {
+	if fh.owner != nil {
+		panic("handles are disabled on a heap with snapshots")
+	}
+}
+
+// Clone returns a deep copy of the heap. Handles of the heap do not refer
+// to items of the copy. The copy has no snapshots, so it supports handles
+// even if the heap does not.
+// The complexity is O(n).
+func (fh *Heap[T]) Clone() *Heap[T] {
+	c := &Heap[T]{size: fh.size, cmp: fh.cmp}
+	// pairs of a node and its copy whose children are still to copy
+	var stack []*node[T]
+	for n := fh.first; n != nil; n = n.next {
+		t := &node[T]{item: n.item, isMarked: n.isMarked, degree: n.degree}
+		if c.last == nil {
+			c.first = t
+		} else {
+			c.last.next = t
+		}
+		c.last = t
+		if n == fh.root {
+			c.root = t
+		}
+		stack = append(stack, n, t)
+	}
+	for len(stack) > 0 {
+		from, to := stack[len(stack)-2], stack[len(stack)-1]
+		stack = stack[:len(stack)-2]
+		to.child = cloneRing(from.child, to, &stack)
+	}
+	return c
+}
+
+// Snapshot returns a copy of the heap that shares all nodes with it.
+// Afterwards both heaps copy a shared node before they modify it:
+// DeleteMin copies the roots and the children of the minimum that it
+// consolidates, which it visits anyway, and linking copies the children
+// of the winner, O(log n) of them. Shared nodes cannot point back to a
+// single parent, so both heaps disable handles until they are cleared:
+// InsertHandle, Remove, DecreaseKey and IncreaseKey panic, and Delete and
+// Adjust copy the roots before the item's tree and the children of every
+// node on the path to the item instead.
+// The complexity is O(1).
+func (fh *Heap[T]) Snapshot() *Heap[T] {
+	fh.owner = new(owner)
+	return &Heap[T]{root: fh.root, first: fh.first, last: fh.last, size: fh.size, cmp: fh.cmp, owner: new(owner)}
+}
+
+// Clone returns a deep copy of the FibonacciHeap.
+// The complexity is O(n).
+func (fh *FibonacciHeap) Clone() *FibonacciHeap {
+	return &FibonacciHeap{*fh.Heap.Clone()}
+}
+
+// Snapshot returns a copy of the FibonacciHeap that shares all nodes with
+// it, like Heap.Snapshot.
+// The complexity is O(1).
+func (fh *FibonacciHeap) Snapshot() *FibonacciHeap {
+	return &FibonacciHeap{*fh.Heap.Snapshot()}
+}
+
+// findPath returns the path from a root to a node holding item along child
+// and sibling pointers, or nil if there is none.
+func (fh *Heap[T]) findPath(item T) []*node[T] {
+	type visit struct {
+		n     *node[T]
+		depth int
+	}
+	var path []*node[T]
+	var stack []visit
+	for n := fh.first; n != nil; n = n.next {
+		stack = append(stack, visit{n, 0})
+	}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		path = append(path[:v.depth], v.n)
+		c := fh.compare(v.n.item, item)
+		if c == 0 {
+			return path
+		}
+		// no child is smaller than v.n
+		if c < 0 {
+			ring(v.n.child, func(n *node[T]) {
+				stack = append(stack, visit{n, v.depth + 1})
+			})
+		}
+	}
+	return nil
+}
+
+// copyPath replaces the nodes of path, which leads to a node as returned by
+// findPath, with nodes owned by the heap and returns the copy of the last
+// one. It copies the roots before the first node of path and the children
+// of every node on it, whose copies point to their parent, so that
+// decrease can cut the copy.
+func (fh *Heap[T]) copyPath(path []*node[T]) *node[T] {
+	var n, prev *node[T]
+	for x := fh.first; n == nil; x = x.next {
+		c := fh.mutable(x)
+		if prev == nil {
+			fh.first = c
+		} else {
+			prev.next = c
+		}
+		if x == fh.root {
+			fh.root = c
+		}
+		if x == fh.last {
+			fh.last = c
+		}
+		if x == path[0] {
+			n = c
+		}
+		prev = c
+	}
+	for _, x := range path[1:] {
+		old := n.child
+		n.child = fh.ownRing(old, n)
+		c := n.child
+		for y := old; y != x; y = y.next {
+			c = c.next
+		}
+		n = c
+	}
+	return n
+}
+
+// cloneRing returns a copy of the circular list of nodes starting at first,
+// whose nodes get parent, and pushes every node with its copy on stack.
+func cloneRing[T any](first, parent *node[T], stack *[]*node[T]) *node[T] {
+	var head, last *node[T]
+	ring(first, func(n *node[T]) {
+		t := &node[T]{item: n.item, parent: parent, isMarked: n.isMarked, degree: n.degree}
+		if head == nil {
+			head = t
+		} else {
+			last.next, t.prev = t, last
+		}
+		last = t
+		*stack = append(*stack, n, t)
+	})
+	if head != nil {
+		last.next, head.prev = head, last
+	}
+	return head
+}
@@ -4,178 +4,375 @@
 package fibonacci
 
 import (
+	"cmp"
+	"fmt"
+
 	heap "github.com/theodesp/go-heaps"
 )
 
+// FibonacciHeap implements the Extended interface
+var _ heap.Extended = (*FibonacciHeap)(nil)
+
+// Heap implements the ExtendedHeap interface
+var _ heap.ExtendedHeap[int] = (*Heap[int])(nil)
+
+// Heap is a implementation of Fibonacci heap over values of type T.
+// The zero value for Heap is an empty Heap ordered by go_heaps.Compare.
+type Heap[T any] struct {
+	// root is the root holding the smallest item
+	root *node[T]
+	// first and last are the ends of the root list
+	first, last *node[T]
+	size        int
+	cmp         func(a, b T) int
+	// owner is set once the Heap has snapshots
+	owner *owner
+}
+
 // FibonacciHeap is a implementation of Fibonacci heap.
 type FibonacciHeap struct {
-	root   *node
+	Heap[heap.Item]
 }
 
 // node holds structure of nodes inside Fibonacci heap.
-type node struct {
-	item                      heap.Item
-	prev, next, parent, child *node
+type node[T any] struct {
+	item T
+	// next links the root list; prev and next link the children of a node
+	// in a circle. The parent of a node shared with snapshots may be a
+	// node of another heap.
+	prev, next, parent, child *node[T]
 	isMarked                  bool
 	degree                    int
+	// nInf marks a node that compares below every other node (negative inf)
+	nInf  bool
+	owner *owner
+}
+
+// Handle is an opaque reference to an item inserted with InsertHandle.
+// It stays valid until the item is removed from the heap.
+type Handle[T any] node[T]
+
+// Item returns the item referenced by the Handle.
+func (h *Handle[T]) Item() T {
+	return h.item
 }
 
 // New creates and returns a new, empty heap.
 func New() *FibonacciHeap {
-	return &FibonacciHeap{root: nil}
+	return &FibonacciHeap{}
+}
+
+// NewWithComparator creates and returns a new, empty heap ordered by
+// compare instead of Item.Compare.
+func NewWithComparator(compare func(a, b heap.Item) int) *FibonacciHeap {
+	fh := &FibonacciHeap{}
+	fh.cmp = compare
+	return fh
+}
+
+// NewFunc creates and returns a new, empty heap ordered by compare.
+func NewFunc[T any](compare func(a, b T) int) *Heap[T] {
+	return &Heap[T]{cmp: compare}
+}
+
+// NewOrdered creates and returns a new, empty heap of ordered values.
+func NewOrdered[T cmp.Ordered]() *Heap[T] { return NewFunc(cmp.Compare[T]) }
+
+func (fh *Heap[T]) compare(a, b T) int {
+	if fh.cmp == nil {
+		return heap.Compare(a, b)
+	}
+	return fh.cmp(a, b)
+}
+
+// less reports whether node a orders before node b, taking nInf into account.
+func (fh *Heap[T]) less(a, b *node[T]) bool {
+	if a.nInf || b.nInf {
+		return a.nInf && !b.nInf
+	}
+	return fh.compare(a.item, b.item) < 0
 }
 
 // Insert inserts a new node, with predeclared item, to the heap.
-func (fh *FibonacciHeap) Insert(item heap.Item) heap.Item {
-	n := &node{item: item, isMarked: false}
+func (fh *Heap[T]) Insert(item T) T {
+	n := &node[T]{item: item, isMarked: false, owner: fh.owner}
 
 	fh.insertRoot(n)
+	fh.size++
+	heap.DebugValidate(fh)
 	return item
 }
 
+// InsertHandle inserts a new node, with predeclared item, to the heap
+// and returns a Handle to it.
+func (fh *Heap[T]) InsertHandle(item T) *Handle[T] {
+	fh.checkHandles()
+	n := &node[T]{item: item, isMarked: false}
+
+	fh.insertRoot(n)
+	fh.size++
+	heap.DebugValidate(fh)
+	return (*Handle[T])(n)
+}
+
 // FindMin returns the minimum item.
-func (fh *FibonacciHeap) FindMin() heap.Item {
+func (fh *Heap[T]) FindMin() T {
 	if fh.root == nil {
-		return nil
+		var zero T
+		return zero
 	}
 	return fh.root.item
 }
 
 // DeleteMin extracts the node with minimum item from a heap
 // and returns the minimum item.
-func (fh *FibonacciHeap) DeleteMin() heap.Item {
+func (fh *Heap[T]) DeleteMin() T {
 	r := fh.root
 	if r == nil {
-		return nil
+		var zero T
+		return zero
 	}
-	for {
-		// add r children to fh's root list
-		if x := r.child; x != nil {
-			x.parent = nil
-			if x.next != x {
-				r.child = x.next
-				x.next.prev = x.prev
-				x.prev.next = x.next
-			} else {
-				r.child = nil
-			}
-			x.prev = r.prev
-			x.next = r
-			r.prev.next = x
-			r.prev = x
-		} else {
-			break
+	// the other roots and the children of r make up the new root list
+	var roots []*node[T]
+	for x := fh.first; x != nil; x = x.next {
+		if x != r {
+			roots = append(roots, x)
 		}
 	}
-	// remove r from fh's root list
-	r.prev.next = r.next
-	r.next.prev = r.prev
-
-	if r == r.next {
-		fh.root = nil
-	} else {
-		fh.root = r.next
-		fh.consolidate()
+	ring(r.child, func(x *node[T]) {
+		roots = append(roots, x)
+	})
+	if fh.owner == nil {
+		r.child = nil
 	}
 
+	fh.size--
+	fh.consolidate(roots)
+	heap.DebugValidate(fh)
+
 	return r.item
 }
 
-func (fh *FibonacciHeap) consolidate() {
-	degreeToRoot := make(map[int]*node)
-	w := fh.root
-	last := w.prev
-	for {
-		r := w.next
-		x := w
+// consolidate links the trees of roots until no two of them have the same
+// degree and makes them the root list. On a heap with snapshots it copies
+// the roots it does not own first.
+func (fh *Heap[T]) consolidate(roots []*node[T]) {
+	degreeToRoot := make(map[int]*node[T])
+	for _, x := range roots {
+		x = fh.mutable(x)
+		x.parent = nil
 		d := x.degree
 		for {
 			if y, ok := degreeToRoot[d]; !ok {
 				break
 			} else {
-				if y.item.Compare(x.item) < 0 {
+				if fh.less(y, x) {
 					y, x = x, y
 				}
-				link(x, y)
+				fh.link(x, y)
 				delete(degreeToRoot, d)
 				d++
 			}
 		}
 		degreeToRoot[d] = x
-		if w == last {
-			break
-		}
-		w = r
 	}
-	fh.root = nil
+	fh.root, fh.first, fh.last = nil, nil, nil
 	for _, v := range degreeToRoot {
 		fh.insertRoot(v)
 	}
-
 }
 
 // Clear resets heap.
-func (fh *FibonacciHeap) Clear() {
-	fh.root = nil
+func (fh *Heap[T]) Clear() {
+	fh.root, fh.first, fh.last = nil, nil, nil
+	fh.size = 0
+	fh.owner = nil
 }
 
-func link(x, y *node) {
-	// remove y from fh's root list
-	y.next.prev = y.prev
-	y.prev.next = y.next
-	// make y a child of x and increase degree of x
-	y.parent = x
-	if x.child == nil {
-		x.child = y
-		y.prev = y
-		y.next = y
-	} else {
-		insert(x.child, y)
-	}
+// Len returns the number of items in the heap.
+// The complexity is O(1).
+func (fh *Heap[T]) Len() int {
+	return fh.size
+}
 
-	y.isMarked = false
+// IsEmpty returns true if the heap has no items.
+// The complexity is O(1).
+func (fh *Heap[T]) IsEmpty() bool {
+	return fh.root == nil
 }
 
-func (fh *FibonacciHeap) insertRoot(n *node) {
-	if fh.root == nil {
-		// create fh's root list containing only n
-		n.prev = n
-		n.next = n
-		fh.root = n
-	} else {
-		// insert n to fh's root list
-		insert(fh.root, n)
-		if n.item.Compare(fh.root.item) < 0 {
-			fh.root = n
+// Meld moves all items of a into the heap by concatenating the root lists.
+// If a has snapshots and the heap does not, Meld melds a Clone of a, so
+// that the heap keeps its handles; this takes O(m). If the last roots of
+// both heaps are shared with snapshots, Meld copies the k roots of a
+// instead; this takes O(k).
+// The complexity is O(1).
+func (fh *Heap[T]) Meld(a heap.Heap[T]) heap.Heap[T] {
+	if a == nil {
+		return fh
+	}
+	switch h := a.(type) {
+	case *Heap[T]:
+		if h == fh || h.root == nil {
+			return fh
+		}
+		fh.size += h.size
+		src := h
+		if h.owner != nil && fh.owner == nil {
+			src = h.Clone()
 		}
+		// modify a last root that is not shared
+		switch {
+		case fh.root == nil:
+			fh.root, fh.first, fh.last = src.root, src.first, src.last
+		case fh.owner == nil || fh.last.owner == fh.owner:
+			fh.last.next = src.first
+			fh.last = src.last
+		case src.owner == nil || src.last.owner == src.owner:
+			src.last.next = fh.first
+			fh.first = src.first
+		default:
+			for x := src.first; x != nil; x = x.next {
+				c := fh.mutable(x)
+				c.next = fh.first
+				fh.first = c
+				if x == src.root {
+					src.root = c
+				}
+			}
+		}
+		if fh.less(src.root, fh.root) {
+			fh.root = src.root
+		}
+		h.Clear()
+		heap.DebugValidate(fh)
+	default:
+		panic(fmt.Sprintf("unexpected type %T", a))
 	}
+	return fh
 }
 
-func insert(x, y *node) {
-	x.prev.next = y
-	y.next = x
-	y.prev = x.prev
-	x.prev = y
+// Meld moves all items of a into the heap by concatenating the root lists.
+// The complexity is O(1).
+func (fh *FibonacciHeap) Meld(a heap.Interface) heap.Interface {
+	if a == nil {
+		return fh
+	}
+	switch h := a.(type) {
+	case *FibonacciHeap:
+		fh.Heap.Meld(&h.Heap)
+	default:
+		panic(fmt.Sprintf("unexpected type %T", a))
+	}
+	return fh
+}
+
+// Adjust changes the item old to new and returns new.
+// The complexity is O(n) to find the item, then O(1) amortized for a
+// smaller item and O(log n) amortized for a greater one.
+func (fh *Heap[T]) Adjust(old, new T) T {
+	x := fh.findAny(old)
+	if x == nil {
+		var zero T
+		return zero
+	}
+	switch {
+	case fh.compare(new, x.item) <= 0:
+		x.item = new
+		fh.decrease(x)
+		heap.DebugValidate(fh)
+	case fh.owner != nil:
+		fh.remove(x)
+		fh.Insert(new)
+	default:
+		fh.IncreaseKey((*Handle[T])(x), new)
+	}
+	return new
+}
+
+// Delete removes item from the heap and returns it.
+// The complexity is O(n) to find the item plus O(log n) amortized.
+func (fh *Heap[T]) Delete(item T) T {
+	x := fh.findAny(item)
+	if x == nil {
+		var zero T
+		return zero
+	}
+	return fh.remove(x)
+}
+
+// findAny returns the node holding item or nil if there is none. On a heap
+// with snapshots it copies the path to the node first, so that decrease
+// can cut it and its marked ancestors.
+func (fh *Heap[T]) findAny(item T) *node[T] {
+	if fh.owner == nil {
+		return fh.find(fh.first, item)
+	}
+	path := fh.findPath(item)
+	if path == nil {
+		return nil
+	}
+	return fh.copyPath(path)
 }
 
-/*
-// DecreaseKey decreases the key of given node.
-func (fh *FibonacciHeap) DecreaseKey(x *node, k heap.Item) {
-	if x.item.Compare(k) < 0 {
+// DecreaseKey decreases the item of given node.
+// The complexity is O(1) amortized.
+func (fh *Heap[T]) DecreaseKey(h *Handle[T], k T) {
+	fh.checkHandles()
+	x := (*node[T])(h)
+	if fh.compare(x.item, k) < 0 {
 		panic("new item is greater than the previous one")
 	}
 	x.item = k
+	fh.decrease(x)
+	heap.DebugValidate(fh)
+}
+
+// IncreaseKey increases the item of given node.
+// The complexity is O(log n) amortized.
+func (fh *Heap[T]) IncreaseKey(h *Handle[T], k T) {
+	fh.checkHandles()
+	x := (*node[T])(h)
+	if fh.compare(x.item, k) > 0 {
+		panic("new item is smaller than the previous one")
+	}
+	fh.remove(x)
+	x.item, x.degree, x.isMarked = k, 0, false
+	fh.insertRoot(x)
+	fh.size++
+	heap.DebugValidate(fh)
+}
+
+// Remove deletes the item of given node from the heap and returns it.
+// The complexity is O(log n) amortized.
+func (fh *Heap[T]) Remove(h *Handle[T]) T {
+	fh.checkHandles()
+	return fh.remove((*node[T])(h))
+}
+
+// remove deletes x from the heap and returns its item.
+func (fh *Heap[T]) remove(x *node[T]) T {
+	x.nInf = true
+	fh.decrease(x)
+	fh.DeleteMin()
+	x.nInf = false
+	return x.item
+}
+
+// decrease moves x to the root list after its item has been decreased
+// if it now violates the heap order.
+func (fh *Heap[T]) decrease(x *node[T]) {
 	y := x.parent
-	if y != nil && x.item.Compare(y.item) < 0 {
+	if y != nil && fh.less(x, y) {
 		fh.cut(x, y)
 		fh.cascadingCut(y)
 	}
-	if x.item.Compare(fh.root.item) < 0 {
+	if fh.less(x, fh.root) {
 		fh.root = x
 	}
 }
 
-func (fh *FibonacciHeap) cut(x, y *node) {
+func (fh *Heap[T]) cut(x, y *node[T]) {
 	// remove x from y's children list and decrement y's degree
 	if x.next != x {
 		y.child = x.next
@@ -186,13 +383,13 @@ func (fh *FibonacciHeap) cut(x, y *node) {
 	}
 	y.degree--
 	// add x to fh's root list
-	insert(fh.root, x)
+	fh.insertRoot(x)
 
 	x.parent = nil
 	x.isMarked = false
 }
 
-func (fh *FibonacciHeap) cascadingCut(y *node) {
+func (fh *Heap[T]) cascadingCut(y *node[T]) {
 	z := y.parent
 	if z != nil {
 		if !y.isMarked {
@@ -203,4 +400,61 @@ func (fh *FibonacciHeap) cascadingCut(y *node) {
 		}
 	}
 }
-*/
+
+// find returns the node holding item in the root list or circular list
+// starting at start or in any of its subtrees.
+func (fh *Heap[T]) find(start *node[T], item T) *node[T] {
+	if start == nil {
+		return nil
+	}
+	x := start
+	for {
+		if fh.compare(x.item, item) == 0 {
+			return x
+		}
+		if found := fh.find(x.child, item); found != nil {
+			return found
+		}
+		x = x.next
+		if x == nil || x == start {
+			return nil
+		}
+	}
+}
+
+// link makes the root y a child of the root x. Both are owned by the heap
+// and in no list.
+func (fh *Heap[T]) link(x, y *node[T]) {
+	// make y a child of x and increase degree of x
+	y.parent = x
+	if x.child == nil {
+		x.child = y
+		y.prev = y
+		y.next = y
+	} else {
+		x.child = fh.ownRing(x.child, x)
+		insert(x.child, y)
+	}
+	x.degree++
+
+	y.isMarked = false
+}
+
+// insertRoot puts n, which the heap owns, at the front of the root list.
+func (fh *Heap[T]) insertRoot(n *node[T]) {
+	n.prev, n.next = nil, fh.first
+	fh.first = n
+	if fh.last == nil {
+		fh.last = n
+	}
+	if fh.root == nil || fh.less(n, fh.root) {
+		fh.root = n
+	}
+}
+
+func insert[T any](x, y *node[T]) {
+	x.prev.next = y
+	y.next = x
+	y.prev = x.prev
+	x.prev = y
+}
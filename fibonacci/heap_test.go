@@ -1,10 +1,13 @@
 package fibonacci
 
 import (
+	"fmt"
+	"slices"
 	"sort"
 	"testing"
 
 	"github.com/theodesp/go-heaps"
+	"github.com/theodesp/go-heaps/heaptest"
 )
 
 func TestFibonacciHeapInteger(t *testing.T) {
@@ -63,6 +66,164 @@ func TestFibonacciHeap(t *testing.T) {
 	}
 }
 
+func TestFibonacciHeapLen(t *testing.T) {
+	heap := &FibonacciHeap{}
+
+	if !heap.IsEmpty() || heap.Len() != 0 || heap.DeleteMin() != nil {
+		t.Fail()
+	}
+
+	for _, number := range []int{4, 3, 2, 5} {
+		heap.Insert(Int(number))
+	}
+	heap.DeleteMin()
+
+	if heap.IsEmpty() || heap.Len() != 3 {
+		t.Fail()
+	}
+
+	heap.Clear()
+	if !heap.IsEmpty() || heap.Len() != 0 {
+		t.Fail()
+	}
+}
+
+func TestFibonacciHeapMaxHeap(t *testing.T) {
+	heap := NewWithComparator(func(a, b go_heaps.Item) int { return b.Compare(a) })
+	reversed := New()
+
+	for _, number := range []int{4, 3, 7, 5} {
+		heap.Insert(go_heaps.Integer(number))
+		reversed.Insert(go_heaps.Reverse(go_heaps.Integer(number)))
+	}
+
+	for _, number := range []int{7, 5, 4, 3} {
+		if go_heaps.Integer(number) != heap.DeleteMin() {
+			t.Fail()
+		}
+		if go_heaps.Integer(number) != reversed.DeleteMin().(go_heaps.Reversed).Item {
+			t.Fail()
+		}
+	}
+}
+
+func TestHeapOrdered(t *testing.T) {
+	heap := NewOrdered[int]()
+
+	numbers := []int{4, 3, -1, 5, 9}
+
+	for _, number := range numbers {
+		heap.Insert(number)
+	}
+
+	sort.Ints(numbers)
+
+	for _, number := range numbers {
+		if number != heap.DeleteMin() {
+			t.Fail()
+		}
+	}
+	if heap.FindMin() != 0 {
+		t.Fail()
+	}
+}
+
+func TestHeapFunc(t *testing.T) {
+	heap := NewFunc(func(a, b string) int { return len(a) - len(b) })
+
+	for _, str := range []string{"ccc", "a", "dddd", "bb"} {
+		heap.Insert(str)
+	}
+
+	for _, str := range []string{"a", "bb", "ccc", "dddd"} {
+		if str != heap.DeleteMin() {
+			t.Fail()
+		}
+	}
+}
+
+func TestFibonacciHeapMeld(t *testing.T) {
+	heap := New()
+	other := New()
+
+	for _, number := range []int{4, 3, 9} {
+		heap.Insert(Int(number))
+	}
+	for _, number := range []int{8, 1, 5} {
+		other.Insert(Int(number))
+	}
+	heap.Meld(other)
+	heap.Meld(New())
+
+	if other.FindMin() != nil {
+		t.Fail()
+	}
+	for _, number := range []int{1, 3, 4, 5, 8, 9} {
+		if Int(number) != heap.DeleteMin().(go_heaps.Integer) {
+			t.Fail()
+		}
+	}
+}
+
+func TestFibonacciHeapAdjustDelete(t *testing.T) {
+	heap := New()
+
+	for i := 0; i < 20; i++ {
+		heap.Insert(Int(i * 10))
+	}
+	// consolidate so that adjusted items sit deep in the trees
+	heap.DeleteMin()
+
+	heap.Adjust(Int(190), Int(5))
+	heap.Adjust(Int(10), Int(200))
+	heap.Delete(Int(100))
+	if heap.Delete(Int(7)) != nil {
+		t.Fail()
+	}
+
+	numbers := []int{5, 20, 30, 40, 50, 60, 70, 80, 90, 110, 120, 130, 140, 150, 160, 170, 180, 200}
+	for _, number := range numbers {
+		if Int(number) != heap.DeleteMin().(go_heaps.Integer) {
+			t.Fail()
+		}
+	}
+	if heap.DeleteMin() != nil {
+		t.Fail()
+	}
+}
+
+func TestHandle(t *testing.T) {
+	heap := NewOrdered[int]()
+	handles := make([]*Handle[int], 50)
+	for i := range handles {
+		handles[i] = heap.InsertHandle(i)
+	}
+	heap.DeleteMin()
+
+	for i := 49; i > 25; i-- {
+		heap.DecreaseKey(handles[i], -i)
+	}
+	heap.IncreaseKey(handles[25], 100)
+	if heap.Remove(handles[1]) != 1 || handles[25].Item() != 100 {
+		t.Fail()
+	}
+
+	prev := heap.DeleteMin()
+	if prev != -49 {
+		t.Fail()
+	}
+	for i := 1; i < 48; i++ {
+		next := heap.DeleteMin()
+		if next < prev {
+			t.Fail()
+		}
+		prev = next
+	}
+	if prev != 100 {
+		t.Fail()
+	}
+}
+
 func Int(value int) go_heaps.Integer {
 	return go_heaps.Integer(value)
 }
@@ -70,3 +231,152 @@ func Int(value int) go_heaps.Integer {
 func Str(value string) go_heaps.String {
 	return go_heaps.String(value)
 }
+
+func TestExtended(t *testing.T) {
+	heaptest.RunExtendedTests(t, func() go_heaps.Extended { return New() })
+}
+
+func FuzzHeap(f *testing.F) {
+	heaptest.AddSeeds(f)
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		heaptest.FuzzExtended(t, func() go_heaps.Extended { return New() }, ops)
+		heaptest.FuzzHandles(t, func() heaptest.HandleHeap[*Handle[int]] { return NewOrdered[int]() }, ops)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	h := NewOrdered[int]()
+	for _, number := range []int{5, 3, 8, 1, 9, 4} {
+		h.Insert(number)
+	}
+	h.DeleteMin()
+	if err := h.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	h.root.degree++
+	if h.Validate() == nil {
+		t.Fail()
+	}
+}
+
+func TestIterable(t *testing.T) {
+	heaptest.RunIterableTests(t, func() heaptest.IterableHeap { return NewOrdered[int]() })
+}
+
+func TestInsertAll(t *testing.T) {
+	heaptest.RunBulkTests(t, func() heaptest.BulkHeap { return NewOrdered[int]() })
+}
+
+func TestFromSlice(t *testing.T) {
+	h := FromSliceOrdered([]int{5, 3, 8, 1})
+	if got := slices.Collect(h.Sorted()); !slices.Equal(got, []int{1, 3, 5, 8}) {
+		t.Errorf("expected %v, got %v", []int{1, 3, 5, 8}, got)
+	}
+	f := FromSlice([]go_heaps.Item{go_heaps.Integer(2), go_heaps.Integer(1)})
+	if res := f.DeleteMin(); res != go_heaps.Integer(1) {
+		t.Errorf("expected 1, got %v", res)
+	}
+}
+
+func TestClone(t *testing.T) {
+	heaptest.RunCloneTests(t, NewOrdered[int], (*Heap[int]).Clone)
+	f := New()
+	f.Insert(go_heaps.Integer(2))
+	c := f.Clone()
+	f.Insert(go_heaps.Integer(1))
+	if res := c.FindMin(); res != go_heaps.Integer(2) {
+		t.Errorf("expected 2, got %v", res)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	heaptest.RunSnapshotTests[*Heap[int], *Handle[int]](t, NewOrdered[int])
+
+	var items []int
+	for i := 1; i <= 16; i++ {
+		items = append(items, i)
+	}
+	h := FromSliceOrdered(items)
+	h.DeleteMin()
+	s := h.Snapshot()
+	if h.first != s.first || h.root != s.root {
+		t.Fatal("expected the snapshot to share the roots")
+	}
+	// deleting, adjusting and melding copy the shared nodes, and cutting
+	// children of the copies marks and cuts them
+	h.Delete(6)
+	h.Adjust(16, 0)
+	h.Adjust(12, -1)
+	h.Adjust(3, 20)
+	h.Meld(FromSliceOrdered([]int{-2, 30}))
+	h.DeleteMin()
+	want := items[1:]
+	if got := slices.Collect(s.Sorted()); !slices.Equal(got, want) {
+		t.Errorf("expected the snapshot to keep %v, got %v", want, got)
+	}
+	want = []int{-1, 0, 2, 4, 5, 7, 8, 9, 10, 11, 13, 14, 15, 20, 30}
+	if got := slices.Collect(h.Sorted()); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	for _, x := range []*Heap[int]{h, s} {
+		if err := x.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f := New()
+	f.Insert(go_heaps.Integer(2))
+	c := f.Snapshot()
+	f.Insert(go_heaps.Integer(1))
+	if res := c.FindMin(); res != go_heaps.Integer(2) {
+		t.Errorf("expected 2, got %v", res)
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	heaptest.RunEncodingTests(t, NewOrdered[int], (*Heap[int]).MarshalBinary)
+}
+
+func TestJSON(t *testing.T) {
+	heaptest.RunJSONTests(t, NewOrdered[int], func(n *go_heaps.TreeNode[int]) error {
+		if n.Attrs[0].Name != "degree" || n.Attrs[0].Value != len(n.Children) || n.Attrs[1].Name != "marked" {
+			return fmt.Errorf("attributes %v with %d children", n.Attrs, len(n.Children))
+		}
+		return nil
+	})
+
+	// the tree view shows the marks that cuts leave
+	h := NewOrdered[int]()
+	handles := make([]*Handle[int], 16)
+	for i := range handles {
+		handles[i] = h.InsertHandle(i)
+	}
+	h.DeleteMin()
+	// cut a grandchild of a root, which marks its parent
+	var grandchild int
+	for _, root := range h.Tree() {
+		for _, c := range root.Children {
+			if len(c.Children) > 0 {
+				grandchild = c.Children[0].Item
+			}
+		}
+	}
+	if grandchild == 0 {
+		t.Fatal("expected a tree of depth 2")
+	}
+	h.DecreaseKey(handles[grandchild], -1)
+	marked := 0
+	var count func(trees []*go_heaps.TreeNode[int])
+	count = func(trees []*go_heaps.TreeNode[int]) {
+		for _, n := range trees {
+			if n.Attrs[1].Value == true {
+				marked++
+			}
+			count(n.Children)
+		}
+	}
+	count(h.Tree())
+	if marked != 1 {
+		t.Errorf("expected 1 marked node, got %d", marked)
+	}
+}
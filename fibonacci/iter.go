@@ -0,0 +1,73 @@
+package fibonacci
+
+import (
+	"iter"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// Heap implements the Iterable interface
+var _ heap.Iterable[int] = (*Heap[int])(nil)
+
+// Do calls it on each item of the heap in no particular order until it
+// returns false.
+// The complexity is O(n).
+func (fh *Heap[T]) Do(it func(item T) bool) {
+	heap.DoForest(fh.roots, children[T], itemOf[T], it)
+}
+
+// Ascend calls it on each item of the heap in ascending order until it
+// returns false, without modifying the heap.
+// The complexity is O(n log n) to visit all n items.
+func (fh *Heap[T]) Ascend(it func(item T) bool) {
+	heap.AscendForest(fh.roots, children[T], itemOf[T], fh.compare, it)
+}
+
+// All returns an iterator over the items of the heap in no particular
+// order.
+func (fh *Heap[T]) All() iter.Seq[T] {
+	return fh.Do
+}
+
+// Sorted returns an iterator over the items of the heap in ascending
+// order.
+func (fh *Heap[T]) Sorted() iter.Seq[T] {
+	return fh.Ascend
+}
+
+// roots visits the minimum and then the other roots in list order.
+func (fh *Heap[T]) roots(visit func(n *node[T])) {
+	if fh.root == nil {
+		return
+	}
+	visit(fh.root)
+	for n := fh.first; n != nil; n = n.next {
+		if n != fh.root {
+			visit(n)
+		}
+	}
+}
+
+func children[T any](n *node[T], visit func(c *node[T])) {
+	ring(n.child, visit)
+}
+
+// ring visits the circular list of nodes starting at first.
+func ring[T any](first *node[T], visit func(n *node[T])) {
+	if first == nil {
+		return
+	}
+	n := first
+	for {
+		next := n.next
+		visit(n)
+		if next == first {
+			return
+		}
+		n = next
+	}
+}
+
+func itemOf[T any](n *node[T]) T {
+	return n.item
+}
@@ -0,0 +1,46 @@
+package fibonacci
+
+import (
+	"encoding/json"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+var (
+	// Heap implements the Marshaler and Unmarshaler interfaces
+	_ json.Marshaler   = (*Heap[int])(nil)
+	_ json.Unmarshaler = (*Heap[int])(nil)
+	// Heap has a tree view
+	_ heap.TreeViewer[int] = (*Heap[int])(nil)
+)
+
+// MarshalJSON encodes the items of the heap in no particular order as a
+// go_heaps.JSONView without the tree view.
+// The complexity is O(n).
+func (fh *Heap[T]) MarshalJSON() ([]byte, error) {
+	return heap.ViewOf[T](fh, false).MarshalJSON()
+}
+
+// UnmarshalJSON replaces the items of the heap with the items of the
+// go_heaps.JSONView encoded in data. The heap keeps its order and builds
+// itself with InsertAll.
+// The complexity is O(n).
+func (fh *Heap[T]) UnmarshalJSON(data []byte) error {
+	var v heap.JSONView[T]
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	fh.Clear()
+	fh.InsertAll(v.Items)
+	return nil
+}
+
+// Tree returns the trees of the heap in the order of the root list,
+// starting at the minimum. Every node has its "degree" and whether it is
+// "marked".
+// The complexity is O(n).
+func (fh *Heap[T]) Tree() []*heap.TreeNode[T] {
+	return heap.TreeOf(fh.roots, children[T], itemOf[T], func(n *node[T]) []heap.Attr {
+		return []heap.Attr{{Name: "degree", Value: n.degree}, {Name: "marked", Value: n.isMarked}}
+	})
+}
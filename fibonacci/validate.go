@@ -0,0 +1,110 @@
+package fibonacci
+
+import (
+	"errors"
+	"fmt"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// Heap implements the Validator interface
+var _ heap.Validator = (*Heap[int])(nil)
+
+// Validate checks that the root list ends at last and holds the root, that
+// the circular lists of children are linked in both directions, that the
+// root is the smallest root, that every degree matches the number of
+// children, that no child is smaller than its parent and that a node of
+// degree k has at least F(k+2) nodes in its subtree. On a heap with
+// snapshots shared nodes may point to a parent in another heap, so it does
+// not check parents there.
+// The complexity is O(n).
+func (fh *Heap[T]) Validate() error {
+	if fh.root == nil {
+		if fh.size != 0 || fh.first != nil || fh.last != nil {
+			return fmt.Errorf("fibonacci: empty heap has size %d or roots", fh.size)
+		}
+		return nil
+	}
+	count := 0
+	found := false
+	var x *node[T]
+	for x = fh.first; ; x = x.next {
+		if count >= fh.size {
+			return fmt.Errorf("fibonacci: more than %d roots or a cycle in the root list", fh.size)
+		}
+		if fh.less(x, fh.root) {
+			return fmt.Errorf("fibonacci: root %v is smaller than the minimum %v", x.item, fh.root.item)
+		}
+		if _, err := fh.validate(x, nil, &count); err != nil {
+			return err
+		}
+		found = found || x == fh.root
+		if x.next == nil {
+			break
+		}
+	}
+	if x != fh.last {
+		return errors.New("fibonacci: last is not the end of the root list")
+	}
+	if !found {
+		return fmt.Errorf("fibonacci: minimum %v is not in the root list", fh.root.item)
+	}
+	if count != fh.size {
+		return fmt.Errorf("fibonacci: heap has %d nodes but size %d", count, fh.size)
+	}
+	return nil
+}
+
+// validate checks the subtree rooted at x, whose parent is parent, and
+// returns its number of nodes.
+func (fh *Heap[T]) validate(x, parent *node[T], count *int) (int, error) {
+	if *count++; *count > fh.size {
+		return 0, fmt.Errorf("fibonacci: more than %d nodes or a cycle at %v", fh.size, x.item)
+	}
+	if x.parent != parent && (fh.owner == nil || parent == nil) {
+		return 0, fmt.Errorf("fibonacci: node %v does not point to its parent", x.item)
+	}
+	if parent != nil && (x.next.prev != x || x.prev.next != x) {
+		return 0, fmt.Errorf("fibonacci: siblings of %v are not linked back to it", x.item)
+	}
+	if x.nInf {
+		return 0, fmt.Errorf("fibonacci: node %v is marked as negative infinity", x.item)
+	}
+	if parent != nil && fh.compare(x.item, parent.item) < 0 {
+		return 0, fmt.Errorf("fibonacci: child %v is smaller than its parent %v", x.item, parent.item)
+	}
+	size, degree := 1, 0
+	if c := x.child; c != nil {
+		for {
+			n, err := fh.validate(c, x, count)
+			if err != nil {
+				return 0, err
+			}
+			size += n
+			degree++
+			if c = c.next; c == x.child {
+				break
+			}
+			if degree > x.degree {
+				return 0, fmt.Errorf("fibonacci: node %v has more than %d children", x.item, x.degree)
+			}
+		}
+	}
+	if degree != x.degree {
+		return 0, fmt.Errorf("fibonacci: node %v has degree %d but %d children", x.item, x.degree, degree)
+	}
+	if min := fib(degree + 2); size < min {
+		return 0, fmt.Errorf("fibonacci: node %v of degree %d has %d nodes, want at least %d",
+			x.item, degree, size, min)
+	}
+	return size, nil
+}
+
+// fib returns the k-th Fibonacci number.
+func fib(k int) int {
+	a, b := 0, 1
+	for ; k > 0; k-- {
+		a, b = b, a+b
+	}
+	return a
+}
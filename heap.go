@@ -1,6 +1,9 @@
 package go_heaps
 
+import "cmp"
+
 // Interface is basic interface that all Heaps implement.
+// DeleteMin and FindMin return nil when the heap is empty.
 type Interface interface {
 	// Inserts an element to the heap and returns it
 	Insert(v Item) Item
@@ -13,6 +16,12 @@ type Interface interface {
 
 	// Removes all items
 	Clear()
+
+	// Len returns the number of items in the heap
+	Len() int
+
+	// IsEmpty reports whether the heap has no items
+	IsEmpty() bool
 }
 
 // Extended adds operations on heaps are often useful.
@@ -29,6 +38,83 @@ type Extended interface {
 	Delete(item Item) Item
 }
 
+// Heap is the type-parameterized counterpart of Interface. Implementations
+// order values of type T with a comparator instead of Item.Compare, so
+// values do not need to be boxed into an Item. DeleteMin and FindMin return
+// the zero value of T when the heap is empty.
+type Heap[T any] interface {
+	// Inserts an element to the heap and returns it
+	Insert(v T) T
+
+	// DeleteMin deletes and returns the smallest element
+	DeleteMin() T
+
+	// FindMin returns the minimum element
+	FindMin() T
+
+	// Removes all items
+	Clear()
+
+	// Len returns the number of items in the heap
+	Len() int
+
+	// IsEmpty reports whether the heap has no items
+	IsEmpty() bool
+}
+
+// ExtendedHeap is the type-parameterized counterpart of Extended.
+type ExtendedHeap[T any] interface {
+	Heap[T]
+	// Return the heap formed by taking the union of the item disjoint
+	// current heap and a
+	Meld(a Heap[T]) Heap[T]
+
+	// Adjusts the key of item old in heap h to new
+	Adjust(old, new T) T
+
+	// Delete arbitrary item from heap h.
+	Delete(item T) T
+}
+
+// Validator is implemented by heaps that can verify their own structure.
+type Validator interface {
+	// Validate walks the heap and returns an error describing the first
+	// violated invariant, or nil if the heap is well formed
+	Validate() error
+}
+
+// DebugValidate panics if v is not valid. It does nothing unless the module
+// is built with the heapdebug tag, so heaps call it after every modification.
+func DebugValidate(v Validator) {
+	if Debug {
+		if err := v.Validate(); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Compare orders a and b using Item.Compare. Heaps that were created
+// without a comparator fall back to it, so T must implement Item.
+func Compare[T any](a, b T) int {
+	return any(a).(Item).Compare(any(b).(Item))
+}
+
+// ReverseFunc returns a comparator that orders values in the opposite
+// direction of compare, so a min-heap built with it behaves as a max-heap.
+func ReverseFunc[T any](compare func(a, b T) int) func(a, b T) int {
+	return func(a, b T) int {
+		return compare(b, a)
+	}
+}
+
+// CompareBy returns a comparator that orders values by the key extracted
+// from them.
+func CompareBy[T any, K cmp.Ordered](key func(v T) K) func(a, b T) int {
+	return func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
 // Item is the basic element that is inserted in a heap
 type Item interface {
 	// Should return a number:
@@ -43,6 +129,17 @@ type Item interface {
 // function will immediately return.
 type ItemIterator func(item Item) bool
 
+// Reversed wraps an Item and inverts its ordering
+type Reversed struct {
+	Item
+}
+
+// Reverse returns item wrapped so that it orders in the opposite direction,
+// turning any heap of such items into a max-heap.
+func Reverse(item Item) Item {
+	return Reversed{item}
+}
+
 // String implements the Item interface
 type String string
 
@@ -72,6 +169,10 @@ func (a String) Compare(b Item) int {
 	return 0
 }
 
+func (a Reversed) Compare(b Item) int {
+	return b.(Reversed).Item.Compare(a.Item)
+}
+
 func (a Integer) Compare(b Item) int {
 	a1 := a
 	a2 := b.(Integer)
@@ -0,0 +1,292 @@
+package go_heaps
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestReverse(t *testing.T) {
+	items := []Item{Reverse(Integer(2)), Reverse(Integer(7)), Reverse(Integer(4))}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Compare(items[j]) < 0
+	})
+
+	for i, want := range []Integer{7, 4, 2} {
+		if items[i].(Reversed).Item != want {
+			t.Errorf("expected %d at %d, got %v", want, i, items[i])
+		}
+	}
+}
+
+func TestReverseFunc(t *testing.T) {
+	compare := ReverseFunc(Compare[String])
+	if compare("a", "b") <= 0 || compare("b", "a") >= 0 || compare("a", "a") != 0 {
+		t.Fail()
+	}
+}
+
+func TestCompareBy(t *testing.T) {
+	compare := CompareBy(func(s string) int { return len(s) })
+	if compare("bb", "a") <= 0 || compare("a", "bb") >= 0 || compare("a", "b") != 0 {
+		t.Fail()
+	}
+}
+
+// tree is a heap ordered forest of int nodes stored in a slice, where the
+// children of i are 3i+2, 3i+3 and 3i+4 and the roots are 0 and 1.
+type tree []int
+
+func (t tree) roots(visit func(i int)) {
+	for i := 0; i < 2 && i < len(t); i++ {
+		visit(i)
+	}
+}
+
+func (t tree) children(i int, visit func(c int)) {
+	for c := 3*i + 2; c <= 3*i+4 && c < len(t); c++ {
+		visit(c)
+	}
+}
+
+func (t tree) item(i int) int {
+	return t[i]
+}
+
+func newTree(n int) tree {
+	t := make(tree, n)
+	for i := range t {
+		t[i] = rand.Intn(100)
+		if i > 1 {
+			t[i] += t[(i-2)/3]
+		}
+	}
+	return t
+}
+
+func TestDoForest(t *testing.T) {
+	tr := newTree(50)
+	var got []int
+	DoForest(tr.roots, tr.children, tr.item, func(v int) bool {
+		got = append(got, v)
+		return true
+	})
+	want := []int{tr[0], tr[2], tr[8], tr[26]}
+	if !slices.Equal(got[:4], want) {
+		t.Errorf("expected preorder starting with %v, got %v", want, got[:4])
+	}
+	slices.Sort(got)
+	sorted := slices.Sorted(slices.Values(tr))
+	if !slices.Equal(got, sorted) {
+		t.Errorf("expected %v, got %v", sorted, got)
+	}
+}
+
+func TestAscendForest(t *testing.T) {
+	tr := newTree(200)
+	var got []int
+	AscendForest(tr.roots, tr.children, tr.item, func(a, b int) int { return a - b }, func(v int) bool {
+		got = append(got, v)
+		return len(got) < 150
+	})
+	want := slices.Sorted(slices.Values(tr))[:150]
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestEncodeForest(t *testing.T) {
+	tr := newTree(50)
+	// items are the node indices, so the decoded links can be checked
+	items, shape := EncodeForest(tr.roots, tr.children, func(i int) int { return i })
+	if len(items) != 50 || len(shape) != 50 || items[0] != 0 || items[1] != 2 {
+		t.Fatalf("expected 50 nodes in preorder, got %v with shape %v", items, shape)
+	}
+	links := 0
+	err := DecodeForest(shape, func(i, parent, prev int) {
+		links++
+		n := items[i]
+		if parent < 0 && n > 1 || parent >= 0 && items[parent] != (n-2)/3 {
+			t.Errorf("node %d got parent %d", n, parent)
+		}
+		if prev >= 0 && items[prev] != n-1 {
+			t.Errorf("node %d got previous sibling %d", n, items[prev])
+		}
+	})
+	if err != nil || links != 50 {
+		t.Errorf("expected 50 links, got %d: %v", links, err)
+	}
+	for _, bad := range [][]int{{1}, {2, 0}, {0, -1}} {
+		if err := DecodeForest(bad, func(i, parent, prev int) {}); err == nil {
+			t.Errorf("DecodeForest(%v) = nil, want an error", bad)
+		}
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	e := Encoding[Item]{Kind: "test", Params: []int{-3, 7}, Items: []Item{Integer(1), String("a"), Integer(3)}, Shape: []int{2, 0, 0}}
+	data, err := e.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Encoding[Item]
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if got.Kind != e.Kind || !slices.Equal(got.Params, e.Params) || !slices.Equal(got.Items, e.Items) ||
+		!slices.Equal(got.Shape, e.Shape) {
+		t.Errorf("expected %v, got %v", e, got)
+	}
+	for i := range data {
+		if err := got.UnmarshalBinary(data[:i]); err == nil {
+			t.Errorf("UnmarshalBinary of %d of %d bytes = nil, want an error", i, len(data))
+		}
+	}
+	e.Shape = e.Shape[1:]
+	if _, err := e.MarshalBinary(); err == nil {
+		t.Error("expected an error for a shape that does not fit the items")
+	}
+	items, err := UnmarshalItems[Item](data)
+	if err != nil || len(items) != 3 {
+		t.Errorf("expected 3 items, got %v: %v", items, err)
+	}
+}
+
+// celsius has a Codec that counts its calls.
+type celsius float64
+
+type celsiusCodec struct {
+	calls *int
+}
+
+func (c celsiusCodec) EncodeItems(items []celsius) ([]byte, error) {
+	*c.calls++
+	var b []byte
+	for _, v := range items {
+		b = append(b, byte(v))
+	}
+	return b, nil
+}
+
+func (c celsiusCodec) DecodeItems(data []byte) ([]celsius, error) {
+	*c.calls++
+	items := make([]celsius, len(data))
+	for i, v := range data {
+		items[i] = celsius(v)
+	}
+	return items, nil
+}
+
+func TestRegisterCodec(t *testing.T) {
+	if _, ok := CodecFor[celsius]().(GobCodec[celsius]); !ok {
+		t.Errorf("expected GobCodec before registering, got %T", CodecFor[celsius]())
+	}
+	calls := 0
+	RegisterCodec[celsius](celsiusCodec{&calls})
+	data, err := MarshalItems(slices.Values([]celsius{21, 37}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	items, err := UnmarshalItems[celsius](data)
+	if err != nil || !slices.Equal(items, []celsius{21, 37}) || calls != 2 {
+		t.Errorf("expected [21 37] in 2 calls, got %v in %d: %v", items, calls, err)
+	}
+}
+
+// point is an Item registered for JSON.
+type point struct {
+	X, Y int
+}
+
+func (p point) Compare(than Item) int {
+	return p.X - than.(point).X
+}
+
+// registerPoint registers point once, however often the tests run.
+var registerPoint sync.Once
+
+func TestRegisterItem(t *testing.T) {
+	registerPoint.Do(func() { RegisterItem("point", point{}) })
+	v := JSONView[Item]{Items: []Item{Integer(1), Reverse(String("a")), point{2, 3}, nil}}
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"items":[{"type":"Integer","value":1},{"type":"Reversed","value":{"type":"String","value":"a"}},` +
+		`{"type":"point","value":{"X":2,"Y":3}},null]}`
+	if string(data) != want {
+		t.Errorf("expected %s, got %s", want, data)
+	}
+	var got JSONView[Item]
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got.Items, v.Items) {
+		t.Errorf("expected %v, got %v", v.Items, got.Items)
+	}
+
+	if _, err := json.Marshal(JSONView[Item]{Items: []Item{Reverse(celsiusItem(1))}}); err == nil {
+		t.Error("expected an error for an unregistered item type")
+	}
+	if err := json.Unmarshal([]byte(`{"items":[{"type":"kelvin","value":1}]}`), &got); err == nil {
+		t.Error("expected an error for an unregistered item name")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a name registered twice")
+		}
+	}()
+	RegisterItem("point", celsiusItem(0))
+}
+
+// celsiusItem is an Item that is not registered for JSON.
+type celsiusItem float64
+
+func (c celsiusItem) Compare(than Item) int {
+	return Compare(c, than.(celsiusItem))
+}
+
+func TestTreeOf(t *testing.T) {
+	tr := newTree(5)
+	trees := TreeOf(tr.roots, tr.children, tr.item, func(i int) []Attr {
+		return []Attr{{Name: "index", Value: i}}
+	})
+	data, err := json.Marshal(JSONView[int]{Items: tr, Tree: trees})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := fmt.Sprintf(`{"items":[%d,%d,%d,%d,%d],"tree":[{"item":%d,"index":0,"children":[{"item":%d,"index":2},`+
+		`{"item":%d,"index":3},{"item":%d,"index":4}]},{"item":%d,"index":1}]}`,
+		tr[0], tr[1], tr[2], tr[3], tr[4], tr[0], tr[2], tr[3], tr[4], tr[1])
+	if string(data) != want {
+		t.Errorf("expected %s, got %s", want, data)
+	}
+
+	// a missing child is null, and deep trees are written without recursion
+	var path []int
+	for i := range 100000 {
+		path = append(path, i)
+	}
+	trees = TreeOf(func(visit func(i int)) { visit(0) }, func(i int, visit func(c int)) {
+		if i+1 < len(path) {
+			visit(0)
+			visit(i + 1)
+		}
+	}, func(i int) int { return path[i] }, nil)
+	data, err = trees[0].MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prefix := `{"item":0,"children":[null,{"item":1,"children":[null,`; !strings.HasPrefix(string(data), prefix) {
+		t.Errorf("expected a prefix %s, got %.60s", prefix, data)
+	}
+	if suffix := `null,{"item":99999}` + strings.Repeat("]}", 99999); !strings.HasSuffix(string(data), suffix) {
+		t.Errorf("expected the last node to close all others, got %.60s", data[len(data)-len(suffix):])
+	}
+}
@@ -0,0 +1,87 @@
+package heaptest
+
+import (
+	"math/rand"
+	"slices"
+	"strconv"
+	"testing"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// BulkHeap is a heap of ints that can insert many items at once.
+type BulkHeap interface {
+	heap.Heap[int]
+	InsertAll(items []int)
+}
+
+// BulkFactory returns a new, empty bulk heap.
+type BulkFactory func() BulkHeap
+
+// RunBulkTests checks InsertAll on the heaps returned by factory: it keeps
+// duplicates, adds to the items already in the heap, accepts an empty
+// slice and leaves its argument unchanged.
+func RunBulkTests(t *testing.T, factory BulkFactory) {
+	t.Helper()
+	t.Run("Empty", func(t *testing.T) {
+		h := factory()
+		h.InsertAll(nil)
+		if !h.IsEmpty() || h.Len() != 0 {
+			t.Errorf("InsertAll(nil): IsEmpty() = %v, Len() = %d", h.IsEmpty(), h.Len())
+		}
+		h.InsertAll([]int{3})
+		checkInts(t, "InsertAll of one item", h, []int{3})
+	})
+	for _, n := range []int{2, 7, 64, 1000} {
+		t.Run("Random"+strconv.Itoa(n), func(t *testing.T) {
+			r := rand.New(rand.NewSource(int64(n)))
+			items := make([]int, n)
+			for i := range items {
+				items[i] = r.Intn(n)
+			}
+			input := slices.Clone(items)
+			h := factory()
+			h.InsertAll(input)
+			if !slices.Equal(input, items) {
+				t.Fatalf("InsertAll modified its argument")
+			}
+			checkInts(t, "InsertAll", h, items)
+		})
+	}
+	t.Run("NonEmpty", func(t *testing.T) {
+		h := factory()
+		var want []int
+		for i := 0; i < 300; i += 3 {
+			h.Insert(i)
+			want = append(want, i)
+		}
+		// the items interleave with the ones in the heap
+		var more []int
+		for i := 299; i > 0; i -= 2 {
+			more = append(more, i)
+		}
+		h.DeleteMin()
+		h.InsertAll(more)
+		want = append(want[1:], more...)
+		checkInts(t, "InsertAll into a non-empty heap", h, want)
+	})
+}
+
+// checkInts validates h and checks that it holds want by draining it.
+func checkInts(t *testing.T, name string, h heap.Heap[int], want []int) {
+	t.Helper()
+	if v, ok := h.(heap.Validator); ok {
+		if err := v.Validate(); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+	if h.Len() != len(want) {
+		t.Fatalf("%s: Len() = %d, want %d", name, h.Len(), len(want))
+	}
+	want = slices.Sorted(slices.Values(want))
+	for i, w := range want {
+		if v := h.DeleteMin(); v != w {
+			t.Fatalf("%s: DeleteMin() #%d = %d, want %d", name, i, v, w)
+		}
+	}
+}
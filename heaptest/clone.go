@@ -0,0 +1,163 @@
+package heaptest
+
+import (
+	"math/rand"
+	"slices"
+	"strconv"
+	"testing"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// RunCloneTests checks that copy, such as a Clone or Snapshot method,
+// returns heaps that are independent of the heap they were copied from. It
+// copies the heaps returned by factory and their copies at random points
+// of a random sequence of insertions, deletions, adjustments and melds
+// spread over all of them and checks every heap against its own model.
+func RunCloneTests[H heap.Heap[int]](t *testing.T, factory func() H, copy func(h H) H) {
+	t.Helper()
+	t.Run("Empty", func(t *testing.T) {
+		h := factory()
+		c := copy(h)
+		h.Insert(1)
+		checkInts(t, "copy of an empty heap", c, nil)
+		c.Insert(2)
+		checkInts(t, "heap", h, []int{1})
+	})
+	for _, seed := range []int64{1, 2, 3} {
+		t.Run("Random"+strconv.FormatInt(seed, 10), func(t *testing.T) {
+			r := rand.New(rand.NewSource(seed))
+			heaps := []H{factory()}
+			models := []*model{{}}
+			for range 2000 {
+				i := r.Intn(len(heaps))
+				h, m := heaps[i], models[i]
+				a, canAdjust := any(h).(adjuster)
+				d, canDelete := any(h).(deleter)
+				ml, canMeld := any(h).(melder)
+				switch op := r.Intn(20); {
+				case op == 0 && len(heaps) < 20:
+					heaps = append(heaps, copy(h))
+					models = append(models, &model{items: slices.Clone(m.items)})
+				case op == 1 && len(heaps) > 1 && canMeld:
+					j := (i + 1 + r.Intn(len(heaps)-1)) % len(heaps)
+					ml.Meld(heaps[j])
+					for _, v := range models[j].items {
+						m.insert(v)
+					}
+					models[j].items = nil
+				case op < 4 && len(m.items) > 0 && canAdjust:
+					old, new := m.items[r.Intn(len(m.items))], r.Intn(100)
+					a.Adjust(old, new)
+					m.remove(old)
+					m.insert(new)
+				case op < 5 && canDelete:
+					v, want := r.Intn(100), 0
+					if m.remove(v) {
+						want = v
+					}
+					if got := d.Delete(v); got != want {
+						t.Fatalf("heap %d: Delete(%d) = %d, want %d", i, v, got, want)
+					}
+				case op < 14 || len(m.items) == 0:
+					v := r.Intn(100)
+					h.Insert(v)
+					m.insert(v)
+				default:
+					if v := h.DeleteMin(); v != m.items[0] {
+						t.Fatalf("heap %d: DeleteMin() = %d, want %d", i, v, m.items[0])
+					}
+					m.items = m.items[1:]
+				}
+			}
+			for i, h := range heaps {
+				checkInts(t, "heap "+strconv.Itoa(i), h, models[i].items)
+			}
+		})
+	}
+}
+
+// adjuster is implemented by heaps that can change the value of an item.
+type adjuster interface {
+	Adjust(old, new int) int
+}
+
+// deleter is implemented by heaps that can delete any item.
+type deleter interface {
+	Delete(item int) int
+}
+
+// melder is implemented by heaps that can meld another heap into them.
+type melder interface {
+	Meld(a heap.Heap[int]) heap.Heap[int]
+}
+
+// SnapshotHeap is a heap of ints with handles of type D that can take
+// copy-on-write snapshots of itself.
+type SnapshotHeap[H any, D Handle] interface {
+	HandleHeap[D]
+	Snapshot() H
+	Clone() H
+	Meld(a heap.Heap[int]) heap.Heap[int]
+}
+
+// RunSnapshotTests runs RunCloneTests with Snapshot as the copy and checks
+// how snapshots affect handles: InsertHandle panics on a heap and on its
+// snapshot, a Clone and a cleared heap support handles again, and melding
+// a heap with snapshots into one without them keeps the handles of the
+// receiver working.
+//
+// The type of the handles cannot be inferred, so callers name both types:
+//
+//	heaptest.RunSnapshotTests[*Heap[int], *Handle[int]](t, NewOrdered[int])
+func RunSnapshotTests[H SnapshotHeap[H, D], D Handle](t *testing.T, factory func() H) {
+	t.Helper()
+	RunCloneTests(t, factory, func(h H) H { return h.Snapshot() })
+	fill := func() H {
+		h := factory()
+		for i := 1; i <= 8; i++ {
+			h.Insert(i)
+		}
+		return h
+	}
+	t.Run("Handles", func(t *testing.T) {
+		h := fill()
+		s := h.Snapshot()
+		mustPanic(t, "InsertHandle on a heap with a snapshot", func() { h.InsertHandle(0) })
+		mustPanic(t, "InsertHandle on a snapshot", func() { s.InsertHandle(0) })
+
+		c := s.Clone()
+		hd := c.InsertHandle(0)
+		c.DecreaseKey(hd, -1)
+		checkInts(t, "clone of a snapshot", c, []int{-1, 1, 2, 3, 4, 5, 6, 7, 8})
+
+		s.Clear()
+		hd = s.InsertHandle(3)
+		s.IncreaseKey(hd, 4)
+		checkInts(t, "cleared snapshot", s, []int{4})
+		checkInts(t, "heap", h, ints(1, 9))
+	})
+	t.Run("Meld", func(t *testing.T) {
+		h := fill()
+		other := factory()
+		hd := other.InsertHandle(9)
+		other.Meld(h.Snapshot())
+		other.DecreaseKey(hd, 0)
+		if got := other.Remove(other.InsertHandle(5)); got != 5 {
+			t.Fatalf("Remove() = %d, want 5", got)
+		}
+		checkInts(t, "receiver of a snapshot", other, ints(0, 9))
+		checkInts(t, "heap", h, ints(1, 9))
+	})
+}
+
+// mustPanic fails t if f does not panic.
+func mustPanic(t *testing.T, name string, f func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("%s: expected a panic", name)
+		}
+	}()
+	f()
+}
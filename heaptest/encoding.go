@@ -0,0 +1,88 @@
+package heaptest
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"math/rand"
+	"testing"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// EncodingHeap is a heap of ints that can be marshaled.
+type EncodingHeap interface {
+	heap.Heap[int]
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+// RunEncodingTests checks that the heaps returned by factory round-trip
+// through marshal, such as a MarshalBinary or MarshalShape method, and
+// UnmarshalBinary, and through gob, without changing the marshaled heap.
+// It also checks that UnmarshalBinary rejects malformed data.
+func RunEncodingTests[H EncodingHeap](t *testing.T, factory func() H, marshal func(h H) ([]byte, error)) {
+	t.Helper()
+	for _, n := range []int{0, 1, 10, 1000} {
+		h, items := fill(factory, n)
+		data, err := marshal(h)
+		if err != nil {
+			t.Fatalf("marshal %d items: %v", n, err)
+		}
+		loaded := factory()
+		loaded.Insert(-1)
+		if err := loaded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary of %d items: %v", n, err)
+		}
+		checkInts(t, "unmarshaled heap", loaded, items)
+		checkInts(t, "marshaled heap", h, items)
+	}
+	t.Run("Gob", func(t *testing.T) {
+		h, items := fill(factory, 100)
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(h); err != nil {
+			t.Fatal(err)
+		}
+		loaded := factory()
+		if err := gob.NewDecoder(&buf).Decode(loaded); err != nil {
+			t.Fatal(err)
+		}
+		checkInts(t, "gob decoded heap", loaded, items)
+	})
+	t.Run("Malformed", func(t *testing.T) {
+		h, _ := fill(factory, 10)
+		data, err := marshal(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, bad := range [][]byte{nil, []byte("heap"), data[:len(data)/2], append([]byte("pile"), data[4:]...)} {
+			if err := factory().UnmarshalBinary(bad); err == nil {
+				t.Errorf("UnmarshalBinary(%q) = nil, want an error", bad)
+			}
+		}
+	})
+}
+
+// fill returns a heap from factory with n random items, a tenth of which
+// were deleted again, and the items left in it.
+func fill[H heap.Heap[int]](factory func() H, n int) (H, []int) {
+	h := factory()
+	r := rand.New(rand.NewSource(int64(n)))
+	var items []int
+	for range n {
+		v := r.Intn(n)
+		h.Insert(v)
+		items = append(items, v)
+	}
+	// a few deletions leave the heap in a less regular shape
+	for range n / 10 {
+		min := h.DeleteMin()
+		for i, v := range items {
+			if v == min {
+				items = append(items[:i], items[i+1:]...)
+				break
+			}
+		}
+	}
+	return h, items
+}
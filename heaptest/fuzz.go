@@ -0,0 +1,77 @@
+package heaptest
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// AddSeeds adds operation sequences that exercise every operation checked
+// by the fuzz functions of this package to the seed corpus of f.
+func AddSeeds(f *testing.F) {
+	f.Add([]byte{})
+	// ten inserts followed by ten deletions of the minimum
+	f.Add([]byte{
+		0, 9, 0, 3, 0, 7, 0, 3, 0, 1, 0, 8, 0, 2, 0, 6, 0, 4, 0, 5,
+		9, 9, 9, 9, 9, 9, 9, 9, 9, 9,
+	})
+	for seed := int64(1); seed <= 8; seed++ {
+		ops := make([]byte, 64*seed)
+		rand.New(rand.NewSource(seed)).Read(ops)
+		f.Add(ops)
+	}
+}
+
+// FuzzInterface decodes ops into a sequence of Insert, DeleteMin, FindMin
+// and Clear operations, applies them to a heap returned by factory and to
+// a sorted slice and fails t on the first difference. Heaps that implement
+// go_heaps.Validator are validated after every operation.
+//
+// Packages call it from their fuzz targets:
+//
+//	func FuzzHeap(f *testing.F) {
+//		heaptest.AddSeeds(f)
+//		f.Fuzz(func(t *testing.T, ops []byte) {
+//			heaptest.FuzzInterface(t, func() heap.Interface { return New() }, ops)
+//		})
+//	}
+func FuzzInterface(t *testing.T, factory Factory, ops []byte) {
+	t.Helper()
+	src := byteSource(ops)
+	newChecker(t, "fuzz", &src, factory(), nil).run()
+}
+
+// FuzzExtended is like FuzzInterface but also decodes Delete, Adjust and
+// Meld operations.
+func FuzzExtended(t *testing.T, factory ExtendedFactory, ops []byte) {
+	t.Helper()
+	src := byteSource(ops)
+	newChecker(t, "fuzz", &src, factory(), factory).run()
+}
+
+// FuzzHandles decodes ops into a sequence of Insert, InsertHandle,
+// DeleteMin, FindMin, Clear and Meld operations and of Remove, DecreaseKey
+// and IncreaseKey operations through the handles, applies them to a heap
+// returned by factory and to a sorted slice and fails t on the first
+// difference. After every operation each handle must still reference its
+// item. Meld is only decoded for heaps that implement it.
+//
+// The type of the handles is inferred from factory:
+//
+//	heaptest.FuzzHandles(t, func() heaptest.HandleHeap[*Handle[int]] { return NewOrdered[int]() }, ops)
+func FuzzHandles[H Handle](t *testing.T, factory func() HandleHeap[H], ops []byte) {
+	t.Helper()
+	src := byteSource(ops)
+	c := &handleChecker[H]{t: t, name: "fuzz", src: &src, h: factory(), factory: factory}
+	c.run()
+}
+
+// FuzzSplit decodes ops into a sequence of Insert, DeleteMin, Clear,
+// Delete, Contains and Split operations, joining the two halves again
+// after each Split, applies them to a heap returned by factory and to a
+// sorted slice and fails t on the first difference.
+func FuzzSplit[H SplitHeap[H]](t *testing.T, factory func() H, ops []byte) {
+	t.Helper()
+	src := byteSource(ops)
+	c := &splitChecker[H]{t: t, name: "fuzz", src: &src, h: factory()}
+	c.run()
+}
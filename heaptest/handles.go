@@ -0,0 +1,210 @@
+package heaptest
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// Handle is a reference to an item of a HandleHeap.
+type Handle interface {
+	// Item returns the item referenced by the handle
+	Item() int
+}
+
+// HandleHeap is a heap of ints whose items can be changed and removed
+// through the handles of type H returned by InsertHandle.
+type HandleHeap[H Handle] interface {
+	heap.Heap[int]
+	InsertHandle(item int) H
+	Remove(h H) int
+	DecreaseKey(h H, item int)
+	IncreaseKey(h H, item int)
+}
+
+// idSpace separates the key of an item checked by a handleChecker from the
+// sequence number that makes it unique: an item is key*idSpace + id. Fuzz
+// inputs are too short to use up the sequence numbers.
+const idSpace = 1 << 20
+
+// handleEntry is an item of the heap that was inserted with InsertHandle.
+type handleEntry[H Handle] struct {
+	item   int
+	handle H
+}
+
+// handleChecker applies the operations chosen by a source to a HandleHeap
+// and to a model and fails the test on the first difference. Every item is
+// unique, so the checker knows which handles DeleteMin invalidates.
+type handleChecker[H Handle] struct {
+	t *testing.T
+	// name identifies the sequence of operations in failures
+	name    string
+	src     source
+	h       HandleHeap[H]
+	factory func() HandleHeap[H]
+	m       model
+	// live holds the handles that are still valid in insertion order
+	live []handleEntry[H]
+	next int
+	step int
+}
+
+func (c *handleChecker[H]) fatalf(format string, args ...interface{}) {
+	c.t.Helper()
+	c.t.Fatalf("%s step %d: %s", c.name, c.step, fmt.Sprintf(format, args...))
+}
+
+// item returns a new unique item with the given key.
+func (c *handleChecker[H]) item(key int) int {
+	c.next++
+	return key*idSpace + c.next
+}
+
+// forget drops the handle of item, if it has one.
+func (c *handleChecker[H]) forget(item int) {
+	c.live = slices.DeleteFunc(c.live, func(e handleEntry[H]) bool { return e.item == item })
+}
+
+// run performs operations until the source is used up, checking Len,
+// IsEmpty, Validate and the items of all live handles after each of them,
+// and finally drains the heap.
+func (c *handleChecker[H]) run() {
+	c.t.Helper()
+	for ; c.src.more(); c.step++ {
+		op := c.do()
+		if c.h.Len() != len(c.m.items) || c.h.IsEmpty() != (len(c.m.items) == 0) {
+			c.fatalf("after %s Len() = %d, IsEmpty() = %v, want %d items",
+				op, c.h.Len(), c.h.IsEmpty(), len(c.m.items))
+		}
+		if v, ok := c.h.(heap.Validator); ok {
+			if err := v.Validate(); err != nil {
+				c.fatalf("after %s: %v", op, err)
+			}
+		}
+		for _, e := range c.live {
+			if got := e.handle.Item(); got != e.item {
+				c.fatalf("after %s handle of %d references %d", op, e.item, got)
+			}
+		}
+	}
+	checkInts(c.t, c.name, c.h, c.m.items)
+}
+
+// insert adds a new item with a random key to h, with a handle if the
+// source chooses so, and returns the item.
+func (c *handleChecker[H]) insert(h HandleHeap[H]) int {
+	item := c.item(c.src.Intn(50))
+	if c.src.Intn(4) == 0 {
+		h.Insert(item)
+	} else {
+		c.live = append(c.live, handleEntry[H]{item, h.InsertHandle(item)})
+	}
+	c.m.insert(item)
+	return item
+}
+
+// do performs one operation and returns its description.
+func (c *handleChecker[H]) do() string {
+	c.t.Helper()
+	switch n := c.src.Intn(20); {
+	case n < 7:
+		return fmt.Sprintf("Insert(%d)", c.insert(c.h))
+	case n < 10:
+		var want int
+		if len(c.m.items) > 0 {
+			want = c.m.items[0]
+			c.m.items = c.m.items[1:]
+			c.forget(want)
+		}
+		if got := c.h.DeleteMin(); got != want {
+			c.fatalf("DeleteMin() = %d, want %d", got, want)
+		}
+		return "DeleteMin()"
+	case n < 11:
+		var want int
+		if len(c.m.items) > 0 {
+			want = c.m.items[0]
+		}
+		if got := c.h.FindMin(); got != want {
+			c.fatalf("FindMin() = %d, want %d", got, want)
+		}
+		return "FindMin()"
+	case n < 12:
+		if c.src.Intn(4) != 0 {
+			return "nothing"
+		}
+		c.h.Clear()
+		c.m.items, c.live = nil, nil
+		return "Clear()"
+	case n < 18:
+		if len(c.live) == 0 {
+			return "nothing"
+		}
+		return c.doHandle()
+	default:
+		return c.doMeld()
+	}
+}
+
+// doHandle performs a Remove, DecreaseKey or IncreaseKey through a live
+// handle and returns its description.
+func (c *handleChecker[H]) doHandle() string {
+	c.t.Helper()
+	i := c.src.Intn(len(c.live))
+	e := c.live[i]
+	key := e.item / idSpace
+	switch c.src.Intn(3) {
+	case 0:
+		if got := c.h.Remove(e.handle); got != e.item {
+			c.fatalf("Remove(handle of %d) = %d", e.item, got)
+		}
+		c.m.remove(e.item)
+		c.live = slices.Delete(c.live, i, i+1)
+		return fmt.Sprintf("Remove(%d)", e.item)
+	case 1:
+		if key == 0 {
+			return "nothing"
+		}
+		// a smaller key, since the item gets a greater sequence number
+		item := c.item(c.src.Intn(key))
+		c.h.DecreaseKey(e.handle, item)
+		c.m.remove(e.item)
+		c.m.insert(item)
+		c.live[i].item = item
+		return fmt.Sprintf("DecreaseKey(%d, %d)", e.item, item)
+	default:
+		item := c.item(key + c.src.Intn(50))
+		c.h.IncreaseKey(e.handle, item)
+		c.m.remove(e.item)
+		c.m.insert(item)
+		c.live[i].item = item
+		return fmt.Sprintf("IncreaseKey(%d, %d)", e.item, item)
+	}
+}
+
+// doMeld melds a new heap with a few items into the heap if it supports
+// Meld. The handles of the melded heap stay valid.
+func (c *handleChecker[H]) doMeld() string {
+	c.t.Helper()
+	h, ok := c.h.(interface {
+		Meld(a heap.Heap[int]) heap.Heap[int]
+	})
+	if !ok {
+		return "nothing"
+	}
+	other := c.factory()
+	var items []int
+	for i := c.src.Intn(8); i > 0; i-- {
+		items = append(items, c.insert(other))
+	}
+	if got := h.Meld(other); got != heap.Heap[int](c.h) {
+		c.fatalf("Meld(%v) returned a different heap", items)
+	}
+	if !other.IsEmpty() {
+		c.fatalf("melded argument not empty: Len() = %d", other.Len())
+	}
+	return fmt.Sprintf("Meld(%v)", items)
+}
@@ -0,0 +1,240 @@
+// Package heaptest provides a conformance test suite for implementations
+// of go_heaps.Interface and go_heaps.Extended.
+//
+// Every heap package of this module runs it from its own tests:
+//
+//	func TestInterface(t *testing.T) {
+//		heaptest.RunInterfaceTests(t, func() heap.Interface { return New() })
+//	}
+package heaptest
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// Factory returns a new, empty heap.
+type Factory func() heap.Interface
+
+// ExtendedFactory returns a new, empty extended heap.
+type ExtendedFactory func() heap.Extended
+
+// modelSeeds is the number of seeded random operation sequences checked
+// against the model.
+const modelSeeds = 50
+
+// RunInterfaceTests checks that the heaps returned by factory implement
+// go_heaps.Interface: items come out in order, duplicates are kept,
+// Len and IsEmpty track the contents and an empty heap returns nil from
+// FindMin and DeleteMin. Heaps that implement go_heaps.Validator are
+// validated after every operation of the randomized tests.
+func RunInterfaceTests(t *testing.T, factory Factory) {
+	t.Helper()
+	t.Run("Empty", func(t *testing.T) { testEmpty(t, factory()) })
+	t.Run("Ordering", func(t *testing.T) { testOrdering(t, factory) })
+	t.Run("Duplicates", func(t *testing.T) { testDuplicates(t, factory()) })
+	t.Run("Strings", func(t *testing.T) { testStrings(t, factory()) })
+	t.Run("Clear", func(t *testing.T) { testClear(t, factory()) })
+	t.Run("Model", func(t *testing.T) {
+		for seed := int64(1); seed <= modelSeeds; seed++ {
+			newChecker(t, fmt.Sprintf("seed %d", seed), newRandSource(seed), factory(), nil).run()
+		}
+	})
+}
+
+// RunExtendedTests runs RunInterfaceTests and additionally checks Meld,
+// Adjust and Delete. Meld is expected to leave its argument empty, and
+// Adjust and Delete to return nil for items that are not in the heap.
+func RunExtendedTests(t *testing.T, factory ExtendedFactory) {
+	t.Helper()
+	RunInterfaceTests(t, func() heap.Interface { return factory() })
+	t.Run("Meld", func(t *testing.T) { testMeld(t, factory) })
+	t.Run("Adjust", func(t *testing.T) { testAdjust(t, factory()) })
+	t.Run("Delete", func(t *testing.T) { testDelete(t, factory()) })
+	t.Run("ExtendedModel", func(t *testing.T) {
+		for seed := int64(1); seed <= modelSeeds; seed++ {
+			newChecker(t, fmt.Sprintf("seed %d", seed), newRandSource(seed), factory(), factory).run()
+		}
+	})
+}
+
+func testEmpty(t *testing.T, h heap.Interface) {
+	if !h.IsEmpty() || h.Len() != 0 {
+		t.Errorf("new heap: IsEmpty() = %v, Len() = %d", h.IsEmpty(), h.Len())
+	}
+	if v := h.FindMin(); v != nil {
+		t.Errorf("FindMin() on empty heap = %v, want nil", v)
+	}
+	if v := h.DeleteMin(); v != nil {
+		t.Errorf("DeleteMin() on empty heap = %v, want nil", v)
+	}
+	h.Insert(heap.Integer(1))
+	h.DeleteMin()
+	if v := h.DeleteMin(); v != nil || !h.IsEmpty() || h.Len() != 0 {
+		t.Errorf("heap emptied by DeleteMin: DeleteMin() = %v, IsEmpty() = %v, Len() = %d",
+			v, h.IsEmpty(), h.Len())
+	}
+}
+
+func testOrdering(t *testing.T, factory Factory) {
+	inputs := map[string][]int{
+		"sorted":  ints(0, 100),
+		"reverse": reverse(ints(0, 100)),
+		"random":  rand.New(rand.NewSource(1)).Perm(100),
+	}
+	for name, input := range inputs {
+		h := factory()
+		for _, v := range input {
+			if got := h.Insert(heap.Integer(v)); got != heap.Integer(v) {
+				t.Errorf("%s: Insert(%d) = %v", name, v, got)
+			}
+		}
+		if h.Len() != len(input) {
+			t.Errorf("%s: Len() = %d, want %d", name, h.Len(), len(input))
+		}
+		if got := h.FindMin(); got != heap.Integer(0) {
+			t.Errorf("%s: FindMin() = %v, want 0", name, got)
+		}
+		checkDrain(t, name, h, ints(0, 100))
+	}
+}
+
+func testDuplicates(t *testing.T, h heap.Interface) {
+	input := []int{3, 1, 3, 2, 1, 3, 2, 1, 1}
+	for _, v := range input {
+		h.Insert(heap.Integer(v))
+	}
+	want := append([]int(nil), input...)
+	sort.Ints(want)
+	checkDrain(t, "duplicates", h, want)
+}
+
+func testStrings(t *testing.T, h heap.Interface) {
+	for _, s := range []string{"d", "a", "ccc", "bb", "a"} {
+		h.Insert(heap.String(s))
+	}
+	for _, want := range []string{"a", "a", "bb", "ccc", "d"} {
+		if got := h.DeleteMin(); got != heap.String(want) {
+			t.Errorf("DeleteMin() = %v, want %q", got, want)
+		}
+	}
+}
+
+func testClear(t *testing.T, h heap.Interface) {
+	for _, v := range ints(0, 10) {
+		h.Insert(heap.Integer(v))
+	}
+	h.Clear()
+	if !h.IsEmpty() || h.Len() != 0 || h.FindMin() != nil {
+		t.Errorf("after Clear: IsEmpty() = %v, Len() = %d, FindMin() = %v",
+			h.IsEmpty(), h.Len(), h.FindMin())
+	}
+	h.Insert(heap.Integer(5))
+	h.Insert(heap.Integer(4))
+	checkDrain(t, "after Clear", h, []int{4, 5})
+}
+
+func testMeld(t *testing.T, factory ExtendedFactory) {
+	cases := []struct {
+		name string
+		a, b []int
+	}{
+		{"both", []int{2, 8, 5, 7}, []int{4, 9, 6, 1}},
+		{"single", []int{2, 8, 5, 7}, []int{4}},
+		{"into single", []int{4}, []int{2, 8, 5, 7}},
+		{"empty argument", []int{2, 8}, nil},
+		{"into empty", nil, []int{2, 8}},
+		{"duplicates", []int{1, 1, 3}, []int{1, 3, 3}},
+	}
+	for _, c := range cases {
+		a, b := factory(), factory()
+		for _, v := range c.a {
+			a.Insert(heap.Integer(v))
+		}
+		for _, v := range c.b {
+			b.Insert(heap.Integer(v))
+		}
+		melded := a.Meld(b)
+		if !b.IsEmpty() || b.Len() != 0 {
+			t.Errorf("%s: melded argument not empty: Len() = %d", c.name, b.Len())
+		}
+		want := append(append([]int(nil), c.a...), c.b...)
+		sort.Ints(want)
+		checkDrain(t, c.name, melded, want)
+	}
+}
+
+func testAdjust(t *testing.T, h heap.Extended) {
+	for _, v := range ints(0, 10) {
+		h.Insert(heap.Integer(v * 10))
+	}
+	if got := h.Adjust(heap.Integer(55), heap.Integer(1)); got != nil {
+		t.Errorf("Adjust of missing item = %v, want nil", got)
+	}
+	// decrease to the new minimum, increase past the maximum and move
+	// the current minimum
+	h.Adjust(heap.Integer(50), heap.Integer(-1))
+	h.Adjust(heap.Integer(20), heap.Integer(95))
+	h.Adjust(heap.Integer(-1), heap.Integer(35))
+	if h.Len() != 10 {
+		t.Errorf("Len() after Adjust = %d, want 10", h.Len())
+	}
+	checkDrain(t, "adjust", h, []int{0, 10, 30, 35, 40, 60, 70, 80, 90, 95})
+}
+
+func testDelete(t *testing.T, h heap.Extended) {
+	for _, v := range ints(0, 10) {
+		h.Insert(heap.Integer(v))
+	}
+	if got := h.Delete(heap.Integer(42)); got != nil {
+		t.Errorf("Delete of missing item = %v, want nil", got)
+	}
+	for _, v := range []int{0, 9, 4} {
+		if got := h.Delete(heap.Integer(v)); got != heap.Integer(v) {
+			t.Errorf("Delete(%d) = %v", v, got)
+		}
+	}
+	if h.Len() != 7 {
+		t.Errorf("Len() after Delete = %d, want 7", h.Len())
+	}
+	checkDrain(t, "delete", h, []int{1, 2, 3, 5, 6, 7, 8})
+	if got := h.Delete(heap.Integer(1)); got != nil {
+		t.Errorf("Delete on empty heap = %v, want nil", got)
+	}
+}
+
+// checkDrain deletes all items of h and compares them with want.
+func checkDrain(t *testing.T, name string, h heap.Interface, want []int) {
+	t.Helper()
+	for i, w := range want {
+		got := h.DeleteMin()
+		if got != heap.Integer(w) {
+			t.Fatalf("%s: DeleteMin() #%d = %v, want %d", name, i, got, w)
+		}
+		if h.Len() != len(want)-i-1 {
+			t.Fatalf("%s: Len() = %d after %d deletions of %d items", name, h.Len(), i+1, len(want))
+		}
+	}
+	if got := h.DeleteMin(); got != nil || !h.IsEmpty() {
+		t.Fatalf("%s: drained heap: DeleteMin() = %v, IsEmpty() = %v", name, got, h.IsEmpty())
+	}
+}
+
+func ints(from, to int) []int {
+	out := make([]int, 0, to-from)
+	for i := from; i < to; i++ {
+		out = append(out, i)
+	}
+	return out
+}
+
+func reverse(s []int) []int {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+	return s
+}
@@ -0,0 +1,99 @@
+package heaptest
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// IterableHeap is a heap of ints that can visit its items.
+type IterableHeap interface {
+	heap.Heap[int]
+	heap.Iterable[int]
+}
+
+// IterableFactory returns a new, empty iterable heap.
+type IterableFactory func() IterableHeap
+
+// RunIterableTests checks that the heaps returned by factory implement
+// go_heaps.Iterable: Do and All visit every item once, Ascend and Sorted
+// visit them in ascending order, all of them stop early when asked to and
+// none of them modifies the heap.
+func RunIterableTests(t *testing.T, factory IterableFactory) {
+	t.Helper()
+	t.Run("Empty", func(t *testing.T) {
+		h := factory()
+		for range h.All() {
+			t.Error("All() on empty heap yielded an item")
+		}
+		for range h.Sorted() {
+			t.Error("Sorted() on empty heap yielded an item")
+		}
+	})
+	t.Run("Items", func(t *testing.T) {
+		h := factory()
+		r := rand.New(rand.NewSource(1))
+		var want []int
+		for range 500 {
+			v := r.Intn(200)
+			h.Insert(v)
+			want = append(want, v)
+		}
+		// a few deletions leave the heap in a less regular shape
+		slices.Sort(want)
+		for range 50 {
+			h.DeleteMin()
+		}
+		want = want[50:]
+
+		got := slices.Collect(h.All())
+		slices.Sort(got)
+		if !slices.Equal(got, want) {
+			t.Errorf("All() = %v, want %v", got, want)
+		}
+		if got := slices.Collect(h.Sorted()); !slices.Equal(got, want) {
+			t.Errorf("Sorted() = %v, want %v", got, want)
+		}
+		checkStop(t, "Do", h.Do)
+		checkStop(t, "Ascend", h.Ascend)
+
+		var prefix []int
+		for v := range h.Sorted() {
+			if len(prefix) == 10 {
+				break
+			}
+			prefix = append(prefix, v)
+		}
+		if !slices.Equal(prefix, want[:10]) {
+			t.Errorf("first 10 items of Sorted() = %v, want %v", prefix, want[:10])
+		}
+
+		if v, ok := h.(heap.Validator); ok {
+			if err := v.Validate(); err != nil {
+				t.Fatalf("after iterating: %v", err)
+			}
+		}
+		if h.Len() != len(want) {
+			t.Fatalf("after iterating: Len() = %d, want %d", h.Len(), len(want))
+		}
+		for _, w := range want {
+			if v := h.DeleteMin(); v != w {
+				t.Fatalf("after iterating: DeleteMin() = %d, want %d", v, w)
+			}
+		}
+	})
+}
+
+// checkStop checks that do stops calling iter once it returns false.
+func checkStop(t *testing.T, name string, do func(iter func(item int) bool)) {
+	calls := 0
+	do(func(int) bool {
+		calls++
+		return calls < 3
+	})
+	if calls != 3 {
+		t.Errorf("%s called iter %d times after it returned false on the third call", name, calls)
+	}
+}
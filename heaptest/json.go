@@ -0,0 +1,85 @@
+package heaptest
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// JSONHeap is a heap of ints that can be marshaled to JSON and shown as a
+// tree view.
+type JSONHeap interface {
+	heap.Heap[int]
+	heap.TreeViewer[int]
+	json.Marshaler
+	json.Unmarshaler
+}
+
+// RunJSONTests checks that the heaps returned by factory round-trip through
+// MarshalJSON and UnmarshalJSON without changing the marshaled heap, and
+// that a go_heaps.JSONView with the tree view decodes like one without.
+// It checks that the tree view holds the items of the heap and calls check,
+// which may be nil, on every node of it.
+func RunJSONTests[H JSONHeap](t *testing.T, factory func() H, check func(n *heap.TreeNode[int]) error) {
+	t.Helper()
+	for _, n := range []int{0, 1, 10, 1000} {
+		h, items := fill(factory, n)
+		checkTree(t, h.Tree(), items, check)
+		data, err := json.Marshal(h)
+		if err != nil {
+			t.Fatalf("MarshalJSON of %d items: %v", n, err)
+		}
+		loaded := factory()
+		loaded.Insert(-1)
+		if err := json.Unmarshal(data, loaded); err != nil {
+			t.Fatalf("UnmarshalJSON of %d items: %v", n, err)
+		}
+		checkInts(t, "unmarshaled heap", loaded, items)
+
+		data, err = json.Marshal(heap.ViewOf[int](h, true))
+		if err != nil {
+			t.Fatalf("marshal view of %d items: %v", n, err)
+		}
+		loaded = factory()
+		if err := json.Unmarshal(data, loaded); err != nil {
+			t.Fatalf("UnmarshalJSON of the view of %d items: %v", n, err)
+		}
+		checkInts(t, "unmarshaled view", loaded, items)
+		checkInts(t, "marshaled heap", h, items)
+	}
+	t.Run("Malformed", func(t *testing.T) {
+		for _, bad := range []string{"", "[1]", `{"items":{}}`, `{"items":["a"]}`} {
+			if err := json.Unmarshal([]byte(bad), factory()); err == nil {
+				t.Errorf("UnmarshalJSON(%q) = nil, want an error", bad)
+			}
+		}
+	})
+}
+
+// checkTree checks that the trees hold the items and calls check on each
+// of their nodes.
+func checkTree(t *testing.T, trees []*heap.TreeNode[int], items []int, check func(n *heap.TreeNode[int]) error) {
+	t.Helper()
+	var got []int
+	stack := slices.Clone(trees)
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if n == nil {
+			continue
+		}
+		got = append(got, n.Item)
+		if check != nil {
+			if err := check(n); err != nil {
+				t.Fatalf("tree node %d: %v", n.Item, err)
+			}
+		}
+		stack = append(stack, n.Children...)
+	}
+	slices.Sort(got)
+	if want := slices.Sorted(slices.Values(items)); !slices.Equal(got, want) {
+		t.Fatalf("tree holds %v, want %v", got, want)
+	}
+}
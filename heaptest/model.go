@@ -0,0 +1,206 @@
+package heaptest
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// modelSteps is the number of operations of a seeded random sequence.
+const modelSteps = 300
+
+// model is the reference implementation heaps are checked against: a
+// sorted slice of ints.
+type model struct {
+	items []int
+}
+
+func (m *model) insert(v int) {
+	i := sort.SearchInts(m.items, v)
+	m.items = append(m.items, 0)
+	copy(m.items[i+1:], m.items[i:])
+	m.items[i] = v
+}
+
+// remove deletes one occurrence of v and reports whether it was present.
+func (m *model) remove(v int) bool {
+	i := sort.SearchInts(m.items, v)
+	if i == len(m.items) || m.items[i] != v {
+		return false
+	}
+	m.items = append(m.items[:i], m.items[i+1:]...)
+	return true
+}
+
+func (m *model) min() heap.Item {
+	if len(m.items) == 0 {
+		return nil
+	}
+	return heap.Integer(m.items[0])
+}
+
+// source chooses the operations a checker performs.
+type source interface {
+	// Intn returns a number in [0, n)
+	Intn(n int) int
+	// more reports whether another operation should be performed
+	more() bool
+}
+
+// randSource draws modelSteps operations from a seeded generator.
+type randSource struct {
+	*rand.Rand
+	steps int
+}
+
+func newRandSource(seed int64) *randSource {
+	return &randSource{Rand: rand.New(rand.NewSource(seed)), steps: modelSteps}
+}
+
+func (s *randSource) more() bool {
+	s.steps--
+	return s.steps >= 0
+}
+
+// byteSource draws operations from fuzzer input until it is used up.
+type byteSource []byte
+
+func (s *byteSource) Intn(n int) int {
+	if len(*s) == 0 {
+		return 0
+	}
+	b := (*s)[0]
+	*s = (*s)[1:]
+	return int(b) % n
+}
+
+func (s *byteSource) more() bool {
+	return len(*s) > 0
+}
+
+// checker applies the operations chosen by a source to a heap and to a
+// model and fails the test on the first difference.
+type checker struct {
+	t *testing.T
+	// name identifies the sequence of operations in failures
+	name string
+	src  source
+	h    heap.Interface
+	// factory is nil if h only implements go_heaps.Interface
+	factory ExtendedFactory
+	m       model
+	step    int
+}
+
+func newChecker(t *testing.T, name string, src source, h heap.Interface, factory ExtendedFactory) *checker {
+	return &checker{t: t, name: name, src: src, h: h, factory: factory}
+}
+
+func (c *checker) fatalf(format string, args ...interface{}) {
+	c.t.Helper()
+	c.t.Fatalf("%s step %d: %s", c.name, c.step, fmt.Sprintf(format, args...))
+}
+
+// run performs operations until the source is used up, checking Len,
+// IsEmpty and Validate after each of them, and finally drains the heap.
+func (c *checker) run() {
+	c.t.Helper()
+	for ; c.src.more(); c.step++ {
+		op := c.do()
+		if c.h.Len() != len(c.m.items) || c.h.IsEmpty() != (len(c.m.items) == 0) {
+			c.fatalf("after %s Len() = %d, IsEmpty() = %v, want %d items",
+				op, c.h.Len(), c.h.IsEmpty(), len(c.m.items))
+		}
+		if v, ok := c.h.(heap.Validator); ok {
+			if err := v.Validate(); err != nil {
+				c.fatalf("after %s: %v", op, err)
+			}
+		}
+	}
+	checkDrain(c.t, c.name, c.h, c.m.items)
+}
+
+// do performs one operation and returns its description.
+func (c *checker) do() string {
+	c.t.Helper()
+	switch n := c.src.Intn(20); {
+	case n < 9:
+		v := c.src.Intn(50)
+		c.h.Insert(heap.Integer(v))
+		c.m.insert(v)
+		return fmt.Sprintf("Insert(%d)", v)
+	case n < 15:
+		want := c.m.min()
+		if got := c.h.DeleteMin(); got != want {
+			c.fatalf("DeleteMin() = %v, want %v", got, want)
+		}
+		if want != nil {
+			c.m.items = c.m.items[1:]
+		}
+		return "DeleteMin()"
+	case n < 17:
+		if got, want := c.h.FindMin(), c.m.min(); got != want {
+			c.fatalf("FindMin() = %v, want %v", got, want)
+		}
+		return "FindMin()"
+	case n < 18:
+		if c.src.Intn(4) != 0 {
+			return "nothing"
+		}
+		c.h.Clear()
+		c.m.items = nil
+		return "Clear()"
+	default:
+		if c.factory == nil {
+			return "nothing"
+		}
+		return c.doExtended(c.h.(heap.Extended))
+	}
+}
+
+// doExtended performs a Delete, Adjust or Meld and returns its description.
+func (c *checker) doExtended(h heap.Extended) string {
+	c.t.Helper()
+	switch c.src.Intn(3) {
+	case 0:
+		v := c.src.Intn(60)
+		got := h.Delete(heap.Integer(v))
+		if c.m.remove(v) {
+			if got == nil || got.Compare(heap.Integer(v)) != 0 {
+				c.fatalf("Delete(%d) = %v", v, got)
+			}
+		} else if got != nil {
+			c.fatalf("Delete(%d) of missing item = %v, want nil", v, got)
+		}
+		return fmt.Sprintf("Delete(%d)", v)
+	case 1:
+		old, new := c.src.Intn(60), c.src.Intn(60)
+		got := h.Adjust(heap.Integer(old), heap.Integer(new))
+		if c.m.remove(old) {
+			c.m.insert(new)
+			if got == nil {
+				c.fatalf("Adjust(%d, %d) = nil", old, new)
+			}
+		} else if got != nil {
+			c.fatalf("Adjust(%d, %d) of missing item = %v, want nil", old, new, got)
+		}
+		return fmt.Sprintf("Adjust(%d, %d)", old, new)
+	default:
+		other := c.factory()
+		var items []int
+		for i := c.src.Intn(8); i > 0; i-- {
+			v := c.src.Intn(50)
+			other.Insert(heap.Integer(v))
+			c.m.insert(v)
+			items = append(items, v)
+		}
+		c.h = h.Meld(other)
+		if !other.IsEmpty() {
+			c.fatalf("melded argument not empty: Len() = %d", other.Len())
+		}
+		return fmt.Sprintf("Meld(%v)", items)
+	}
+}
@@ -0,0 +1,136 @@
+package heaptest
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// SplitHeap is a heap of ints kept in search tree order, such as a treap,
+// that can delete any item and split and join its items at a key.
+type SplitHeap[H any] interface {
+	heap.Heap[int]
+	Delete(item int) int
+	Contains(item int) bool
+	Split(key int) H
+	Join(other H)
+}
+
+// splitChecker applies the operations chosen by a source to a SplitHeap
+// and to a model and fails the test on the first difference. Items are
+// positive, so the zero value returned for a missing item is never an
+// item of the heap.
+type splitChecker[H SplitHeap[H]] struct {
+	t *testing.T
+	// name identifies the sequence of operations in failures
+	name string
+	src  source
+	h    H
+	m    model
+	step int
+}
+
+func (c *splitChecker[H]) fatalf(format string, args ...interface{}) {
+	c.t.Helper()
+	c.t.Fatalf("%s step %d: %s", c.name, c.step, fmt.Sprintf(format, args...))
+}
+
+// check fails the test after op if h does not hold the items in want or
+// does not validate.
+func (c *splitChecker[H]) check(op string, h H, want []int) {
+	c.t.Helper()
+	if h.Len() != len(want) || h.IsEmpty() != (len(want) == 0) {
+		c.fatalf("after %s Len() = %d, IsEmpty() = %v, want %d items",
+			op, h.Len(), h.IsEmpty(), len(want))
+	}
+	if v, ok := any(h).(heap.Validator); ok {
+		if err := v.Validate(); err != nil {
+			c.fatalf("after %s: %v", op, err)
+		}
+	}
+	var min int
+	if len(want) > 0 {
+		min = want[0]
+	}
+	if got := h.FindMin(); got != min {
+		c.fatalf("after %s FindMin() = %d, want %d", op, got, min)
+	}
+}
+
+// run performs operations until the source is used up, checking the heap
+// after each of them, and finally drains it.
+func (c *splitChecker[H]) run() {
+	c.t.Helper()
+	for ; c.src.more(); c.step++ {
+		c.check(c.do(), c.h, c.m.items)
+	}
+	checkInts(c.t, c.name, c.h, c.m.items)
+}
+
+// do performs one operation and returns its description.
+func (c *splitChecker[H]) do() string {
+	c.t.Helper()
+	switch n := c.src.Intn(20); {
+	case n < 8:
+		v := 1 + c.src.Intn(50)
+		c.h.Insert(v)
+		c.m.insert(v)
+		return fmt.Sprintf("Insert(%d)", v)
+	case n < 11:
+		var want int
+		if len(c.m.items) > 0 {
+			want = c.m.items[0]
+			c.m.items = c.m.items[1:]
+		}
+		if got := c.h.DeleteMin(); got != want {
+			c.fatalf("DeleteMin() = %d, want %d", got, want)
+		}
+		return "DeleteMin()"
+	case n < 12:
+		if c.src.Intn(4) != 0 {
+			return "nothing"
+		}
+		c.h.Clear()
+		c.m.items = nil
+		return "Clear()"
+	case n < 15:
+		v := 1 + c.src.Intn(60)
+		var want int
+		if c.m.remove(v) {
+			want = v
+		}
+		if got := c.h.Delete(v); got != want {
+			c.fatalf("Delete(%d) = %d, want %d", v, got, want)
+		}
+		return fmt.Sprintf("Delete(%d)", v)
+	case n < 17:
+		v := 1 + c.src.Intn(60)
+		i := sort.SearchInts(c.m.items, v)
+		want := i < len(c.m.items) && c.m.items[i] == v
+		if got := c.h.Contains(v); got != want {
+			c.fatalf("Contains(%d) = %v, want %v", v, got, want)
+		}
+		return fmt.Sprintf("Contains(%d)", v)
+	default:
+		return c.doSplit()
+	}
+}
+
+// doSplit splits the heap at a random key, checks both halves and joins
+// them again.
+func (c *splitChecker[H]) doSplit() string {
+	c.t.Helper()
+	key := c.src.Intn(52)
+	i := sort.SearchInts(c.m.items, key+1)
+	op := fmt.Sprintf("Split(%d)", key)
+	right := c.h.Split(key)
+	c.check(op, c.h, c.m.items[:i])
+	c.check(op, right, c.m.items[i:])
+	c.h.Join(right)
+	if !right.IsEmpty() {
+		c.fatalf("joined argument not empty: Len() = %d", right.Len())
+	}
+	return op + " and Join"
+}
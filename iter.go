@@ -0,0 +1,112 @@
+package go_heaps
+
+import (
+	"iter"
+	"slices"
+)
+
+// Iterable is implemented by heaps that can visit their items without
+// removing them. The behavior of the iteration is undefined if the heap is
+// modified before it ends.
+type Iterable[T any] interface {
+	// Do calls iter on every item in no particular order until iter
+	// returns false
+	Do(iter func(item T) bool)
+
+	// Ascend calls iter on every item in ascending order until iter
+	// returns false
+	Ascend(iter func(item T) bool)
+
+	// All returns an iterator over the items in no particular order
+	All() iter.Seq[T]
+
+	// Sorted returns an iterator over the items in ascending order
+	Sorted() iter.Seq[T]
+}
+
+// DoForest calls iter on the items of the forest whose nodes roots and
+// children visit, in preorder, until iter returns false. It keeps the nodes
+// still to visit on a stack, so deep trees do not grow the goroutine stack.
+func DoForest[N, T any](roots func(visit func(n N)), children func(n N, visit func(c N)),
+	item func(n N) T, iter func(item T) bool) {
+	var stack []N
+	push := func(n N) { stack = append(stack, n) }
+	roots(push)
+	slices.Reverse(stack)
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !iter(item(n)) {
+			return
+		}
+		top := len(stack)
+		children(n, push)
+		slices.Reverse(stack[top:])
+	}
+}
+
+// AscendForest calls iter on the items of the heap ordered forest whose
+// nodes roots and children visit, in ascending order, until iter returns
+// false. It keeps the roots and the children of the nodes visited so far in
+// an auxiliary binary heap, so the forest itself is not modified.
+// The complexity is O(k log n) to visit k of n items in a forest of
+// bounded degree.
+func AscendForest[N, T any](roots func(visit func(n N)), children func(n N, visit func(c N)),
+	item func(n N) T, compare func(a, b T) int, iter func(item T) bool) {
+	f := frontier[N, T]{item: item, compare: compare}
+	roots(f.push)
+	for len(f.nodes) > 0 {
+		n := f.pop()
+		if !iter(item(n)) {
+			return
+		}
+		children(n, f.push)
+	}
+}
+
+// frontier is a binary heap of nodes ordered by their items.
+type frontier[N, T any] struct {
+	nodes   []N
+	item    func(n N) T
+	compare func(a, b T) int
+}
+
+func (f *frontier[N, T]) less(i, j int) bool {
+	return f.compare(f.item(f.nodes[i]), f.item(f.nodes[j])) < 0
+}
+
+func (f *frontier[N, T]) push(n N) {
+	f.nodes = append(f.nodes, n)
+	for i := len(f.nodes) - 1; i > 0; {
+		parent := (i - 1) / 2
+		if !f.less(i, parent) {
+			break
+		}
+		f.nodes[i], f.nodes[parent] = f.nodes[parent], f.nodes[i]
+		i = parent
+	}
+}
+
+func (f *frontier[N, T]) pop() N {
+	n := f.nodes[0]
+	last := len(f.nodes) - 1
+	f.nodes[0] = f.nodes[last]
+	var zero N
+	f.nodes[last] = zero
+	f.nodes = f.nodes[:last]
+	for i := 0; ; {
+		min, left, right := i, 2*i+1, 2*i+2
+		if left < last && f.less(left, min) {
+			min = left
+		}
+		if right < last && f.less(right, min) {
+			min = right
+		}
+		if min == i {
+			break
+		}
+		f.nodes[i], f.nodes[min] = f.nodes[min], f.nodes[i]
+		i = min
+	}
+	return n
+}
@@ -0,0 +1,320 @@
+package go_heaps
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+func init() {
+	RegisterItem("Integer", Integer(0))
+	RegisterItem("String", String(""))
+	RegisterItem("Reversed", Reversed{})
+}
+
+// registry maps the names of the registered Item types to their types and
+// back.
+var registry struct {
+	sync.RWMutex
+	types map[string]reflect.Type
+	names map[reflect.Type]string
+}
+
+// RegisterItem makes heaps of Item values encode items of the concrete
+// type of item in JSON as {"type": name, "value": item}, where item is
+// encoded by encoding/json, and decode them back to that type. It panics
+// if name or the type of item is registered already. Integer, String and
+// Reversed are registered under their names.
+func RegisterItem(name string, item Item) {
+	registry.Lock()
+	defer registry.Unlock()
+	if registry.types == nil {
+		registry.types = make(map[string]reflect.Type)
+		registry.names = make(map[reflect.Type]string)
+	}
+	t := reflect.TypeOf(item)
+	if _, ok := registry.types[name]; ok {
+		panic(fmt.Sprintf("heap: item name %q registered twice", name))
+	}
+	if _, ok := registry.names[t]; ok {
+		panic(fmt.Sprintf("heap: item type %v registered twice", t))
+	}
+	registry.types[name], registry.names[t] = t, name
+}
+
+// jsonItem is the JSON form of an Item.
+type jsonItem struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+var itemType = reflect.TypeFor[Item]()
+
+// marshalItem returns the JSON encoding of item, which it looks up in the
+// registry if T is Item.
+func marshalItem[T any](item T) ([]byte, error) {
+	if reflect.TypeFor[T]() != itemType {
+		return json.Marshal(item)
+	}
+	return marshalRegistered(any(item))
+}
+
+func marshalRegistered(item any) ([]byte, error) {
+	if item == nil {
+		return []byte("null"), nil
+	}
+	registry.RLock()
+	name, ok := registry.names[reflect.TypeOf(item)]
+	registry.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("heap: item type %T is not registered", item)
+	}
+	value, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonItem{Type: name, Value: value})
+}
+
+// unmarshalItem decodes data into item, looking up the type of the item in
+// the registry if T is Item.
+func unmarshalItem[T any](data []byte, item *T) error {
+	if reflect.TypeFor[T]() != itemType {
+		return json.Unmarshal(data, item)
+	}
+	v, err := unmarshalRegistered(data)
+	if err != nil {
+		return err
+	}
+	*item, _ = v.(T)
+	return nil
+}
+
+func unmarshalRegistered(data []byte) (Item, error) {
+	var j *jsonItem
+	if err := json.Unmarshal(data, &j); err != nil || j == nil {
+		return nil, err
+	}
+	registry.RLock()
+	t, ok := registry.types[j.Type]
+	registry.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("heap: item type %q is not registered", j.Type)
+	}
+	v := reflect.New(t)
+	if err := json.Unmarshal(j.Value, v.Interface()); err != nil {
+		return nil, err
+	}
+	return v.Elem().Interface().(Item), nil
+}
+
+// MarshalJSON encodes the wrapped Item as a registered item.
+func (a Reversed) MarshalJSON() ([]byte, error) {
+	return marshalRegistered(a.Item)
+}
+
+// UnmarshalJSON decodes the wrapped Item as a registered item.
+func (a *Reversed) UnmarshalJSON(data []byte) error {
+	item, err := unmarshalRegistered(data)
+	a.Item = item
+	return err
+}
+
+// Attr is an attribute of a TreeNode, such as the degree of a node of a
+// binomial heap.
+type Attr struct {
+	Name  string
+	Value any
+}
+
+// TreeNode is a node of the tree view of a heap. It appears in JSON as an
+// object with the item, the attributes and, unless there are none, the
+// children of the node. A nil child stands for a missing child of a binary
+// tree and appears as null.
+type TreeNode[T any] struct {
+	Item     T
+	Attrs    []Attr
+	Children []*TreeNode[T]
+}
+
+// MarshalJSON encodes the subtree rooted at n.
+func (n *TreeNode[T]) MarshalJSON() ([]byte, error) {
+	b, err := appendTrees(nil, []*TreeNode[T]{n})
+	if err != nil {
+		return nil, err
+	}
+	// drop the brackets of the list
+	return b[1 : len(b)-1], nil
+}
+
+// appendTrees appends the JSON list of the trees to b. It keeps the lists
+// of children still to write on a stack, so deep trees do not grow the
+// goroutine stack.
+func appendTrees[T any](b []byte, trees []*TreeNode[T]) ([]byte, error) {
+	type list struct {
+		nodes []*TreeNode[T]
+		next  int
+	}
+	stack := []list{{nodes: trees}}
+	b = append(b, '[')
+	for len(stack) > 0 {
+		l := &stack[len(stack)-1]
+		if l.next == len(l.nodes) {
+			stack = stack[:len(stack)-1]
+			b = append(b, ']')
+			if len(stack) > 0 {
+				// close the node the list belongs to
+				b = append(b, '}')
+			}
+			continue
+		}
+		if l.next > 0 {
+			b = append(b, ',')
+		}
+		n := l.nodes[l.next]
+		l.next++
+		if n == nil {
+			b = append(b, "null"...)
+			continue
+		}
+		item, err := marshalItem(n.Item)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, `{"item":`...)
+		b = append(b, item...)
+		for _, a := range n.Attrs {
+			name, err := json.Marshal(a.Name)
+			if err != nil {
+				return nil, err
+			}
+			value, err := json.Marshal(a.Value)
+			if err != nil {
+				return nil, err
+			}
+			b = append(append(append(append(b, ','), name...), ':'), value...)
+		}
+		if len(n.Children) == 0 {
+			b = append(b, '}')
+			continue
+		}
+		b = append(b, `,"children":[`...)
+		stack = append(stack, list{nodes: n.Children})
+	}
+	return b, nil
+}
+
+// TreeOf returns the tree view of the forest whose nodes roots and
+// children visit, with the attributes that attrs, which may be nil,
+// returns for every node. A child equal to the zero value of N stands for
+// a missing child of a binary tree; roots are never missing, so N may be
+// an index that is zero at a root.
+// The complexity is O(n).
+func TreeOf[N comparable, T any](roots func(visit func(n N)), children func(n N, visit func(c N)),
+	item func(n N) T, attrs func(n N) []Attr) []*TreeNode[T] {
+	type pending struct {
+		n    N
+		view *TreeNode[T]
+	}
+	var stack []pending
+	var zero N
+	add := func(list *[]*TreeNode[T], child bool) func(n N) {
+		return func(n N) {
+			if child && n == zero {
+				*list = append(*list, nil)
+				return
+			}
+			view := &TreeNode[T]{Item: item(n)}
+			if attrs != nil {
+				view.Attrs = attrs(n)
+			}
+			*list = append(*list, view)
+			stack = append(stack, pending{n, view})
+		}
+	}
+	var trees []*TreeNode[T]
+	roots(add(&trees, false))
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		children(p.n, add(&p.view.Children, true))
+	}
+	return trees
+}
+
+// TreeViewer is implemented by heaps that can show their structure as a
+// tree view.
+type TreeViewer[T any] interface {
+	// All returns an iterator over the items in no particular order
+	All() iter.Seq[T]
+
+	// Tree returns the trees of the heap with the attributes of their
+	// nodes
+	Tree() []*TreeNode[T]
+}
+
+// JSONView is the JSON form of a heap: an object with the items of the
+// heap in an "items" list and, if Tree is set, its trees in a "tree" list.
+// Heaps implement json.Marshaler with a JSONView of their items, and
+// ViewOf returns one with their trees for debugging. Items of type Item
+// are encoded as registered with RegisterItem.
+type JSONView[T any] struct {
+	Items []T
+	Tree  []*TreeNode[T]
+}
+
+// ViewOf returns the JSONView of h, including its trees if tree is set.
+func ViewOf[T any](h TreeViewer[T], tree bool) JSONView[T] {
+	v := JSONView[T]{Items: slices.Collect(h.All())}
+	if tree {
+		// an empty heap still shows an empty tree list
+		v.Tree = append([]*TreeNode[T]{}, h.Tree()...)
+	}
+	return v
+}
+
+// MarshalJSON encodes v.
+func (v JSONView[T]) MarshalJSON() ([]byte, error) {
+	b := []byte(`{"items":[`)
+	for i, item := range v.Items {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		data, err := marshalItem(item)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, data...)
+	}
+	b = append(b, ']')
+	if v.Tree != nil {
+		var err error
+		b = append(b, `,"tree":`...)
+		if b, err = appendTrees(b, v.Tree); err != nil {
+			return nil, err
+		}
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON decodes the items of data into v. It does not decode the
+// tree view.
+func (v *JSONView[T]) UnmarshalJSON(data []byte) error {
+	var j struct {
+		Items []json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	items := make([]T, len(j.Items))
+	for i, data := range j.Items {
+		if err := unmarshalItem(data, &items[i]); err != nil {
+			return err
+		}
+	}
+	v.Items, v.Tree = items, nil
+	return nil
+}
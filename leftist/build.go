@@ -0,0 +1,51 @@
+package leftist
+
+import (
+	"cmp"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// FromSliceFunc returns a Heap ordered by compare that holds items.
+// The complexity is O(n).
+func FromSliceFunc[T any](items []T, compare func(a, b T) int) *Heap[T] {
+	h := NewFunc(compare)
+	h.InsertAll(items)
+	return h
+}
+
+// FromSliceOrdered returns a Heap of ordered values that holds items.
+// The complexity is O(n).
+func FromSliceOrdered[T cmp.Ordered](items []T) *Heap[T] {
+	return FromSliceFunc(items, cmp.Compare[T])
+}
+
+// FromSlice returns a LeftistHeap that holds items.
+// The complexity is O(n).
+func FromSlice(items []heap.Item) *LeftistHeap {
+	h := New()
+	h.InsertAll(items)
+	return h
+}
+
+// InsertAll inserts items into the heap. It keeps a queue of heaps that
+// starts with one heap per item, repeatedly merges the two heaps at its
+// front into one at its back and merges the last one into the heap.
+// The complexity is O(k + log n) for k items.
+func (h *Heap[T]) InsertAll(items []T) {
+	if len(items) == 0 {
+		return
+	}
+	queue := make([]*NodeOf[T], len(items))
+	for i, item := range items {
+		n := &NodeOf[T]{item: item, owner: h.owner}
+		n.s = h.rankOf(n)
+		queue[i] = n
+	}
+	for len(queue) > 1 {
+		queue = append(queue[2:], h.mergeNodes(queue[0], queue[1]))
+	}
+	h.setRoot(h.mergeNodes(queue[0], h.root))
+	h.size += len(items)
+	heap.DebugValidate(h)
+}
@@ -0,0 +1,139 @@
+package leftist
+
+import (
+	heap "github.com/theodesp/go-heaps"
+)
+
+// owner identifies the heap that may modify a node in place. Heaps that
+// share nodes after a Snapshot have different owners, so each of them
+// copies a shared node before it modifies it.
+type owner struct {
+	_ byte
+}
+
+// mutable returns n if the heap owns it or has no snapshots and a copy of
+// n owned by the heap otherwise.
+func (h *Heap[T]) mutable(n *NodeOf[T]) *NodeOf[T] {
+	if h.owner == nil || n.owner == h.owner {
+		return n
+	}
+	c := *n
+	c.owner = h.owner
+	return &c
+}
+
+// checkHandles panics if the heap has snapshots, since handles need the
+// parent pointers that such heaps do not keep.
+func (h *Heap[T]) checkHandles() {
+	if h.owner != nil {
+		panic("handles are disabled on a heap with snapshots")
+	}
+}
+
+// Clone returns a deep copy of the heap with the same Bias. Handles of the
+// heap do not refer to items of the copy. The copy has no snapshots, so it
+// supports handles even if the heap does not.
+// The complexity is O(n).
+func (h *Heap[T]) Clone() *Heap[T] {
+	return &Heap[T]{root: cloneTree(h.root), size: h.size, cmp: h.cmp, bias: h.bias}
+}
+
+// Snapshot returns a copy of the heap with the same Bias that shares all
+// nodes with it. Afterwards both heaps copy a shared node before they
+// modify it, so each operation copies only the O(log n) nodes on the paths
+// it changes. Shared nodes cannot point to a single parent, so both heaps
+// disable handles until they are cleared: InsertHandle, Remove,
+// DecreaseKey and IncreaseKey panic, and Delete and Adjust copy the path
+// from the root to the item instead.
+// The complexity is O(1).
+func (h *Heap[T]) Snapshot() *Heap[T] {
+	h.owner = new(owner)
+	return &Heap[T]{root: h.root, size: h.size, cmp: h.cmp, bias: h.bias, owner: new(owner)}
+}
+
+// Clone returns a deep copy of the LeftistHeap with the same Bias.
+// The complexity is O(n).
+func (h *LeftistHeap) Clone() *LeftistHeap {
+	return &LeftistHeap{*h.Heap.Clone()}
+}
+
+// Snapshot returns a copy of the LeftistHeap that shares all nodes with
+// it, like Heap.Snapshot.
+// The complexity is O(1).
+func (h *LeftistHeap) Snapshot() *LeftistHeap {
+	return &LeftistHeap{*h.Heap.Snapshot()}
+}
+
+// findPath returns the path from the root to a node holding item, or nil
+// if there is none.
+func (h *Heap[T]) findPath(item T) []*NodeOf[T] {
+	type visit struct {
+		n     *NodeOf[T]
+		depth int
+	}
+	var path []*NodeOf[T]
+	stack := []visit{{h.root, 0}}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if v.n == nil {
+			continue
+		}
+		path = append(path[:v.depth], v.n)
+		if h.compare(v.n.item, item) == 0 {
+			return path
+		}
+		stack = append(stack, visit{v.n.right, v.depth + 1}, visit{v.n.left, v.depth + 1})
+	}
+	return nil
+}
+
+// removePath removes the last node of path, which leads to it from the
+// root, and returns its item. It copies the ancestors it changes, so it
+// works on a heap with snapshots.
+// The complexity is O(log n) plus the length of path.
+func (h *Heap[T]) removePath(path []*NodeOf[T]) T {
+	n := path[len(path)-1]
+	sub := h.mergeNodes(n.left, n.right)
+	for i := len(path) - 2; i >= 0; i-- {
+		p := h.mutable(path[i])
+		if p.left == path[i+1] {
+			p.left = sub
+		} else {
+			p.right = sub
+		}
+		if h.rank(p.left) < h.rank(p.right) {
+			p.left, p.right = p.right, p.left
+		}
+		p.s = h.rankOf(p)
+		sub = p
+	}
+	h.setRoot(sub)
+	h.size--
+	heap.DebugValidate(h)
+	return n.item
+}
+
+// cloneTree returns a copy of the tree rooted at n. It keeps the nodes
+// still to copy on a stack, since the tree can be deep on the left.
+func cloneTree[T any](n *NodeOf[T]) *NodeOf[T] {
+	if n == nil {
+		return nil
+	}
+	root := &NodeOf[T]{item: n.item, s: n.s}
+	// pairs of a node and its copy
+	stack := []*NodeOf[T]{n, root}
+	for len(stack) > 0 {
+		from, to := stack[len(stack)-2], stack[len(stack)-1]
+		stack = stack[:len(stack)-2]
+		if from.left != nil {
+			to.left = &NodeOf[T]{item: from.left.item, s: from.left.s, parent: to}
+			stack = append(stack, from.left, to.left)
+		}
+		if from.right != nil {
+			to.right = &NodeOf[T]{item: from.right.item, s: from.right.s, parent: to}
+			stack = append(stack, from.right, to.right)
+		}
+	}
+	return root
+}
@@ -0,0 +1,55 @@
+package leftist
+
+import (
+	"iter"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// Heap implements the Iterable interface
+var _ heap.Iterable[int] = (*Heap[int])(nil)
+
+// Do calls it on each item of the heap in no particular order until it
+// returns false.
+// The complexity is O(n).
+func (h *Heap[T]) Do(it func(item T) bool) {
+	heap.DoForest(h.roots, children[T], itemOf[T], it)
+}
+
+// Ascend calls it on each item of the heap in ascending order until it
+// returns false, without modifying the heap.
+// The complexity is O(n log n) to visit all n items.
+func (h *Heap[T]) Ascend(it func(item T) bool) {
+	heap.AscendForest(h.roots, children[T], itemOf[T], h.compare, it)
+}
+
+// All returns an iterator over the items of the heap in no particular
+// order.
+func (h *Heap[T]) All() iter.Seq[T] {
+	return h.Do
+}
+
+// Sorted returns an iterator over the items of the heap in ascending
+// order.
+func (h *Heap[T]) Sorted() iter.Seq[T] {
+	return h.Ascend
+}
+
+func (h *Heap[T]) roots(visit func(n *NodeOf[T])) {
+	if h.root != nil {
+		visit(h.root)
+	}
+}
+
+func children[T any](n *NodeOf[T], visit func(c *NodeOf[T])) {
+	if n.left != nil {
+		visit(n.left)
+	}
+	if n.right != nil {
+		visit(n.right)
+	}
+}
+
+func itemOf[T any](n *NodeOf[T]) T {
+	return n.item
+}
@@ -0,0 +1,55 @@
+package leftist
+
+import (
+	"encoding/json"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+var (
+	// Heap implements the Marshaler and Unmarshaler interfaces
+	_ json.Marshaler   = (*Heap[int])(nil)
+	_ json.Unmarshaler = (*Heap[int])(nil)
+	// Heap has a tree view
+	_ heap.TreeViewer[int] = (*Heap[int])(nil)
+)
+
+// MarshalJSON encodes the items of the heap in no particular order as a
+// go_heaps.JSONView without the tree view.
+// The complexity is O(n).
+func (h *Heap[T]) MarshalJSON() ([]byte, error) {
+	return heap.ViewOf[T](h, false).MarshalJSON()
+}
+
+// UnmarshalJSON replaces the items of the heap with the items of the
+// go_heaps.JSONView encoded in data. The heap keeps its order and
+// configuration and builds itself with InsertAll.
+// The complexity is O(n).
+func (h *Heap[T]) UnmarshalJSON(data []byte) error {
+	var v heap.JSONView[T]
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	h.Clear()
+	h.InsertAll(v.Items)
+	return nil
+}
+
+// Tree returns the tree of the heap. A node that has a child lists both its
+// left and right child, null where missing. Every node has its "s" value,
+// or its "weight" if the heap is WeightBiased.
+// The complexity is O(n).
+func (h *Heap[T]) Tree() []*heap.TreeNode[T] {
+	name := "s"
+	if h.bias == WeightBiased {
+		name = "weight"
+	}
+	return heap.TreeOf(h.roots, func(n *NodeOf[T], visit func(c *NodeOf[T])) {
+		if n.left != nil || n.right != nil {
+			visit(n.left)
+			visit(n.right)
+		}
+	}, itemOf[T], func(n *NodeOf[T]) []heap.Attr {
+		return []heap.Attr{{Name: name, Value: n.s}}
+	})
+}
@@ -1,22 +1,55 @@
 package leftist
 
 import (
+	"cmp"
+	"fmt"
+
 	heap "github.com/theodesp/go-heaps"
 )
 
+// LeftistHeap implements the Extended interface
+var _ heap.Extended = (*LeftistHeap)(nil)
+
+// Heap implements the ExtendedHeap interface
+var _ heap.ExtendedHeap[int] = (*Heap[int])(nil)
+
+// NodeOf is a leaf in a heap of values of type T.
+type NodeOf[T any] struct {
+	item                T
+	left, right, parent *NodeOf[T]
+	s                   int // s-value (or rank), or the weight if weight-biased
+	// owner is the heap that may modify the node in place once the heap
+	// has snapshots
+	owner *owner
+}
+
 // Node is a leaf in the heap.
-type Node struct {
-	item        heap.Item
-	left, right *Node
-	s           int // s-value (or rank)
+type Node = NodeOf[heap.Item]
+
+// Heap is a leftist heap implementation over values of type T.
+// The zero value for Heap is an empty Heap ordered by go_heaps.Compare.
+type Heap[T any] struct {
+	root *NodeOf[T]
+	size int
+	cmp  func(a, b T) int
+	bias Bias
+	// owner is set once the heap has snapshots
+	owner *owner
 }
 
 // LeftistHeap is a leftist heap implementation.
 type LeftistHeap struct {
-	root *Node
+	Heap[heap.Item]
 }
 
-func mergeNodes(x, y *Node) *Node {
+func (h *Heap[T]) compare(a, b T) int {
+	if h.cmp == nil {
+		return heap.Compare(a, b)
+	}
+	return h.cmp(a, b)
+}
+
+func (h *Heap[T]) mergeNodes(x, y *NodeOf[T]) *NodeOf[T] {
 	if x == nil {
 		return y
 	}
@@ -24,73 +57,349 @@ func mergeNodes(x, y *Node) *Node {
 	if y == nil {
 		return x
 	}
-	// Compare the roots of two heaps.
-	if x.item.Compare(y.item) > 0 {
-		return merge(y, x)
-	} else {
-		return merge(x, y)
+	if h.bias == WeightBiased {
+		return h.mergeWeight(x, y)
 	}
+	return h.merge(x, y)
 }
 
-func merge(x, y *Node) *Node {
-	if x.left == nil {
-		// left child doesn't exist, so move right child to the smallest key
-		// to maintain the leftList invariant
-		x.left = y
-		x.right = nil
-	} else {
-		x.right = mergeNodes(x.right, y)
-		// left child does exist, so compare s-values
-		if x.left.s < x.right.s {
-			x.left, x.right = x.right, x.left
+// merge merges the height-biased trees x and y without recursion. The
+// first pass merges their right spines like two sorted lists, the second
+// walks back up the merged spine and restores the s-values.
+func (h *Heap[T]) merge(x, y *NodeOf[T]) *NodeOf[T] {
+	var root, parent *NodeOf[T]
+	link := &root
+	for x != nil && y != nil {
+		// x should point to the smaller item
+		if h.compare(x.item, y.item) > 0 {
+			x, y = y, x
+		}
+		x = h.mutable(x)
+		x.parent = parent
+		*link = x
+		parent, link, x = x, &x.right, x.right
+	}
+	if x == nil {
+		x = y
+	}
+	*link = x
+	h.setParent(x, parent)
+
+	for p := parent; p != nil; p = p.parent {
+		// swap the children if the right one has the greater s-value to
+		// maintain the leftist invariant
+		if sValue(p.left) < sValue(p.right) {
+			p.left, p.right = p.right, p.left
 		}
-		// since we know the right child has the lower s-value, we can just
-		// add one to its s-value
-		x.s = x.right.s + 1
+		p.s = sValue(p.right) + 1
 	}
+	return root
+}
+
+// Init initializes or clears the Heap. A cleared Heap has no snapshots
+// and supports handles again.
+func (h *Heap[T]) Init() *Heap[T] {
+	h.root = nil
+	h.size = 0
+	h.owner = nil
+	return h
+}
 
-	return x
+// NewFunc returns an initialized Heap ordered by compare.
+func NewFunc[T any](compare func(a, b T) int) *Heap[T] {
+	return (&Heap[T]{cmp: compare}).Init()
 }
 
+// NewOrdered returns an initialized Heap of ordered values.
+func NewOrdered[T cmp.Ordered]() *Heap[T] { return NewFunc(cmp.Compare[T]) }
+
 // Init initializes or clears the LeftistHeap
 func (h *LeftistHeap) Init() *LeftistHeap {
-	h.root = nil
+	h.Heap.Init()
 	return h
 }
 
 // New returns an initialized LeftistHeap.
 func New() *LeftistHeap { return new(LeftistHeap).Init() }
 
+// NewWithComparator returns an initialized LeftistHeap ordered by compare
+// instead of Item.Compare.
+func NewWithComparator(compare func(a, b heap.Item) int) *LeftistHeap {
+	h := &LeftistHeap{}
+	h.cmp = compare
+	return h.Init()
+}
+
+// setRoot makes n the root of the heap.
+func (h *Heap[T]) setRoot(n *NodeOf[T]) {
+	h.root = n
+	h.setParent(n, nil)
+}
+
+// setParent points n, which may be missing, to its parent p. Heaps with
+// snapshots share nodes between different parents and keep no parent
+// pointers.
+func (h *Heap[T]) setParent(n, p *NodeOf[T]) {
+	if n != nil && h.owner == nil {
+		n.parent = p
+	}
+}
+
 // Insert adds an item into the heap.
 // The complexity is O(log n) amortized.
-func (h *LeftistHeap) Insert(item heap.Item) heap.Item {
-	h.root = mergeNodes(&Node{
-		item: item,
-	}, h.root)
-
+func (h *Heap[T]) Insert(item T) T {
+	h.insert(&NodeOf[T]{item: item, owner: h.owner})
 	return item
 }
 
+// Handle is an opaque reference to an item inserted with InsertHandle.
+// It stays valid until the item is removed from the heap.
+type Handle[T any] NodeOf[T]
+
+// Item returns the item referenced by the Handle.
+func (hd *Handle[T]) Item() T {
+	return hd.item
+}
+
+// InsertHandle adds an item into the heap and returns a Handle to it that
+// can be passed to DecreaseKey, IncreaseKey and Remove. It panics if the
+// heap has snapshots.
+// The complexity is O(log n).
+func (h *Heap[T]) InsertHandle(item T) *Handle[T] {
+	h.checkHandles()
+	n := &NodeOf[T]{item: item}
+	h.insert(n)
+	return (*Handle[T])(n)
+}
+
+func (h *Heap[T]) insert(n *NodeOf[T]) {
+	n.s = h.rankOf(n)
+	h.setRoot(h.mergeNodes(n, h.root))
+	h.size++
+	heap.DebugValidate(h)
+}
+
 // DeleteMin deletes the minimum value and returns it.
+// It returns the zero value of T if the heap is empty.
 // The complexity is O(log n) amortized.
-func (h *LeftistHeap) DeleteMin() heap.Item {
-	item := h.root.item
+func (h *Heap[T]) DeleteMin() T {
+	if h.root == nil {
+		var zero T
+		return zero
+	}
+	if h.owner != nil {
+		return h.removePath([]*NodeOf[T]{h.root})
+	}
+	return h.Remove((*Handle[T])(h.root))
+}
 
-	h.root = mergeNodes(h.root.left, h.root.right)
+// Remove deletes the item referenced by hd from the heap and returns it.
+// It panics if the heap has snapshots.
+// The complexity is O(log n), or proportional to the depth of the item if
+// the heap is weight-biased, since the weight of every ancestor changes.
+func (h *Heap[T]) Remove(hd *Handle[T]) T {
+	h.checkHandles()
+	n := (*NodeOf[T])(hd)
+	h.replace(n, h.mergeNodes(n.left, n.right))
+	n.left, n.right, n.s = nil, nil, 0
+	h.size--
+	heap.DebugValidate(h)
+	return n.item
+}
 
-	return item
+// DecreaseKey replaces the item referenced by hd with a smaller item.
+// It panics if the heap has snapshots.
+// The complexity is the same as for Remove.
+func (h *Heap[T]) DecreaseKey(hd *Handle[T], item T) {
+	h.checkHandles()
+	n := (*NodeOf[T])(hd)
+	if h.compare(item, n.item) > 0 {
+		panic("new item is greater than the previous one")
+	}
+	n.item = item
+	if n.parent != nil && h.compare(item, n.parent.item) < 0 {
+		// the subtree of n is still a leftist heap, so cut and merge it
+		h.replace(n, nil)
+		h.setRoot(h.mergeNodes(h.root, n))
+	}
+	heap.DebugValidate(h)
+}
+
+// IncreaseKey replaces the item referenced by hd with a greater item.
+// It panics if the heap has snapshots.
+// The complexity is the same as for Remove.
+func (h *Heap[T]) IncreaseKey(hd *Handle[T], item T) {
+	h.checkHandles()
+	n := (*NodeOf[T])(hd)
+	if h.compare(item, n.item) < 0 {
+		panic("new item is smaller than the previous one")
+	}
+	h.Remove(hd)
+	n.item = item
+	h.insert(n)
+}
+
+// replace puts sub in the place of n and restores the ranks of the
+// ancestors of n.
+func (h *Heap[T]) replace(n, sub *NodeOf[T]) {
+	p := n.parent
+	n.parent = nil
+	if sub != nil {
+		sub.parent = p
+	}
+	if p == nil {
+		h.root = sub
+		return
+	}
+	if p.left == n {
+		p.left = sub
+	} else {
+		p.right = sub
+	}
+	for ; p != nil; p = p.parent {
+		if h.rank(p.left) < h.rank(p.right) {
+			p.left, p.right = p.right, p.left
+		}
+		s := h.rankOf(p)
+		if s == p.s {
+			break
+		}
+		p.s = s
+	}
+}
+
+// Adjust replaces the item old with new and returns new.
+// The complexity is O(n) to find the item plus O(log n).
+func (h *Heap[T]) Adjust(old, new T) T {
+	if h.owner != nil {
+		path := h.findPath(old)
+		if path == nil {
+			var zero T
+			return zero
+		}
+		h.removePath(path)
+		return h.Insert(new)
+	}
+	n := h.find(h.root, old)
+	if n == nil {
+		var zero T
+		return zero
+	}
+	if h.compare(new, n.item) <= 0 {
+		h.DecreaseKey((*Handle[T])(n), new)
+	} else {
+		h.IncreaseKey((*Handle[T])(n), new)
+	}
+	return new
+}
+
+// Delete removes item from the heap and returns it.
+// The complexity is O(n) to find the item plus O(log n).
+func (h *Heap[T]) Delete(item T) T {
+	if h.owner != nil {
+		path := h.findPath(item)
+		if path == nil {
+			var zero T
+			return zero
+		}
+		return h.removePath(path)
+	}
+	n := h.find(h.root, item)
+	if n == nil {
+		var zero T
+		return zero
+	}
+	return h.Remove((*Handle[T])(n))
+}
+
+// find returns the node holding item in the subtree rooted at n or nil.
+// It uses an explicit stack since left paths can be as long as the heap.
+func (h *Heap[T]) find(n *NodeOf[T], item T) *NodeOf[T] {
+	var stack []*NodeOf[T]
+	for n != nil || len(stack) > 0 {
+		if n == nil {
+			n, stack = stack[len(stack)-1], stack[:len(stack)-1]
+		}
+		if h.compare(n.item, item) == 0 {
+			return n
+		}
+		if n.right != nil {
+			stack = append(stack, n.right)
+		}
+		n = n.left
+	}
+	return nil
+}
+
+// Meld moves all items of a into the heap by merging both trees.
+// Both heaps must have the same Bias, otherwise Meld panics. If a has
+// snapshots and the heap does not, Meld merges a Clone of a, so that the
+// heap keeps its handles; this takes O(m).
+// The complexity is O(log n + log m).
+func (h *Heap[T]) Meld(a heap.Heap[T]) heap.Heap[T] {
+	if a == nil {
+		return h
+	}
+	switch o := a.(type) {
+	case *Heap[T]:
+		if o == h || o.root == nil {
+			return h
+		}
+		if o.bias != h.bias {
+			panic("heaps have different biases")
+		}
+		src := o
+		if o.owner != nil && h.owner == nil {
+			src = o.Clone()
+		}
+		h.setRoot(h.mergeNodes(h.root, src.root))
+		h.size += o.size
+		o.Init()
+		heap.DebugValidate(h)
+	default:
+		panic(fmt.Sprintf("unexpected type %T", a))
+	}
+	return h
+}
+
+// Meld moves all items of a into the heap by merging both trees.
+// The complexity is O(log n + log m).
+func (h *LeftistHeap) Meld(a heap.Interface) heap.Interface {
+	if a == nil {
+		return h
+	}
+	switch o := a.(type) {
+	case *LeftistHeap:
+		h.Heap.Meld(&o.Heap)
+	default:
+		panic(fmt.Sprintf("unexpected type %T", a))
+	}
+	return h
 }
 
 // FindMin finds the minimum value.
 // The complexity is O(1).
-func (h *LeftistHeap) FindMin() heap.Item {
+func (h *Heap[T]) FindMin() T {
 	if h.root == nil {
-		return nil
+		var zero T
+		return zero
 	}
 	return h.root.item
 }
 
+// Len returns the number of items in the heap.
+// The complexity is O(1).
+func (h *Heap[T]) Len() int {
+	return h.size
+}
+
+// IsEmpty returns true if the heap has no items.
+// The complexity is O(1).
+func (h *Heap[T]) IsEmpty() bool {
+	return h.root == nil
+}
+
 // Clear removes all items from the heap.
-func (h *LeftistHeap) Clear() {
+func (h *Heap[T]) Clear() {
 	h.Init()
 }
@@ -1,10 +1,13 @@
 package leftist
 
 import (
+	"fmt"
+	"slices"
 	"sort"
 	"testing"
 
 	"github.com/theodesp/go-heaps"
+	"github.com/theodesp/go-heaps/heaptest"
 )
 
 func TestLeftistHeapInteger(t *testing.T) {
@@ -62,6 +65,82 @@ func TestLeftistHeap(t *testing.T) {
 	}
 }
 
+func TestLeftistHeapLen(t *testing.T) {
+	heap := &LeftistHeap{}
+
+	if !heap.IsEmpty() || heap.Len() != 0 || heap.DeleteMin() != nil {
+		t.Fail()
+	}
+
+	for _, number := range []int{4, 3, 2, 5} {
+		heap.Insert(Int(number))
+	}
+	heap.DeleteMin()
+
+	if heap.IsEmpty() || heap.Len() != 3 {
+		t.Fail()
+	}
+
+	heap.Clear()
+	if !heap.IsEmpty() || heap.Len() != 0 {
+		t.Fail()
+	}
+}
+
+func TestLeftistHeapMaxHeap(t *testing.T) {
+	heap := NewWithComparator(func(a, b go_heaps.Item) int { return b.Compare(a) })
+	reversed := New()
+
+	for _, number := range []int{4, 3, 7, 5} {
+		heap.Insert(go_heaps.Integer(number))
+		reversed.Insert(go_heaps.Reverse(go_heaps.Integer(number)))
+	}
+
+	for _, number := range []int{7, 5, 4, 3} {
+		if go_heaps.Integer(number) != heap.DeleteMin() {
+			t.Fail()
+		}
+		if go_heaps.Integer(number) != reversed.DeleteMin().(go_heaps.Reversed).Item {
+			t.Fail()
+		}
+	}
+}
+
+func TestHeapOrdered(t *testing.T) {
+	heap := NewOrdered[int]()
+
+	numbers := []int{4, 3, -1, 5, 9}
+
+	for _, number := range numbers {
+		heap.Insert(number)
+	}
+
+	sort.Ints(numbers)
+
+	for _, number := range numbers {
+		if number != heap.DeleteMin() {
+			t.Fail()
+		}
+	}
+	if heap.FindMin() != 0 {
+		t.Fail()
+	}
+}
+
+func TestHeapFunc(t *testing.T) {
+	heap := NewFunc(func(a, b string) int { return len(a) - len(b) })
+
+	for _, str := range []string{"ccc", "a", "dddd", "bb"} {
+		heap.Insert(str)
+	}
+
+	for _, str := range []string{"a", "bb", "ccc", "dddd"} {
+		if str != heap.DeleteMin() {
+			t.Fail()
+		}
+	}
+}
+
 func Int(value int) go_heaps.Integer {
 	return go_heaps.Integer(value)
 }
@@ -69,3 +148,293 @@ func Int(value int) go_heaps.Integer {
 func Str(value string) go_heaps.String {
 	return go_heaps.String(value)
 }
+
+func TestLeftistHeapMeld(t *testing.T) {
+	heap := New()
+	other := New()
+	for _, number := range []int{4, 8, 1} {
+		heap.Insert(Int(number))
+	}
+	for _, number := range []int{7, 0, 3} {
+		other.Insert(Int(number))
+	}
+
+	heap.Meld(other)
+	if heap.Len() != 6 || !other.IsEmpty() {
+		t.Fatalf("Len() = %d, other.Len() = %d", heap.Len(), other.Len())
+	}
+	for _, number := range []int{0, 1, 3, 4, 7, 8} {
+		if Int(number) != heap.DeleteMin() {
+			t.Fail()
+		}
+	}
+}
+
+func TestHandle(t *testing.T) {
+	h := NewOrdered[int]()
+	handles := make([]*Handle[int], 10)
+	for i := range handles {
+		handles[i] = h.InsertHandle(i * 10)
+	}
+
+	h.DecreaseKey(handles[9], -5)
+	if h.FindMin() != -5 {
+		t.Fatalf("FindMin() = %d, want -5", h.FindMin())
+	}
+	h.IncreaseKey(handles[9], 95)
+	h.IncreaseKey(handles[0], 55)
+	if got := h.Remove(handles[5]); got != 50 {
+		t.Fatalf("Remove() = %d, want 50", got)
+	}
+	if handles[0].Item() != 55 {
+		t.Fail()
+	}
+
+	var got []int
+	for !h.IsEmpty() {
+		got = append(got, h.DeleteMin())
+	}
+	if !slices.Equal(got, []int{10, 20, 30, 40, 55, 60, 70, 80, 95}) {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestExtended(t *testing.T) {
+	heaptest.RunExtendedTests(t, func() go_heaps.Extended { return New() })
+}
+
+func FuzzHeap(f *testing.F) {
+	heaptest.AddSeeds(f)
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		heaptest.FuzzExtended(t, func() go_heaps.Extended { return New() }, ops)
+		heaptest.FuzzExtended(t, func() go_heaps.Extended { return NewBiased(WeightBiased) }, ops)
+		heaptest.FuzzHandles(t, func() heaptest.HandleHeap[*Handle[int]] { return NewOrdered[int]() }, ops)
+		heaptest.FuzzHandles(t, func() heaptest.HandleHeap[*Handle[int]] {
+			return NewBiasedFunc(WeightBiased, func(a, b int) int { return a - b })
+		}, ops)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	h := NewOrdered[int]()
+	for _, number := range []int{5, 3, 8, 1, 9, 4} {
+		h.Insert(number)
+	}
+	if err := h.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	h.root.s++
+	if h.Validate() == nil {
+		t.Fail()
+	}
+}
+
+func TestWeightBiased(t *testing.T) {
+	h := NewBiasedFunc(WeightBiased, func(a, b int) int { return a - b })
+	handles := make([]*Handle[int], 10)
+	for i := range handles {
+		handles[i] = h.InsertHandle(i * 10)
+	}
+	h.DecreaseKey(handles[9], -5)
+	h.Remove(handles[4])
+	if h.root.s != h.Len() || h.Len() != 9 {
+		t.Fatalf("root weight %d, Len() = %d", h.root.s, h.Len())
+	}
+
+	other := NewBiasedFunc(WeightBiased, func(a, b int) int { return a - b })
+	other.Insert(35)
+	h.Meld(other)
+
+	var got []int
+	for !h.IsEmpty() {
+		got = append(got, h.DeleteMin())
+	}
+	if !slices.Equal(got, []int{-5, 0, 10, 20, 30, 35, 50, 60, 70, 80}) {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestMeldDifferentBias(t *testing.T) {
+	h := NewOrdered[int]()
+	other := NewBiasedFunc(WeightBiased, func(a, b int) int { return a - b })
+	other.Insert(1)
+	defer func() {
+		if recover() == nil {
+			t.Fail()
+		}
+	}()
+	h.Meld(other)
+}
+
+func TestExtendedWeightBiased(t *testing.T) {
+	heaptest.RunExtendedTests(t, func() go_heaps.Extended { return NewBiased(WeightBiased) })
+}
+
+func TestValidateWeightBiased(t *testing.T) {
+	h := NewBiasedFunc(WeightBiased, func(a, b int) int { return a - b })
+	for _, number := range []int{5, 3, 8, 1, 9, 4} {
+		h.Insert(number)
+	}
+	if err := h.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	h.root.left.s++
+	if h.Validate() == nil {
+		t.Fail()
+	}
+}
+
+func TestLargeInputs(t *testing.T) {
+	if testing.Short() || go_heaps.Debug {
+		t.Skip("skipping large inputs")
+	}
+	const n = 1 << 21
+	sorted := make([]int, n)
+	reversed := make([]int, n)
+	for i := range sorted {
+		sorted[i] = i
+		reversed[i] = n - 1 - i
+	}
+	for name, input := range map[string][]int{"sorted": sorted, "reversed": reversed} {
+		for variant, bias := range map[string]Bias{"height": HeightBiased, "weight": WeightBiased} {
+			t.Run(name+"/"+variant, func(t *testing.T) {
+				// insert the first half and meld in the second half
+				h := NewBiasedFunc(bias, func(a, b int) int { return a - b })
+				other := NewBiasedFunc(bias, func(a, b int) int { return a - b })
+				for _, v := range input[:n/2] {
+					h.Insert(v)
+				}
+				for _, v := range input[n/2:] {
+					other.Insert(v)
+				}
+				h.Meld(other)
+				// the reversed input leaves the largest item at the end of a
+				// left path of half the heap
+				if got := h.Delete(n - 1); got != n-1 {
+					t.Fatalf("Delete(%d) = %d", n-1, got)
+				}
+				for i := 0; i < n-1; i++ {
+					if got := h.DeleteMin(); got != i {
+						t.Fatalf("DeleteMin() = %d, want %d", got, i)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestIterable(t *testing.T) {
+	heaptest.RunIterableTests(t, func() heaptest.IterableHeap { return NewOrdered[int]() })
+	t.Run("WeightBiased", func(t *testing.T) {
+		heaptest.RunIterableTests(t, func() heaptest.IterableHeap {
+			return NewBiasedFunc(WeightBiased, func(a, b int) int { return a - b })
+		})
+	})
+}
+
+func TestInsertAll(t *testing.T) {
+	heaptest.RunBulkTests(t, func() heaptest.BulkHeap { return NewOrdered[int]() })
+	t.Run("WeightBiased", func(t *testing.T) {
+		heaptest.RunBulkTests(t, func() heaptest.BulkHeap {
+			return NewBiasedFunc(WeightBiased, func(a, b int) int { return a - b })
+		})
+	})
+}
+
+func TestFromSlice(t *testing.T) {
+	h := FromSliceOrdered([]int{5, 3, 8, 1})
+	if got := slices.Collect(h.Sorted()); !slices.Equal(got, []int{1, 3, 5, 8}) {
+		t.Errorf("expected %v, got %v", []int{1, 3, 5, 8}, got)
+	}
+	l := FromSlice([]go_heaps.Item{go_heaps.Integer(2), go_heaps.Integer(1)})
+	if res := l.DeleteMin(); res != go_heaps.Integer(1) {
+		t.Errorf("expected 1, got %v", res)
+	}
+}
+
+func TestClone(t *testing.T) {
+	heaptest.RunCloneTests(t, NewOrdered[int], (*Heap[int]).Clone)
+	t.Run("WeightBiased", func(t *testing.T) {
+		heaptest.RunCloneTests(t, func() *Heap[int] {
+			return NewBiasedFunc(WeightBiased, func(a, b int) int { return a - b })
+		}, (*Heap[int]).Clone)
+	})
+	l := New()
+	l.Insert(go_heaps.Integer(2))
+	c := l.Clone()
+	l.Insert(go_heaps.Integer(1))
+	if res := c.FindMin(); res != go_heaps.Integer(2) {
+		t.Errorf("expected 2, got %v", res)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	heaptest.RunSnapshotTests[*Heap[int], *Handle[int]](t, NewOrdered[int])
+	t.Run("WeightBiased", func(t *testing.T) {
+		heaptest.RunSnapshotTests[*Heap[int], *Handle[int]](t, func() *Heap[int] {
+			return NewBiasedFunc(WeightBiased, func(a, b int) int { return a - b })
+		})
+	})
+
+	h := FromSliceOrdered([]int{1, 2, 3, 4, 5, 6, 7, 8})
+	s := h.Snapshot()
+	if h.root != s.root {
+		t.Fatal("expected the snapshot to share the root")
+	}
+	// deleting, adjusting and melding copy the shared nodes
+	h.Delete(6)
+	h.Adjust(3, 9)
+	h.Meld(FromSliceOrdered([]int{0, 10}))
+	h.DeleteMin()
+	if got := slices.Collect(s.Sorted()); !slices.Equal(got, []int{1, 2, 3, 4, 5, 6, 7, 8}) {
+		t.Errorf("expected the snapshot to keep %v, got %v", []int{1, 2, 3, 4, 5, 6, 7, 8}, got)
+	}
+	if got := slices.Collect(h.Sorted()); !slices.Equal(got, []int{1, 2, 4, 5, 7, 8, 9, 10}) {
+		t.Errorf("expected %v, got %v", []int{1, 2, 4, 5, 7, 8, 9, 10}, got)
+	}
+	for _, x := range []*Heap[int]{h, s} {
+		if err := x.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	heaptest.RunEncodingTests(t, NewOrdered[int], (*Heap[int]).MarshalBinary)
+}
+
+// rankOf returns the rank a tree view shows for n, which is -1 for a
+// missing node.
+func rankOf(n *go_heaps.TreeNode[int]) int {
+	if n == nil {
+		return -1
+	}
+	return n.Attrs[0].Value.(int)
+}
+
+func TestJSON(t *testing.T) {
+	heaptest.RunJSONTests(t, NewOrdered[int], func(n *go_heaps.TreeNode[int]) error {
+		left, right := -1, -1
+		if len(n.Children) == 2 {
+			left, right = rankOf(n.Children[0]), rankOf(n.Children[1])
+		}
+		if n.Attrs[0].Name != "s" || rankOf(n) != right+1 || left < right {
+			return fmt.Errorf("s-value %v with children of s-values %d and %d", n.Attrs, left, right)
+		}
+		return nil
+	})
+	t.Run("WeightBiased", func(t *testing.T) {
+		heaptest.RunJSONTests(t, func() *Heap[int] { return NewBiasedFunc(WeightBiased, func(a, b int) int { return a - b }) }, func(n *go_heaps.TreeNode[int]) error {
+			weight := 1
+			for _, c := range n.Children {
+				if c != nil {
+					weight += rankOf(c)
+				}
+			}
+			if n.Attrs[0].Name != "weight" || rankOf(n) != weight {
+				return fmt.Errorf("weight %v, want %d", n.Attrs, weight)
+			}
+			return nil
+		})
+	})
+}
@@ -0,0 +1,70 @@
+package leftist
+
+import (
+	"fmt"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// Heap implements the Validator interface
+var _ heap.Validator = (*Heap[int])(nil)
+
+// Validate checks the heap order, the parent pointers unless the heap has
+// snapshots, that every rank is correct and that no right child has a
+// greater rank than its left sibling.
+// The rank is the s-value, one more than the s-value of the right child, or
+// the weight, the size of the subtree, if the heap is weight-biased.
+// The complexity is O(n).
+func (h *Heap[T]) Validate() error {
+	if h.root != nil && h.root.parent != nil && h.owner == nil {
+		return fmt.Errorf("leftist: root %v has a parent", h.root.item)
+	}
+	count := 0
+	if err := h.validate(h.root, &count); err != nil {
+		return err
+	}
+	if count != h.size {
+		return fmt.Errorf("leftist: heap has %d nodes but size %d", count, h.size)
+	}
+	return nil
+}
+
+// sValue returns the s-value of n, which is -1 for a missing node.
+func sValue[T any](n *NodeOf[T]) int {
+	if n == nil {
+		return -1
+	}
+	return n.s
+}
+
+func (h *Heap[T]) validate(n *NodeOf[T], count *int) error {
+	if n == nil {
+		return nil
+	}
+	if *count++; *count > h.size {
+		return fmt.Errorf("leftist: more than %d nodes or a cycle at %v", h.size, n.item)
+	}
+	name := "s-value"
+	if h.bias == WeightBiased {
+		name = "weight"
+	}
+	if want := h.rankOf(n); n.s != want {
+		return fmt.Errorf("leftist: node %v has %s %d, want %d", n.item, name, n.s, want)
+	}
+	if h.rank(n.left) < h.rank(n.right) {
+		return fmt.Errorf("leftist: node %v has %s %d on the left and %d on the right",
+			n.item, name, h.rank(n.left), h.rank(n.right))
+	}
+	for _, c := range []*NodeOf[T]{n.left, n.right} {
+		if c != nil && h.compare(c.item, n.item) < 0 {
+			return fmt.Errorf("leftist: child %v is smaller than its parent %v", c.item, n.item)
+		}
+		if c != nil && c.parent != n && h.owner == nil {
+			return fmt.Errorf("leftist: child %v does not point to its parent %v", c.item, n.item)
+		}
+		if err := h.validate(c, count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
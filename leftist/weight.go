@@ -0,0 +1,89 @@
+package leftist
+
+// Bias selects the rank that a leftist heap keeps greater on the left.
+type Bias int
+
+const (
+	// HeightBiased ranks a node by its s-value, the distance to the nearest
+	// missing child. It is the default.
+	HeightBiased Bias = iota
+	// WeightBiased ranks a node by its weight, the size of its subtree.
+	// The weights of both subtrees are known before merging them, so a
+	// merge decides where to put the result on the way down and needs no
+	// second pass up the right spine.
+	WeightBiased
+)
+
+// NewBiasedFunc returns an initialized Heap with the given Bias ordered by
+// compare.
+func NewBiasedFunc[T any](bias Bias, compare func(a, b T) int) *Heap[T] {
+	return (&Heap[T]{cmp: compare, bias: bias}).Init()
+}
+
+// NewBiased returns an initialized LeftistHeap with the given Bias.
+func NewBiased(bias Bias) *LeftistHeap {
+	h := &LeftistHeap{}
+	h.bias = bias
+	return h.Init()
+}
+
+// Bias returns the Bias of the heap.
+func (h *Heap[T]) Bias() Bias {
+	return h.bias
+}
+
+// rank returns the rank of n, which may be missing.
+func (h *Heap[T]) rank(n *NodeOf[T]) int {
+	if h.bias == WeightBiased {
+		return weight(n)
+	}
+	return sValue(n)
+}
+
+// rankOf computes the rank of n from the ranks of its children.
+func (h *Heap[T]) rankOf(n *NodeOf[T]) int {
+	if h.bias == WeightBiased {
+		return weight(n.left) + weight(n.right) + 1
+	}
+	return sValue(n.right) + 1
+}
+
+// weight returns the size of the subtree rooted at n.
+func weight[T any](n *NodeOf[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.s
+}
+
+// mergeWeight merges the weight-biased trees x and y in a single pass down
+// their right spines. Each node on the way gets the combined weight, and
+// the part still to be merged goes to the left if it outweighs the left
+// child.
+func (h *Heap[T]) mergeWeight(x, y *NodeOf[T]) *NodeOf[T] {
+	var root, parent *NodeOf[T]
+	link := &root
+	for x != nil && y != nil {
+		if h.compare(x.item, y.item) > 0 {
+			x, y = y, x
+		}
+		x = h.mutable(x)
+		x.s += y.s
+		x.parent = parent
+		*link = x
+		rest := x.right
+		if weight(x.left) < weight(rest)+y.s {
+			x.right = x.left
+			link = &x.left
+		} else {
+			link = &x.right
+		}
+		parent, x = x, rest
+	}
+	if x == nil {
+		x = y
+	}
+	*link = x
+	h.setParent(x, parent)
+	return root
+}
@@ -0,0 +1,53 @@
+package pairing
+
+import (
+	"cmp"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// FromSliceFunc returns a Heap ordered by compare and configured by opts
+// that holds items.
+// The complexity is O(n).
+func FromSliceFunc[T any](items []T, compare func(a, b T) int, opts ...Option) *Heap[T] {
+	p := NewFunc(compare, opts...)
+	p.InsertAll(items)
+	return p
+}
+
+// FromSliceOrdered returns a Heap of ordered values configured by opts that
+// holds items.
+// The complexity is O(n).
+func FromSliceOrdered[T cmp.Ordered](items []T, opts ...Option) *Heap[T] {
+	return FromSliceFunc(items, cmp.Compare[T], opts...)
+}
+
+// FromSlice returns a PairHeap configured by opts that holds items.
+// The complexity is O(n).
+func FromSlice(items []heap.Item, opts ...Option) *PairHeap {
+	p := New(opts...)
+	p.InsertAll(items)
+	return p
+}
+
+// InsertAll inserts items into the Heap. It links the items pairwise in a
+// queue until one tree is left and adds that tree to the Heap.
+// The complexity is O(k) for k items.
+func (p *Heap[T]) InsertAll(items []T) {
+	if len(items) == 0 {
+		return
+	}
+	var first, last *node[T]
+	for _, item := range items {
+		n := &node[T]{item: item, owner: p.owner}
+		if first == nil {
+			first = n
+		} else {
+			last.sibling = n
+		}
+		last = n
+	}
+	p.add(p.multiPass(first))
+	p.size += len(items)
+	heap.DebugValidate(p)
+}
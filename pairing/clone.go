@@ -0,0 +1,199 @@
+package pairing
+
+import (
+	heap "github.com/theodesp/go-heaps"
+)
+
+// owner identifies the heap that may modify a node in place. Heaps that
+// share nodes after a Snapshot have different owners, so each of them
+// copies a shared node before it modifies it.
+type owner struct {
+	_ byte
+}
+
+// mutable returns n if the heap owns it or has no snapshots and a copy of
+// n owned by the heap otherwise.
+func (p *Heap[T]) mutable(n *node[T]) *node[T] {
+	if p.owner == nil || n.owner == p.owner {
+		return n
+	}
+	c := *n
+	c.owner = p.owner
+	return &c
+}
+
+// ownList returns the sibling list starting at first with every node owned
+// by the heap. On a heap with snapshots it copies the nodes it does not own
+// and links each copy back to the previous one, as BackToFront needs.
+func (p *Heap[T]) ownList(first *node[T]) *node[T] {
+	if p.owner == nil {
+		return first
+	}
+	var head, prev *node[T]
+	for n := first; n != nil; n = n.sibling {
+		c := p.mutable(n)
+		c.prev = prev
+		if prev == nil {
+			head = c
+		} else {
+			prev.sibling = c
+		}
+		prev = c
+	}
+	return head
+}
+
+// checkHandles panics if the heap has snapshots, since handles need the
+// back pointers that such heaps do not keep.
+func (p *Heap[T]) checkHandles() {
+	if p.owner != nil {
+		panic("handles are disabled on a heap with snapshots")
+	}
+}
+
+// Clone returns a deep copy of the Heap with the same options. Handles of
+// the Heap do not refer to items of the copy. The copy has no snapshots,
+// so it supports handles even if the Heap does not.
+// The complexity is O(n).
+func (p *Heap[T]) Clone() *Heap[T] {
+	c := &Heap[T]{root: cloneTree(p.root), size: p.size, cmp: p.cmp, options: p.options}
+	var last *node[T]
+	for n := p.aux; n != nil; n = n.sibling {
+		t := cloneTree(n)
+		if last == nil {
+			c.aux = t
+		} else {
+			last.sibling, t.prev = t, last
+		}
+		last = t
+	}
+	return c
+}
+
+// Snapshot returns a copy of the Heap with the same options that shares all
+// nodes with it. Afterwards both heaps copy a shared node before they
+// modify it: linking copies the two roots it links, and DeleteMin copies
+// the children of the minimum before it pairs them, which it visits anyway.
+// Shared nodes cannot point back to a single parent or sibling, so both
+// heaps disable handles until they are cleared: InsertHandle, Remove,
+// DecreaseKey and IncreaseKey panic, and Delete and Adjust copy the path
+// from the root to the item instead.
+// The complexity is O(1).
+func (p *Heap[T]) Snapshot() *Heap[T] {
+	p.owner = new(owner)
+	return &Heap[T]{root: p.root, aux: p.aux, size: p.size, cmp: p.cmp, options: p.options, owner: new(owner)}
+}
+
+// Clone returns a deep copy of the PairHeap with the same options.
+// The complexity is O(n).
+func (p *PairHeap) Clone() *PairHeap {
+	return &PairHeap{*p.Heap.Clone()}
+}
+
+// Snapshot returns a copy of the PairHeap that shares all nodes with it,
+// like Heap.Snapshot.
+// The complexity is O(1).
+func (p *PairHeap) Snapshot() *PairHeap {
+	return &PairHeap{*p.Heap.Snapshot()}
+}
+
+// findPath returns the path to a node holding item from root or from the
+// first auxiliary root along child and sibling pointers, or nil if there
+// is none.
+func (p *Heap[T]) findPath(item T) []*node[T] {
+	type visit struct {
+		n     *node[T]
+		depth int
+	}
+	var path []*node[T]
+	var stack []visit
+	for _, n := range []*node[T]{p.aux, p.root} {
+		if n != nil {
+			stack = append(stack, visit{n, 0})
+		}
+	}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		path = append(path[:v.depth], v.n)
+		if p.compare(v.n.item, item) == 0 {
+			return path
+		}
+		if v.n.sibling != nil {
+			stack = append(stack, visit{v.n.sibling, v.depth + 1})
+		}
+		if v.n.child != nil {
+			stack = append(stack, visit{v.n.child, v.depth + 1})
+		}
+	}
+	return nil
+}
+
+// removePath removes the last node of path, which leads to it as returned
+// by findPath, and returns its item. It copies the nodes on the path, so
+// it works on a heap with snapshots.
+// The complexity is the length of path plus O(log n) amortized.
+func (p *Heap[T]) removePath(path []*node[T]) T {
+	n := path[len(path)-1]
+	if n == p.root {
+		return p.DeleteMin()
+	}
+	// replace every node before n with a copy linked to the copy before it
+	var prev *node[T]
+	for _, x := range path[:len(path)-1] {
+		c := p.mutable(x)
+		switch {
+		case prev == nil && x == p.root:
+			p.root = c
+		case prev == nil:
+			p.aux = c
+		case prev.child == x:
+			prev.child = c
+		default:
+			prev.sibling = c
+		}
+		prev = c
+	}
+	switch {
+	case prev == nil:
+		p.aux = n.sibling
+	case prev.child == n:
+		prev.child = n.sibling
+	default:
+		prev.sibling = n.sibling
+	}
+	if rest := p.combine(n.child); rest != nil {
+		p.add(rest)
+	}
+	p.size--
+	heap.DebugValidate(p)
+	return n.item
+}
+
+// cloneTree returns a copy of the tree rooted at n without its siblings.
+// It keeps the nodes still to copy on a stack, since the trees of a pairing
+// heap can be as deep as the heap.
+func cloneTree[T any](n *node[T]) *node[T] {
+	if n == nil {
+		return nil
+	}
+	root := &node[T]{item: n.item}
+	// pairs of a node and its copy
+	stack := []*node[T]{n, root}
+	for len(stack) > 0 {
+		from, to := stack[len(stack)-2], stack[len(stack)-1]
+		stack = stack[:len(stack)-2]
+		prev := to
+		for c := from.child; c != nil; c = c.sibling {
+			t := &node[T]{item: c.item, prev: prev}
+			if prev == to {
+				to.child = t
+			} else {
+				prev.sibling = t
+			}
+			prev = t
+			stack = append(stack, c, t)
+		}
+	}
+	return root
+}
@@ -0,0 +1,82 @@
+package pairing
+
+import (
+	"encoding"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// Heap implements the BinaryMarshaler and BinaryUnmarshaler interfaces
+var (
+	_ encoding.BinaryMarshaler   = (*Heap[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*Heap[int])(nil)
+)
+
+// shapeKind is the Kind of the shape encodings of pairing heaps.
+const shapeKind = "pairing"
+
+// MarshalBinary encodes the items of the Heap in order of appearance with
+// the go_heaps.Codec for T.
+// The complexity is O(n).
+func (p *Heap[T]) MarshalBinary() ([]byte, error) {
+	return heap.MarshalItems(p.All())
+}
+
+// MarshalShape encodes the items of the Heap together with its trees: the
+// tree at the root followed by the auxiliary list. UnmarshalBinary restores
+// them without comparing items.
+// The complexity is O(n).
+func (p *Heap[T]) MarshalShape() ([]byte, error) {
+	e := heap.Encoding[T]{Kind: shapeKind}
+	e.Items, e.Shape = heap.EncodeForest(p.roots, children[T], itemOf[T])
+	return e.MarshalBinary()
+}
+
+// UnmarshalBinary replaces the items of the Heap with the items encoded in
+// data. The Heap keeps its order and pairing strategy. It restores the
+// trees of an encoding made by MarshalShape, if the pairing strategy allows
+// for their number, and otherwise builds itself with InsertAll. Restored
+// trees are trusted to be ordered like the Heap.
+// The complexity is O(n).
+func (p *Heap[T]) UnmarshalBinary(data []byte) error {
+	var e heap.Encoding[T]
+	if err := e.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	p.Clear()
+	if e.Kind == shapeKind && p.decodeShape(&e) {
+		heap.DebugValidate(p)
+		return nil
+	}
+	p.InsertAll(e.Items)
+	return nil
+}
+
+// decodeShape rebuilds the trees of e in the empty Heap and reports
+// whether it could. The first tree becomes the root and the others the
+// auxiliary list, which only Auxiliary pairing keeps.
+func (p *Heap[T]) decodeShape(e *heap.Encoding[T]) bool {
+	nodes := make([]*node[T], len(e.Items))
+	for i, item := range e.Items {
+		nodes[i] = &node[T]{item: item}
+	}
+	var root, aux *node[T]
+	err := heap.DecodeForest(e.Shape, func(i, parent, prev int) {
+		n := nodes[i]
+		switch {
+		case parent < 0 && prev < 0:
+			root = n
+		case parent < 0 && nodes[prev] == root:
+			aux = n
+		case prev < 0:
+			nodes[parent].child, n.prev = n, nodes[parent]
+		default:
+			nodes[prev].sibling, n.prev = n, nodes[prev]
+		}
+	})
+	if err != nil || aux != nil && p.pairing != Auxiliary {
+		return false
+	}
+	p.root, p.aux, p.size = root, aux, len(nodes)
+	return true
+}
@@ -0,0 +1,48 @@
+package pairing
+
+import (
+	"iter"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// Heap implements the Iterable interface
+var _ heap.Iterable[int] = (*Heap[int])(nil)
+
+// Ascend calls it on each element of the Heap in ascending order until it
+// returns false, without modifying the Heap.
+// The complexity is O(n log n) to visit all n items.
+func (p *Heap[T]) Ascend(it func(item T) bool) {
+	heap.AscendForest(p.roots, children[T], itemOf[T], p.compare, it)
+}
+
+// All returns an iterator over the items of the Heap in order of
+// appearance.
+func (p *Heap[T]) All() iter.Seq[T] {
+	return p.Do
+}
+
+// Sorted returns an iterator over the items of the Heap in ascending order.
+func (p *Heap[T]) Sorted() iter.Seq[T] {
+	return p.Ascend
+}
+
+// roots visits root and the roots of the auxiliary list.
+func (p *Heap[T]) roots(visit func(n *node[T])) {
+	if p.root != nil {
+		visit(p.root)
+	}
+	for n := p.aux; n != nil; n = n.sibling {
+		visit(n)
+	}
+}
+
+func children[T any](n *node[T], visit func(c *node[T])) {
+	for c := n.child; c != nil; c = c.sibling {
+		visit(c)
+	}
+}
+
+func itemOf[T any](n *node[T]) T {
+	return n.item
+}
@@ -0,0 +1,43 @@
+package pairing
+
+import (
+	"encoding/json"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+var (
+	// Heap implements the Marshaler and Unmarshaler interfaces
+	_ json.Marshaler   = (*Heap[int])(nil)
+	_ json.Unmarshaler = (*Heap[int])(nil)
+	// Heap has a tree view
+	_ heap.TreeViewer[int] = (*Heap[int])(nil)
+)
+
+// MarshalJSON encodes the items of the Heap in order of appearance as a
+// go_heaps.JSONView without the tree view.
+// The complexity is O(n).
+func (p *Heap[T]) MarshalJSON() ([]byte, error) {
+	return heap.ViewOf[T](p, false).MarshalJSON()
+}
+
+// UnmarshalJSON replaces the items of the Heap with the items of the
+// go_heaps.JSONView encoded in data. The Heap keeps its order and pairing
+// strategy and builds itself with InsertAll.
+// The complexity is O(n).
+func (p *Heap[T]) UnmarshalJSON(data []byte) error {
+	var v heap.JSONView[T]
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	p.Clear()
+	p.InsertAll(v.Items)
+	return nil
+}
+
+// Tree returns the tree at the root followed by the trees of the
+// auxiliary list. Their nodes have no attributes.
+// The complexity is O(n).
+func (p *Heap[T]) Tree() []*heap.TreeNode[T] {
+	return heap.TreeOf(p.roots, children[T], itemOf[T], nil)
+}
@@ -0,0 +1,143 @@
+package pairing
+
+// Pairing selects how DeleteMin and Remove link the subtrees left behind by
+// a removed node into a single tree.
+type Pairing int
+
+const (
+	// TwoPass links the subtrees in pairs from front to back and then links
+	// the pairs into one tree from back to front. It is the default.
+	TwoPass Pairing = iota
+	// MultiPass keeps the subtrees in a queue and repeatedly links the two
+	// at its front, appending the result to its back, until one is left.
+	MultiPass
+	// BackToFront is the mirror image of TwoPass: it links the subtrees in
+	// pairs from back to front and then links the pairs from front to back.
+	BackToFront
+	// Auxiliary is TwoPass with an auxiliary list of roots. Insert, Meld and
+	// DecreaseKey add trees to the list without linking them, and DeleteMin
+	// links the list with MultiPass before removing the minimum.
+	Auxiliary
+)
+
+// Option configures a Heap.
+type Option func(*options)
+
+type options struct {
+	pairing Pairing
+}
+
+// WithPairing sets how the heap links subtrees.
+func WithPairing(pairing Pairing) Option {
+	return func(o *options) { o.pairing = pairing }
+}
+
+func (o *options) apply(opts []Option) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// Pairing returns how the heap links subtrees.
+func (p *Heap[T]) Pairing() Pairing {
+	return p.pairing
+}
+
+// combine links the sibling list starting at first into a single tree
+// using the pairing strategy of the heap.
+func (p *Heap[T]) combine(first *node[T]) *node[T] {
+	if first == nil {
+		return nil
+	}
+	first = p.ownList(first)
+	first.prev = nil
+	switch p.pairing {
+	case MultiPass:
+		return p.multiPass(first)
+	case BackToFront:
+		return p.backToFront(first)
+	default:
+		return p.twoPass(first)
+	}
+}
+
+// detach clears the sibling links of n, which may be nil.
+func detach[T any](n *node[T]) {
+	if n != nil {
+		n.prev, n.sibling = nil, nil
+	}
+}
+
+func (p *Heap[T]) twoPass(first *node[T]) *node[T] {
+	// pair front to back, keeping the pairs in a list in reverse order
+	var pairs *node[T]
+	for first != nil {
+		a, b := first, first.sibling
+		first = nil
+		if b != nil {
+			first = b.sibling
+		}
+		detach(a)
+		detach(b)
+		t := p.link(a, b)
+		t.sibling = pairs
+		pairs = t
+	}
+	return p.accumulate(pairs)
+}
+
+func (p *Heap[T]) backToFront(first *node[T]) *node[T] {
+	last := first
+	for last.sibling != nil {
+		last = last.sibling
+	}
+	// pair back to front, keeping the pairs in a list in order
+	var pairs *node[T]
+	for last != nil {
+		b, a := last, last.prev
+		last = nil
+		if a != nil {
+			last = a.prev
+		}
+		detach(a)
+		detach(b)
+		t := p.link(a, b)
+		t.sibling = pairs
+		pairs = t
+	}
+	return p.accumulate(pairs)
+}
+
+// accumulate links the list of trees starting at first one after another
+// into a single tree.
+func (p *Heap[T]) accumulate(first *node[T]) *node[T] {
+	var root *node[T]
+	for first != nil {
+		next := first.sibling
+		first.sibling = nil
+		root = p.link(root, first)
+		first = next
+	}
+	return root
+}
+
+func (p *Heap[T]) multiPass(first *node[T]) *node[T] {
+	last := first
+	for last.sibling != nil {
+		last = last.sibling
+	}
+	for first != last {
+		a, b := first, first.sibling
+		first = b.sibling
+		detach(a)
+		detach(b)
+		t := p.link(a, b)
+		if first == nil {
+			return t
+		}
+		last.sibling = t
+		last = t
+	}
+	detach(first)
+	return first
+}
@@ -6,247 +6,365 @@
 package pairing
 
 import (
-	heap "github.com/theodesp/go-heaps"
+	"cmp"
 	"fmt"
+
+	heap "github.com/theodesp/go-heaps"
 )
 
 // PairHeap implements the Extended interface
 var _ heap.Extended = (*PairHeap)(nil)
 
+// Heap implements the ExtendedHeap interface
+var _ heap.ExtendedHeap[int] = (*Heap[int])(nil)
+
+// Heap is an implementation of a Pairing Heap over values of type T.
+// The zero value for Heap is an empty two-pass Heap ordered by
+// go_heaps.Compare.
+type Heap[T any] struct {
+	root *node[T]
+	// aux is the list of roots kept apart from root by Auxiliary pairing.
+	// root always holds the smallest item.
+	aux  *node[T]
+	size int
+	cmp  func(a, b T) int
+	// owner is set once the Heap has snapshots
+	owner *owner
+	options
+}
+
 // PairHeap is an implementation of a Pairing Heap.
 // The zero value for PairHeap Root is an empty Heap.
 type PairHeap struct {
-	root       *node
+	Heap[heap.Item]
 }
 
-// node contains the current item and the list if the sub-heaps
-type node struct {
+// node is stored in left-child, right-sibling form: the children of a node
+// form a list starting at child and linked by sibling.
+type node[T any] struct {
 	// for use by client; untouched by this library
-	item heap.Item
-	// List of children nodes all containing values less than the Top of the heap
-	children []*node
-	// A reference to the parent Heap Node
-	parent *node
+	item T
+	// first child and next sibling
+	child, sibling *node[T]
+	// previous sibling, or the parent of a first child; not kept on a heap
+	// with snapshots
+	prev  *node[T]
+	owner *owner
 }
 
-func (n *node) detach() []*node {
-	if n.parent == nil {
-		return nil // avoid detaching root
-	}
-	for _, node := range n.children {
-		node.parent = nil
-	}
-	var idx int
-	for i, node := range n.parent.children {
-		if node == n {
-			idx = i
-			break
+// cut detaches the subtree rooted at n from its parent and siblings.
+func (p *Heap[T]) cut(n *node[T]) {
+	switch {
+	case n.prev == nil:
+		if n == p.aux {
+			p.aux = n.sibling
 		}
+	case n.prev.child == n:
+		n.prev.child = n.sibling
+	default:
+		n.prev.sibling = n.sibling
 	}
-	n.parent.children = append(n.parent.children[:idx], n.parent.children[idx+1:]...)
-	n.parent = nil
-	return n.children
-}
-
-func (n *node) iterItem(iter heap.ItemIterator) {
-	if !iter(n.item) {
-		return
+	if n.sibling != nil {
+		n.sibling.prev = n.prev
 	}
-	n.iterChildren(n.children, iter)
+	detach(n)
 }
 
-func (n *node) iterChildren(children []*node, iter heap.ItemIterator) {
-	if len(children) == 0 {
-		return
+// walk calls visit on the nodes of the heap in preorder until it returns
+// false.
+func (p *Heap[T]) walk(visit func(n *node[T]) bool) {
+	var stack []*node[T]
+	for _, n := range []*node[T]{p.aux, p.root} {
+		if n != nil {
+			stack = append(stack, n)
+		}
 	}
-	for _, node := range children {
-		if !iter(node.item) {
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !visit(n) {
 			return
 		}
-		n.iterChildren(node.children, iter)
+		if n.sibling != nil {
+			stack = append(stack, n.sibling)
+		}
+		if n.child != nil {
+			stack = append(stack, n.child)
+		}
 	}
 }
 
-func (n *node) findNode(item heap.Item) *node {
-	if n.item.Compare(item) == 0 {
-		return n
-	} else {
-		return n.findInChildren(n.children, item)
-	}
+// Init initializes or clears the Heap
+func (p *Heap[T]) Init() *Heap[T] {
+	p.root, p.aux = nil, nil
+	p.size = 0
+	p.owner = nil
+	return p
 }
 
-func (n *node) findInChildren(children []*node, item heap.Item) *node {
-	if len(children) == 0 {
-		return nil
-	}
-	var node *node
-loop:
-	for _, child := range children {
-		node = child.findNode(item)
-		if node != nil {
-			break loop
-		}
-	}
-	return node
+// NewFunc returns an initialized Heap ordered by compare and configured by
+// opts.
+func NewFunc[T any](compare func(a, b T) int, opts ...Option) *Heap[T] {
+	p := &Heap[T]{cmp: compare}
+	p.apply(opts)
+	return p.Init()
+}
+
+// NewOrdered returns an initialized Heap of ordered values configured by
+// opts.
+func NewOrdered[T cmp.Ordered](opts ...Option) *Heap[T] {
+	return NewFunc(cmp.Compare[T], opts...)
 }
 
 // Init initializes or clears the PairHeap
 func (p *PairHeap) Init() *PairHeap {
-	p.root = &node{}
+	p.Heap.Init()
+	return p
+}
+
+// New returns an initialized PairHeap configured by opts.
+func New(opts ...Option) *PairHeap {
+	p := &PairHeap{}
+	p.apply(opts)
+	return p.Init()
+}
+
+// NewWithComparator returns an initialized PairHeap ordered by compare
+// instead of Item.Compare and configured by opts.
+func NewWithComparator(compare func(a, b heap.Item) int, opts ...Option) *PairHeap {
+	p := New(opts...)
+	p.cmp = compare
 	return p
 }
 
-// New returns an initialized PairHeap.
-func New() *PairHeap { return new(PairHeap).Init() }
+func (p *Heap[T]) compare(a, b T) int {
+	if p.cmp == nil {
+		return heap.Compare(a, b)
+	}
+	return p.cmp(a, b)
+}
 
-// IsEmpty returns true if PairHeap p is empty.
+// IsEmpty returns true if Heap p is empty.
 // The complexity is O(1).
-func (p *PairHeap) IsEmpty() bool {
-	return p.root.item == nil
+func (p *Heap[T]) IsEmpty() bool {
+	return p.root == nil
 }
 
-// Resets the current PairHeap
-func (p *PairHeap) Clear() {
+// Len returns the number of items in the Heap.
+// The complexity is O(1).
+func (p *Heap[T]) Len() int {
+	return p.size
+}
+
+// Resets the current Heap
+func (p *Heap[T]) Clear() {
 	p.Init()
 }
 
 // Find the smallest item in the priority queue.
 // The complexity is O(1).
-func (p *PairHeap) FindMin() heap.Item {
+func (p *Heap[T]) FindMin() T {
 	if p.IsEmpty() {
-		return nil
+		var zero T
+		return zero
 	}
 	return p.root.item
 }
 
-// Inserts the value to the PairHeap and returns the item
+// Inserts the value to the Heap and returns the item
 // The complexity is O(1).
-func (p *PairHeap) Insert(item heap.Item) heap.Item {
-	p.root = merge(p.root, &node{item: item})
+func (p *Heap[T]) Insert(item T) T {
+	p.add(&node[T]{item: item, owner: p.owner})
+	p.size++
+	heap.DebugValidate(p)
 	return item
 }
 
+// Handle is an opaque reference to an item inserted with InsertHandle.
+// It stays valid until the item is removed from the heap.
+type Handle[T any] node[T]
 
-// toDelete details what item to remove in a node call.
-type toDelete int
+// Item returns the item referenced by the Handle.
+func (h *Handle[T]) Item() T {
+	return h.item
+}
 
-const (
-	removeItem toDelete = iota   // removes the given item
-	removeMin                  // removes min item in the heap
-)
+// InsertHandle inserts the value to the Heap and returns a Handle to it
+// that can be passed to DecreaseKey, IncreaseKey and Remove.
+// The complexity is O(1).
+func (p *Heap[T]) InsertHandle(item T) *Handle[T] {
+	p.checkHandles()
+	n := &node[T]{item: item}
+	p.add(n)
+	p.size++
+	heap.DebugValidate(p)
+	return (*Handle[T])(n)
+}
 
-// DeleteMin removes the top most value from the PairHeap and returns it
+// DeleteMin removes the top most value from the Heap and returns it
 // The complexity is O(log n) amortized.
-func (p *PairHeap) DeleteMin() heap.Item {
-	return p.deleteItem(nil, removeMin)
+func (p *Heap[T]) DeleteMin() T {
+	if p.IsEmpty() {
+		var zero T
+		return zero
+	}
+	p.flush()
+	result := p.root
+	p.root = p.combine(result.child)
+	if p.owner == nil {
+		result.child = nil
+	}
+	p.size--
+	heap.DebugValidate(p)
+	return result.item
 }
 
 // Deletes a node from the heap and returns the item
-// The complexity is O(log n) amortized.
-func (p *PairHeap) Delete(item heap.Item) heap.Item {
-	return p.deleteItem(item, removeItem)
+// The complexity is O(n) to find the item plus O(log n) amortized.
+func (p *Heap[T]) Delete(item T) T {
+	if p.owner != nil {
+		path := p.findPath(item)
+		if path == nil {
+			var zero T
+			return zero
+		}
+		return p.removePath(path)
+	}
+	n := p.find(item)
+	if n == nil {
+		var zero T
+		return zero
+	}
+	return p.Remove((*Handle[T])(n))
 }
 
-func (p *PairHeap) deleteItem(item heap.Item, typ toDelete) heap.Item {
-	var result node
+// Remove deletes the item referenced by h from the heap and returns it
+// The complexity is O(log n) amortized.
+func (p *Heap[T]) Remove(h *Handle[T]) T {
+	p.checkHandles()
+	n := (*node[T])(h)
+	if n == p.root {
+		return p.DeleteMin()
+	}
+	p.cut(n)
+	if rest := p.combine(n.child); rest != nil {
+		p.add(rest)
+	}
+	n.child = nil
+	p.size--
+	heap.DebugValidate(p)
+	return n.item
+}
 
-	if len(p.root.children) == 0 {
-		result = *p.root
-		p.root.item = nil
-	} else {
-		switch typ {
-		case removeMin:
-			result = *p.root
-			p.root = mergePairs(p.root, p.root.children)
-		case removeItem:
-			node := p.root.findNode(item)
-			if node == nil {
-				return nil
-			} else {
-				children := node.detach()
-				p.root.children = append(p.root.children, children...)
-				result = *node
-			}
-		default:
-			panic("invalid type")
-		}
+// DecreaseKey replaces the item referenced by h with a smaller item.
+// The complexity is O(1).
+func (p *Heap[T]) DecreaseKey(h *Handle[T], item T) {
+	p.checkHandles()
+	n := (*node[T])(h)
+	if p.compare(item, n.item) > 0 {
+		panic("new item is greater than the previous one")
+	}
+	n.item = item
+	if n != p.root {
+		p.cut(n)
+		p.add(n)
 	}
+	heap.DebugValidate(p)
+}
 
-	return result.item
+// IncreaseKey replaces the item referenced by h with a greater item.
+// The complexity is O(log n) amortized.
+func (p *Heap[T]) IncreaseKey(h *Handle[T], item T) {
+	p.checkHandles()
+	n := (*node[T])(h)
+	if p.compare(item, n.item) < 0 {
+		panic("new item is smaller than the previous one")
+	}
+	p.Remove(h)
+	n.item = item
+	p.add(n)
+	p.size++
+	heap.DebugValidate(p)
 }
 
 // Adjusts the value to the node item and returns it
-// The complexity is O(n) amortized.
-func (p *PairHeap) Adjust(item, new heap.Item) heap.Item {
-	n := p.root.findNode(item)
+// The complexity is O(n) to find the item plus O(log n) amortized.
+func (p *Heap[T]) Adjust(item, new T) T {
+	if p.owner != nil {
+		path := p.findPath(item)
+		if path == nil {
+			var zero T
+			return zero
+		}
+		p.removePath(path)
+		p.Insert(new)
+		return new
+	}
+	n := p.find(item)
 	if n == nil {
-		return nil
+		var zero T
+		return zero
 	}
 
-	if n == p.root {
-		p.DeleteMin()
-		return p.Insert(new)
+	if p.compare(new, n.item) <= 0 {
+		p.DecreaseKey((*Handle[T])(n), new)
 	} else {
-		children := n.detach()
-		p.Insert(new)
-		p.root.children = append(p.root.children, children...)
-		return n.item
+		p.IncreaseKey((*Handle[T])(n), new)
 	}
+	return new
 }
 
-// Exhausting search of the element that matches item and returns it
-// The complexity is O(n) amortized.
-func (p *PairHeap) Find(item heap.Item) heap.Item {
-	if p.IsEmpty() {
-		return nil
-	}
-	var found heap.Item
-	p.root.iterItem(func(i heap.Item) bool {
-		if item.Compare(i) == 0 {
-			found = i
+// find returns the node holding item or nil.
+func (p *Heap[T]) find(item T) *node[T] {
+	var found *node[T]
+	p.walk(func(n *node[T]) bool {
+		if p.compare(n.item, item) == 0 {
+			found = n
 			return false
-		} else {
-			return true
 		}
+		return true
 	})
 	return found
 }
 
+// Exhausting search of the element that matches item and returns it
+// The complexity is O(n) amortized.
+func (p *Heap[T]) Find(item T) T {
+	var found T
+	if n := p.find(item); n != nil {
+		found = n.item
+	}
+	return found
+}
 
 // Do calls function cb on each element of the PairingHeap, in order of appearance.
 // The behavior of Do is undefined if cb changes *p.
-func (p *PairHeap) Do(it heap.ItemIterator) {
-	if p.IsEmpty() {
-		return
-	}
-	p.root.iterItem(it)
+func (p *Heap[T]) Do(it func(item T) bool) {
+	p.walk(func(n *node[T]) bool { return it(n.item) })
 }
 
 // Return the heap formed by taking the union of the item disjoint
-// current heap and a that is of the same type
-func (p *PairHeap) Meld(a heap.Interface) heap.Interface {
+// current heap and a that is of the same type.
+// If a has snapshots and the heap does not, Meld melds a Clone of a, so
+// that the heap keeps its handles; this takes O(m).
+func (p *Heap[T]) Meld(a heap.Heap[T]) heap.Heap[T] {
 	if a == nil {
 		return p
 	}
-	switch a.(type) {
-	case *PairHeap:
-		h := a.(*PairHeap)
-		if h.IsEmpty() {
+	switch h := a.(type) {
+	case *Heap[T]:
+		if h == p || h.IsEmpty() {
 			return p
 		}
-		if p.IsEmpty() {
-			p.root = h.root
-			h.Clear()
-			return p
+		src := h
+		if h.owner != nil && p.owner == nil {
+			src = h.Clone()
 		}
-		if p.FindMin().Compare(h.FindMin()) > 0 {
-			h.root = merge(h.root, p.root)
-			p.root = h.root
-			h.Clear()
-		} else {
-			p.root = merge(p.root, h.root)
-		}
-
+		src.flush()
+		p.add(src.root)
+		p.size += h.size
+		h.Clear()
+		heap.DebugValidate(p)
 	default:
 		panic(fmt.Sprintf("unexpected type %T", a))
 	}
@@ -254,47 +372,72 @@ func (p *PairHeap) Meld(a heap.Interface) heap.Interface {
 	return p
 }
 
-func merge(a, b *node) *node {
-	if a.item == nil { // Case when root is empty
-		a = b
-		return a
+// Return the heap formed by taking the union of the item disjoint
+// current heap and a that is of the same type
+func (p *PairHeap) Meld(a heap.Interface) heap.Interface {
+	if a == nil {
+		return p
+	}
+	switch h := a.(type) {
+	case *PairHeap:
+		p.Heap.Meld(&h.Heap)
+	default:
+		panic(fmt.Sprintf("unexpected type %T", a))
 	}
 
-	if a.item.Compare(b.item) < 0 {
-		// put 'second' as the first child of 'first' and update the parent
-		a.children = append([]*node{b}, a.children...)
-		b.parent = a
-		return a
-	} else {
-		// put 'first' as the first child of 'second' and update the parent
-		b.children = append([]*node{a}, b.children...)
-		a.parent = b
+	return p
+}
+
+// link makes the greater of the roots a and b, which may be nil, the first
+// child of the other and returns the new root. b wins ties.
+func (p *Heap[T]) link(a, b *node[T]) *node[T] {
+	if a == nil {
 		return b
 	}
+	if b == nil {
+		return a
+	}
+	if p.compare(a.item, b.item) >= 0 {
+		a, b = b, a
+	}
+	a, b = p.mutable(a), p.mutable(b)
+	b.sibling = a.child
+	if p.owner == nil {
+		if a.child != nil {
+			a.child.prev = b
+		}
+		b.prev = a
+	}
+	a.child = b
+	return a
 }
 
-// Merges heaps together
-func mergePairs(root *node, heaps []*node) *node {
-	if len(heaps) == 1 {
-		root = heaps[0]
-		heaps[0].parent = nil
-		return root
+// add puts the tree rooted at n into the heap. Auxiliary pairing keeps the
+// smaller of n and root as root and moves the other to the auxiliary list.
+func (p *Heap[T]) add(n *node[T]) {
+	if p.pairing != Auxiliary || p.root == nil {
+		p.root = p.link(p.root, n)
+		return
 	}
-	var merged *node
-	for { // iteratively merge heaps
-		if len(heaps) == 0 {
-			break
-		}
-		if merged == nil {
-			merged = merge(heaps[0], heaps[1])
-			heaps = heaps[2:]
-		} else {
-			merged = merge(merged, heaps[0])
-			heaps = heaps[1:]
-		}
+	if p.compare(n.item, p.root.item) < 0 {
+		p.root, n = n, p.root
+	}
+	n = p.mutable(n)
+	n.sibling = p.aux
+	if p.aux != nil && p.owner == nil {
+		p.aux.prev = n
 	}
-	root = merged
-	merged.parent = nil
+	p.aux = n
+}
 
-	return root
+// flush links the trees of the auxiliary list with MultiPass and makes the
+// result a child of root.
+func (p *Heap[T]) flush() {
+	if p.aux == nil {
+		return
+	}
+	t := p.multiPass(p.ownList(p.aux))
+	p.aux = nil
+	// root wins ties, so that it stays the node Remove expects to drop
+	p.root = p.link(t, p.root)
 }
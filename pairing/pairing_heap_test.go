@@ -7,7 +7,10 @@ import (
 	heap "github.com/theodesp/go-heaps"
 	"fmt"
 	"math/rand"
-	"time"
+	"encoding/json"
+	"github.com/theodesp/go-heaps/heaptest"
+	"slices"
+	"sort"
 )
 
 type PairingHeapTestSuite struct {
@@ -23,15 +26,12 @@ func TestExampleTestSuite(t *testing.T) {
 	suite.Run(t, new(PairingHeapTestSuite))
 }
 
-func init() {
-	seed := time.Now().Unix()
-	fmt.Println(seed)
-	rand.Seed(seed)
-}
+// rnd is seeded with a constant so that failures can be reproduced
+var rnd = rand.New(rand.NewSource(1))
 
 // perm returns a random permutation of n Int items in the range [0, n).
 func perm(n int) (out []heap.Item) {
-	for _, v := range rand.Perm(n) {
+	for _, v := range rnd.Perm(n) {
 		out = append(out, Int(v))
 	}
 	return
@@ -88,6 +88,39 @@ func (suite *PairingHeapTestSuite) TestIsEmpty() {
 	assert.Equal(suite.T(), suite.heap.IsEmpty(), false)
 }
 
+func (suite *PairingHeapTestSuite) TestLen() {
+	assert.Equal(suite.T(), suite.heap.Len(), 0)
+	for _, v := range perm(10) {
+		suite.heap.Insert(v)
+	}
+	suite.heap.Delete(Int(3))
+	suite.heap.DeleteMin()
+	assert.Equal(suite.T(), suite.heap.Len(), 8)
+
+	heapB := New()
+	heapB.Insert(Int(20))
+	suite.heap.Meld(heapB)
+	assert.Equal(suite.T(), suite.heap.Len(), 9)
+	assert.Equal(suite.T(), heapB.Len(), 0)
+
+	suite.heap.Clear()
+	assert.Equal(suite.T(), suite.heap.Len(), 0)
+	assert.Nil(suite.T(), suite.heap.DeleteMin())
+	assert.Equal(suite.T(), suite.heap.Len(), 0)
+}
+
+func (suite *PairingHeapTestSuite) TestMaxHeap() {
+	maxHeap := NewWithComparator(func(a, b heap.Item) int { return b.Compare(a) })
+	for _, v := range perm(10) {
+		maxHeap.Insert(v)
+		suite.heap.Insert(heap.Reverse(v))
+	}
+	for _, v := range rangrev(10) {
+		assert.Equal(suite.T(), maxHeap.DeleteMin(), v)
+		assert.Equal(suite.T(), suite.heap.DeleteMin(), heap.Reverse(v))
+	}
+}
+
 func (suite *PairingHeapTestSuite) TestMeld() {
 	assert.NotNil(suite.T(), suite.heap.Meld(nil))
 
@@ -194,4 +227,332 @@ func (suite *PairingHeapTestSuite) TestDelete() {
 
 func Int(value int) heap.Integer {
 	return heap.Integer(value)
-}
\ No newline at end of file
+}
+
+func TestHeapOrdered(t *testing.T) {
+	h := NewOrdered[int]()
+	assert.Equal(t, h.FindMin(), 0)
+	for _, v := range rnd.Perm(100) {
+		h.Insert(v)
+	}
+	h.Adjust(50, -1)
+	assert.Equal(t, h.Delete(99), 99)
+	assert.Equal(t, h.FindMin(), -1)
+
+	other := NewOrdered[int]()
+	other.Insert(-2)
+	h.Meld(other)
+	assert.True(t, other.IsEmpty())
+
+	var got []int
+	for !h.IsEmpty() {
+		got = append(got, h.DeleteMin())
+	}
+	assert.Len(t, got, 100)
+	assert.Equal(t, got[:3], []int{-2, -1, 0})
+	for i := 0; i < len(got)-1; i++ {
+		assert.True(t, got[i] < got[i+1])
+	}
+}
+
+func TestHeapFunc(t *testing.T) {
+	h := NewFunc(func(a, b string) int { return len(a) - len(b) })
+	h.Insert("ccc")
+	h.Insert("a")
+	h.Insert("bb")
+
+	assert.Equal(t, h.DeleteMin(), "a")
+	assert.Equal(t, h.DeleteMin(), "bb")
+	assert.Equal(t, h.DeleteMin(), "ccc")
+	assert.Equal(t, h.DeleteMin(), "")
+}
+
+func TestHandle(t *testing.T) {
+	h := NewOrdered[int]()
+	handles := make([]*Handle[int], 10)
+	for i := range handles {
+		handles[i] = h.InsertHandle(i * 10)
+	}
+
+	h.DecreaseKey(handles[9], -5)
+	assert.Equal(t, h.FindMin(), -5)
+	h.IncreaseKey(handles[9], 95)
+	h.IncreaseKey(handles[0], 55)
+	assert.Equal(t, h.Remove(handles[5]), 50)
+	assert.Equal(t, handles[0].Item(), 55)
+	assert.Panics(t, func() { h.DecreaseKey(handles[1], 20) })
+
+	var got []int
+	for !h.IsEmpty() {
+		got = append(got, h.DeleteMin())
+	}
+	assert.Equal(t, got, []int{10, 20, 30, 40, 55, 60, 70, 80, 95})
+}
+
+func TestExtended(t *testing.T) {
+	heaptest.RunExtendedTests(t, func() heap.Extended { return New() })
+}
+
+var pairings = map[string]Pairing{
+	"multi_pass":    MultiPass,
+	"back_to_front": BackToFront,
+	"auxiliary":     Auxiliary,
+}
+
+func TestExtendedPairings(t *testing.T) {
+	for name, pairing := range pairings {
+		t.Run(name, func(t *testing.T) {
+			heaptest.RunExtendedTests(t, func() heap.Extended { return New(WithPairing(pairing)) })
+		})
+	}
+}
+
+func FuzzHeap(f *testing.F) {
+	heaptest.AddSeeds(f)
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		heaptest.FuzzExtended(t, func() heap.Extended { return New() }, ops)
+		for _, pairing := range pairings {
+			heaptest.FuzzExtended(t, func() heap.Extended { return New(WithPairing(pairing)) }, ops)
+			heaptest.FuzzHandles(t, func() heaptest.HandleHeap[*Handle[int]] { return NewOrdered[int](WithPairing(pairing)) }, ops)
+		}
+	})
+}
+
+func TestPairingHandles(t *testing.T) {
+	for name, pairing := range pairings {
+		t.Run(name, func(t *testing.T) {
+			h := NewOrdered[int](WithPairing(pairing))
+			assert.Equal(t, pairing, h.Pairing())
+			handles := make([]*Handle[int], 100)
+			for i := range handles {
+				handles[i] = h.InsertHandle(i * 10)
+			}
+			assert.Equal(t, 0, h.DeleteMin())
+			for i := 99; i > 0; i -= 2 {
+				h.DecreaseKey(handles[i], handles[i].Item()-15)
+			}
+			assert.Equal(t, 40, h.Remove(handles[4]))
+			h.IncreaseKey(handles[2], 1000)
+			assert.NoError(t, h.Validate())
+
+			var want []int
+			for _, hd := range handles[1:] {
+				if hd != handles[4] {
+					want = append(want, hd.Item())
+				}
+			}
+			sort.Ints(want)
+			var got []int
+			for !h.IsEmpty() {
+				got = append(got, h.DeleteMin())
+			}
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestAuxiliaryRemoveTiedRoot(t *testing.T) {
+	h := NewOrdered[int](WithPairing(Auxiliary))
+	root := h.InsertHandle(1)
+	h.Insert(1)
+	h.Insert(2)
+	h.IncreaseKey(root, 3)
+	assert.NoError(t, h.Validate())
+	assert.Equal(t, []int{1, 2, 3}, []int{h.DeleteMin(), h.DeleteMin(), h.DeleteMin()})
+}
+
+func TestMeldPairings(t *testing.T) {
+	h := NewOrdered[int]()
+	o := NewOrdered[int](WithPairing(Auxiliary))
+	for i := 10; i > 0; i-- {
+		h.Insert(i * 2)
+		o.Insert(i*2 + 1)
+	}
+	h.Meld(o)
+	assert.True(t, o.IsEmpty())
+	assert.NoError(t, h.Validate())
+	for i := 2; i <= 21; i++ {
+		assert.Equal(t, i, h.DeleteMin())
+	}
+}
+
+func TestValidate(t *testing.T) {
+	h := NewOrdered[int]()
+	for _, number := range []int{5, 3, 8, 1, 9, 4} {
+		h.Insert(number)
+	}
+	if err := h.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	h.root.child.item = -1
+	if h.Validate() == nil {
+		t.Fail()
+	}
+}
+
+func TestValidateAuxiliary(t *testing.T) {
+	h := NewOrdered[int](WithPairing(Auxiliary))
+	for _, number := range []int{5, 3, 8, 1, 9, 4} {
+		h.Insert(number)
+	}
+	assert.NotNil(t, h.aux)
+	assert.NoError(t, h.Validate())
+	h.aux.item = 0
+	assert.Error(t, h.Validate())
+}
+
+func TestIterable(t *testing.T) {
+	heaptest.RunIterableTests(t, func() heaptest.IterableHeap { return NewOrdered[int]() })
+	for name, pairing := range pairings {
+		t.Run(name, func(t *testing.T) {
+			heaptest.RunIterableTests(t, func() heaptest.IterableHeap { return NewOrdered[int](WithPairing(pairing)) })
+		})
+	}
+}
+
+func TestInsertAll(t *testing.T) {
+	heaptest.RunBulkTests(t, func() heaptest.BulkHeap { return NewOrdered[int]() })
+	for name, pairing := range pairings {
+		t.Run(name, func(t *testing.T) {
+			heaptest.RunBulkTests(t, func() heaptest.BulkHeap { return NewOrdered[int](WithPairing(pairing)) })
+		})
+	}
+}
+
+func TestFromSlice(t *testing.T) {
+	h := FromSliceOrdered([]int{5, 3, 8, 1}, WithPairing(Auxiliary))
+	assert.Equal(t, Auxiliary, h.Pairing())
+	assert.Equal(t, []int{1, 3, 5, 8}, slices.Collect(h.Sorted()))
+	p := FromSlice([]heap.Item{Int(2), Int(1)})
+	assert.Equal(t, Int(1), p.DeleteMin())
+	assert.Equal(t, Int(2), p.DeleteMin())
+}
+
+func TestClone(t *testing.T) {
+	heaptest.RunCloneTests(t, func() *Heap[int] { return NewOrdered[int]() }, (*Heap[int]).Clone)
+	for name, pairing := range pairings {
+		t.Run(name, func(t *testing.T) {
+			heaptest.RunCloneTests(t, func() *Heap[int] { return NewOrdered[int](WithPairing(pairing)) }, (*Heap[int]).Clone)
+		})
+	}
+	p := New(WithPairing(Auxiliary))
+	p.Insert(Int(2))
+	c := p.Clone()
+	p.Insert(Int(1))
+	assert.Equal(t, Auxiliary, c.Pairing())
+	assert.Equal(t, Int(2), c.FindMin())
+}
+
+func TestSnapshot(t *testing.T) {
+	test := func(t *testing.T, opts ...Option) {
+		heaptest.RunSnapshotTests[*Heap[int], *Handle[int]](t, func() *Heap[int] { return NewOrdered[int](opts...) })
+
+		h := FromSliceOrdered([]int{1, 2, 3, 4, 5, 6, 7, 8}, opts...)
+		s := h.Snapshot()
+		assert.Same(t, h.root, s.root)
+		assert.Equal(t, h.Pairing(), s.Pairing())
+		// deleting, adjusting and melding copy the shared nodes
+		h.Delete(6)
+		h.Adjust(3, 9)
+		h.Meld(FromSliceOrdered([]int{0, 10}, opts...))
+		h.DeleteMin()
+		assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8}, slices.Collect(s.Sorted()))
+		assert.Equal(t, []int{1, 2, 4, 5, 7, 8, 9, 10}, slices.Collect(h.Sorted()))
+		assert.NoError(t, h.Validate())
+		assert.NoError(t, s.Validate())
+	}
+	test(t)
+	for name, pairing := range pairings {
+		t.Run(name, func(t *testing.T) { test(t, WithPairing(pairing)) })
+	}
+	p := New()
+	p.Insert(Int(2))
+	s := p.Snapshot()
+	p.Insert(Int(1))
+	assert.Equal(t, Int(2), s.FindMin())
+}
+
+func TestEncoding(t *testing.T) {
+	heaptest.RunEncodingTests(t, func() *Heap[int] { return NewOrdered[int]() }, (*Heap[int]).MarshalBinary)
+	for name, pairing := range pairings {
+		t.Run(name, func(t *testing.T) {
+			factory := func() *Heap[int] { return NewOrdered[int](WithPairing(pairing)) }
+			heaptest.RunEncodingTests(t, factory, (*Heap[int]).MarshalBinary)
+			heaptest.RunEncodingTests(t, factory, (*Heap[int]).MarshalShape)
+		})
+	}
+}
+
+func TestUnmarshalShape(t *testing.T) {
+	compares := 0
+	compare := func(a, b int) int {
+		compares++
+		return a - b
+	}
+	h := NewFunc(compare, WithPairing(Auxiliary))
+	for i := range 100 {
+		h.Insert(i * 7 % 100)
+	}
+	data, err := h.MarshalShape()
+	assert.NoError(t, err)
+
+	loaded := NewFunc(compare, WithPairing(Auxiliary))
+	compares = 0
+	assert.NoError(t, loaded.UnmarshalBinary(data))
+	// heapdebug builds validate the heap, which compares items
+	if !heap.Debug {
+		assert.Equal(t, 0, compares)
+	}
+	assert.NoError(t, loaded.Validate())
+	assert.Equal(t, slices.Collect(h.Sorted()), slices.Collect(loaded.Sorted()))
+
+	// the auxiliary list is only kept by Auxiliary pairing
+	twoPass := NewFunc(compare)
+	assert.NoError(t, twoPass.UnmarshalBinary(data))
+	assert.Nil(t, twoPass.aux)
+	assert.NoError(t, twoPass.Validate())
+	assert.Equal(t, 100, twoPass.Len())
+
+	p := New()
+	p.Insert(Int(2))
+	p.Insert(Int(1))
+	data, err = p.MarshalShape()
+	assert.NoError(t, err)
+	q := New()
+	assert.NoError(t, q.UnmarshalBinary(data))
+	assert.Equal(t, Int(1), q.DeleteMin())
+}
+
+// ordered checks that the children of n are not less than n.
+func ordered(n *heap.TreeNode[int]) error {
+	for _, c := range n.Children {
+		if c.Item < n.Item {
+			return fmt.Errorf("child %d is less than its parent", c.Item)
+		}
+	}
+	return nil
+}
+
+func TestJSON(t *testing.T) {
+	heaptest.RunJSONTests(t, func() *Heap[int] { return NewOrdered[int]() }, ordered)
+	for name, pairing := range pairings {
+		t.Run(name, func(t *testing.T) {
+			heaptest.RunJSONTests(t, func() *Heap[int] { return NewOrdered[int](WithPairing(pairing)) }, ordered)
+		})
+	}
+
+	p := New()
+	p.Insert(heap.Integer(2))
+	p.Insert(heap.Integer(1))
+	data, err := json.Marshal(p)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"items":[{"type":"Integer","value":1},{"type":"Integer","value":2}]}`, string(data))
+	q := New()
+	assert.NoError(t, json.Unmarshal(data, q))
+	assert.Equal(t, heap.Integer(1), q.DeleteMin())
+
+	data, err = json.Marshal(heap.ViewOf[heap.Item](p, true))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"items":[{"type":"Integer","value":1},{"type":"Integer","value":2}],
+		"tree":[{"item":{"type":"Integer","value":1},"children":[{"item":{"type":"Integer","value":2}}]}]}`, string(data))
+}
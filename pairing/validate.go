@@ -0,0 +1,75 @@
+package pairing
+
+import (
+	"errors"
+	"fmt"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// Heap implements the Validator interface
+var _ heap.Validator = (*Heap[int])(nil)
+
+// Validate checks that every child is linked back to its parent or previous
+// sibling, unless the Heap has snapshots, and is not smaller than its
+// parent, that no root of the auxiliary list is smaller than root and that
+// the Heap holds Len items.
+// The complexity is O(n).
+func (p *Heap[T]) Validate() error {
+	if p.root == nil {
+		if p.size != 0 {
+			return fmt.Errorf("pairing: empty heap has size %d", p.size)
+		}
+		if p.aux != nil {
+			return errors.New("pairing: empty heap has auxiliary roots")
+		}
+		return nil
+	}
+	if p.root.prev != nil && p.owner == nil || p.root.sibling != nil {
+		return errors.New("pairing: root has a parent or siblings")
+	}
+	if p.aux != nil && p.pairing != Auxiliary {
+		return errors.New("pairing: heap without auxiliary pairing has auxiliary roots")
+	}
+	count := 0
+	if err := p.validate(p.root, &count); err != nil {
+		return err
+	}
+	var prev *node[T]
+	for n := p.aux; n != nil; n = n.sibling {
+		if n.prev != prev && p.owner == nil {
+			return fmt.Errorf("pairing: auxiliary root %v does not point to the previous root", n.item)
+		}
+		if p.compare(n.item, p.root.item) < 0 {
+			return fmt.Errorf("pairing: auxiliary root %v is smaller than the root %v", n.item, p.root.item)
+		}
+		if err := p.validate(n, &count); err != nil {
+			return err
+		}
+		prev = n
+	}
+	if count != p.size {
+		return fmt.Errorf("pairing: heap has %d nodes but size %d", count, p.size)
+	}
+	return nil
+}
+
+func (p *Heap[T]) validate(n *node[T], count *int) error {
+	if *count++; *count > p.size {
+		return fmt.Errorf("pairing: more than %d nodes or a cycle at %v", p.size, n.item)
+	}
+	prev := n
+	for c := n.child; c != nil; c = c.sibling {
+		if c.prev != prev && p.owner == nil {
+			return fmt.Errorf("pairing: child %v of %v does not point to its parent or previous sibling", c.item, n.item)
+		}
+		if p.compare(c.item, n.item) < 0 {
+			return fmt.Errorf("pairing: child %v is smaller than its parent %v", c.item, n.item)
+		}
+		if err := p.validate(c, count); err != nil {
+			return err
+		}
+		prev = c
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package persistent
+
+import (
+	"encoding"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+var (
+	// Leftist implements the BinaryMarshaler and BinaryUnmarshaler
+	// interfaces
+	_ encoding.BinaryMarshaler   = Leftist[int]{}
+	_ encoding.BinaryUnmarshaler = (*Leftist[int])(nil)
+	// Pairing implements the BinaryMarshaler and BinaryUnmarshaler
+	// interfaces
+	_ encoding.BinaryMarshaler   = Pairing[int]{}
+	_ encoding.BinaryUnmarshaler = (*Pairing[int])(nil)
+	// SkewBinomial implements the BinaryMarshaler and BinaryUnmarshaler
+	// interfaces
+	_ encoding.BinaryMarshaler   = SkewBinomial[int]{}
+	_ encoding.BinaryUnmarshaler = (*SkewBinomial[int])(nil)
+)
+
+// insertAll returns a version of h with items added.
+func insertAll[T any, H Heap[T, H]](h H, items []T) H {
+	for _, item := range items {
+		h = h.Insert(item)
+	}
+	return h
+}
+
+// MarshalBinary encodes the items of the heap in preorder with the
+// go_heaps.Codec for T.
+// The complexity is O(n).
+func (h Leftist[T]) MarshalBinary() ([]byte, error) {
+	return heap.MarshalItems(h.All())
+}
+
+// UnmarshalBinary replaces h with an empty version of it that the items
+// encoded in data are inserted into. Other versions are not affected.
+// The complexity is O(n log n).
+func (h *Leftist[T]) UnmarshalBinary(data []byte) error {
+	items, err := heap.UnmarshalItems[T](data)
+	if err != nil {
+		return err
+	}
+	*h = insertAll(Leftist[T]{cmp: h.cmp}, items)
+	return nil
+}
+
+// MarshalBinary encodes the items of the heap in preorder with the
+// go_heaps.Codec for T.
+// The complexity is O(n).
+func (h Pairing[T]) MarshalBinary() ([]byte, error) {
+	return heap.MarshalItems(h.All())
+}
+
+// UnmarshalBinary replaces h with an empty version of it that the items
+// encoded in data are inserted into. Other versions are not affected.
+// The complexity is O(n).
+func (h *Pairing[T]) UnmarshalBinary(data []byte) error {
+	items, err := heap.UnmarshalItems[T](data)
+	if err != nil {
+		return err
+	}
+	*h = insertAll(Pairing[T]{cmp: h.cmp}, items)
+	return nil
+}
+
+// MarshalBinary encodes the items of the heap in no particular order with
+// the go_heaps.Codec for T.
+// The complexity is O(n).
+func (h SkewBinomial[T]) MarshalBinary() ([]byte, error) {
+	return heap.MarshalItems(h.All())
+}
+
+// UnmarshalBinary replaces h with an empty version of it that the items
+// encoded in data are inserted into. Other versions are not affected.
+// The complexity is O(n).
+func (h *SkewBinomial[T]) UnmarshalBinary(data []byte) error {
+	items, err := heap.UnmarshalItems[T](data)
+	if err != nil {
+		return err
+	}
+	*h = insertAll(SkewBinomial[T]{cmp: h.cmp}, items)
+	return nil
+}
@@ -0,0 +1,143 @@
+package persistent
+
+import (
+	"iter"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+var (
+	// Leftist implements the Iterable interface
+	_ heap.Iterable[int] = Leftist[int]{}
+	// Pairing implements the Iterable interface
+	_ heap.Iterable[int] = Pairing[int]{}
+	// SkewBinomial implements the Iterable interface
+	_ heap.Iterable[int] = SkewBinomial[int]{}
+)
+
+// ascend calls iter on the items of h in ascending order until iter
+// returns false. It deletes them from successive versions of h, which
+// leaves h itself unchanged.
+func ascend[T any, H Heap[T, H]](h H, iter func(item T) bool) {
+	for !h.IsEmpty() {
+		var item T
+		item, h = h.DeleteMin()
+		if !iter(item) {
+			return
+		}
+	}
+}
+
+// Do calls iter on every item in preorder until iter returns false.
+// The complexity is O(n).
+func (h Leftist[T]) Do(iter func(item T) bool) {
+	heap.DoForest(h.roots, func(n *leftistNode[T], visit func(c *leftistNode[T])) {
+		if n.left != nil {
+			visit(n.left)
+		}
+		if n.right != nil {
+			visit(n.right)
+		}
+	}, func(n *leftistNode[T]) T { return n.item }, iter)
+}
+
+// Ascend calls iter on every item in ascending order until iter returns
+// false. It deletes the items from successive versions of the heap.
+// The complexity is O(k log n) to visit k items.
+func (h Leftist[T]) Ascend(iter func(item T) bool) {
+	ascend(h, iter)
+}
+
+// All returns an iterator over the items in preorder.
+func (h Leftist[T]) All() iter.Seq[T] {
+	return h.Do
+}
+
+// Sorted returns an iterator over the items in ascending order.
+func (h Leftist[T]) Sorted() iter.Seq[T] {
+	return h.Ascend
+}
+
+func (h Leftist[T]) roots(visit func(n *leftistNode[T])) {
+	if h.root != nil {
+		visit(h.root)
+	}
+}
+
+// Do calls iter on every item in preorder until iter returns false.
+// The complexity is O(n).
+func (h Pairing[T]) Do(iter func(item T) bool) {
+	heap.DoForest(h.roots, func(n *pairingNode[T], visit func(c *pairingNode[T])) {
+		for l := n.children; l != nil; l = l.tail {
+			visit(l.head)
+		}
+	}, func(n *pairingNode[T]) T { return n.item }, iter)
+}
+
+// Ascend calls iter on every item in ascending order until iter returns
+// false. It deletes the items from successive versions of the heap.
+// The complexity is O(k log n) amortized to visit k items.
+func (h Pairing[T]) Ascend(iter func(item T) bool) {
+	ascend(h, iter)
+}
+
+// All returns an iterator over the items in preorder.
+func (h Pairing[T]) All() iter.Seq[T] {
+	return h.Do
+}
+
+// Sorted returns an iterator over the items in ascending order.
+func (h Pairing[T]) Sorted() iter.Seq[T] {
+	return h.Ascend
+}
+
+func (h Pairing[T]) roots(visit func(n *pairingNode[T])) {
+	if h.root != nil {
+		visit(h.root)
+	}
+}
+
+// Do calls iter on every item in no particular order until iter returns
+// false.
+// The complexity is O(n).
+func (h SkewBinomial[T]) Do(iter func(item T) bool) {
+	heap.DoForest(h.roots, func(b *bootRoot[T], visit func(c *bootRoot[T])) {
+		for l := b.heaps; l != nil; l = l.tail {
+			doTree(l.head, visit)
+		}
+	}, func(b *bootRoot[T]) T { return b.item }, iter)
+}
+
+// doTree calls visit on the root and the extra items of every tree of t.
+func doTree[E any](t *skewTree[E], visit func(e E)) {
+	visit(t.root)
+	for l := t.extra; l != nil; l = l.tail {
+		visit(l.head)
+	}
+	for l := t.children; l != nil; l = l.tail {
+		doTree(l.head, visit)
+	}
+}
+
+// Ascend calls iter on every item in ascending order until iter returns
+// false. It deletes the items from successive versions of the heap.
+// The complexity is O(k log n) to visit k items.
+func (h SkewBinomial[T]) Ascend(iter func(item T) bool) {
+	ascend(h, iter)
+}
+
+// All returns an iterator over the items in no particular order.
+func (h SkewBinomial[T]) All() iter.Seq[T] {
+	return h.Do
+}
+
+// Sorted returns an iterator over the items in ascending order.
+func (h SkewBinomial[T]) Sorted() iter.Seq[T] {
+	return h.Ascend
+}
+
+func (h SkewBinomial[T]) roots(visit func(b *bootRoot[T])) {
+	if h.root != nil {
+		visit(h.root)
+	}
+}
@@ -0,0 +1,119 @@
+package persistent
+
+import (
+	"encoding/json"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+var (
+	// Leftist implements the Marshaler and Unmarshaler interfaces
+	_ json.Marshaler   = Leftist[int]{}
+	_ json.Unmarshaler = (*Leftist[int])(nil)
+	// Pairing implements the Marshaler and Unmarshaler interfaces
+	_ json.Marshaler   = Pairing[int]{}
+	_ json.Unmarshaler = (*Pairing[int])(nil)
+	// SkewBinomial implements the Marshaler and Unmarshaler interfaces
+	_ json.Marshaler   = SkewBinomial[int]{}
+	_ json.Unmarshaler = (*SkewBinomial[int])(nil)
+	// Leftist, Pairing and SkewBinomial have a tree view
+	_ heap.TreeViewer[int] = Leftist[int]{}
+	_ heap.TreeViewer[int] = Pairing[int]{}
+	_ heap.TreeViewer[int] = SkewBinomial[int]{}
+)
+
+// MarshalJSON encodes the items of the heap in preorder as a
+// go_heaps.JSONView without the tree view.
+// The complexity is O(n).
+func (h Leftist[T]) MarshalJSON() ([]byte, error) {
+	return heap.ViewOf[T](h, false).MarshalJSON()
+}
+
+// UnmarshalJSON replaces h with an empty version of it that the items of
+// the go_heaps.JSONView encoded in data are inserted into. Other versions
+// are not affected.
+// The complexity is O(n log n).
+func (h *Leftist[T]) UnmarshalJSON(data []byte) error {
+	var v heap.JSONView[T]
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*h = insertAll(Leftist[T]{cmp: h.cmp}, v.Items)
+	return nil
+}
+
+// Tree returns the tree of the heap. A node that has a child lists both its
+// left and right child, null where missing. Every node has its "s" value.
+// The complexity is O(n).
+func (h Leftist[T]) Tree() []*heap.TreeNode[T] {
+	return heap.TreeOf(h.roots, func(n *leftistNode[T], visit func(c *leftistNode[T])) {
+		if n.left != nil || n.right != nil {
+			visit(n.left)
+			visit(n.right)
+		}
+	}, func(n *leftistNode[T]) T { return n.item }, func(n *leftistNode[T]) []heap.Attr {
+		return []heap.Attr{{Name: "s", Value: n.s}}
+	})
+}
+
+// MarshalJSON encodes the items of the heap in preorder as a
+// go_heaps.JSONView without the tree view.
+// The complexity is O(n).
+func (h Pairing[T]) MarshalJSON() ([]byte, error) {
+	return heap.ViewOf[T](h, false).MarshalJSON()
+}
+
+// UnmarshalJSON replaces h with an empty version of it that the items of
+// the go_heaps.JSONView encoded in data are inserted into. Other versions
+// are not affected.
+// The complexity is O(n).
+func (h *Pairing[T]) UnmarshalJSON(data []byte) error {
+	var v heap.JSONView[T]
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*h = insertAll(Pairing[T]{cmp: h.cmp}, v.Items)
+	return nil
+}
+
+// Tree returns the tree of the heap. The nodes have no attributes.
+// The complexity is O(n).
+func (h Pairing[T]) Tree() []*heap.TreeNode[T] {
+	return heap.TreeOf(h.roots, func(n *pairingNode[T], visit func(c *pairingNode[T])) {
+		for l := n.children; l != nil; l = l.tail {
+			visit(l.head)
+		}
+	}, func(n *pairingNode[T]) T { return n.item }, nil)
+}
+
+// MarshalJSON encodes the items of the heap in no particular order as a
+// go_heaps.JSONView without the tree view.
+// The complexity is O(n).
+func (h SkewBinomial[T]) MarshalJSON() ([]byte, error) {
+	return heap.ViewOf[T](h, false).MarshalJSON()
+}
+
+// UnmarshalJSON replaces h with an empty version of it that the items of
+// the go_heaps.JSONView encoded in data are inserted into. Other versions
+// are not affected.
+// The complexity is O(n).
+func (h *SkewBinomial[T]) UnmarshalJSON(data []byte) error {
+	var v heap.JSONView[T]
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*h = insertAll(SkewBinomial[T]{cmp: h.cmp}, v.Items)
+	return nil
+}
+
+// Tree returns the tree of bootstrapped roots of the heap: the children of
+// a root are the roots of the heap it holds, in the order of its skew
+// binomial trees. The nodes have no attributes.
+// The complexity is O(n).
+func (h SkewBinomial[T]) Tree() []*heap.TreeNode[T] {
+	return heap.TreeOf(h.roots, func(b *bootRoot[T], visit func(c *bootRoot[T])) {
+		for l := b.heaps; l != nil; l = l.tail {
+			doTree(l.head, visit)
+		}
+	}, func(b *bootRoot[T]) T { return b.item }, nil)
+}
@@ -0,0 +1,130 @@
+package persistent
+
+import (
+	"cmp"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// Leftist implements the Heap interface
+var _ Heap[int, Leftist[int]] = Leftist[int]{}
+
+type leftistNode[T any] struct {
+	item T
+	// s is the length of the right spine of the subtree, its s-value
+	s           int
+	left, right *leftistNode[T]
+}
+
+func svalue[T any](n *leftistNode[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.s
+}
+
+// Leftist is a persistent leftist heap. Like the leftist package it merges
+// along the right spines, which are O(log n) long, but copies the nodes on
+// them instead of modifying them.
+// The zero value for Leftist is an empty heap ordered by go_heaps.Compare.
+type Leftist[T any] struct {
+	root *leftistNode[T]
+	size int
+	cmp  func(a, b T) int
+}
+
+// NewLeftistFunc returns an empty Leftist heap ordered by compare.
+func NewLeftistFunc[T any](compare func(a, b T) int) Leftist[T] {
+	return Leftist[T]{cmp: compare}
+}
+
+// NewLeftistOrdered returns an empty Leftist heap of ordered values.
+func NewLeftistOrdered[T cmp.Ordered]() Leftist[T] {
+	return NewLeftistFunc(cmp.Compare[T])
+}
+
+func (h Leftist[T]) compare(a, b T) int {
+	if h.cmp == nil {
+		return heap.Compare(a, b)
+	}
+	return h.cmp(a, b)
+}
+
+// Insert returns a version of the heap with item added.
+// The complexity is O(log n).
+func (h Leftist[T]) Insert(item T) Leftist[T] {
+	h.root = h.merge(h.root, &leftistNode[T]{item: item, s: 1})
+	h.size++
+	heap.DebugValidate(h)
+	return h
+}
+
+// DeleteMin returns the smallest item and a version of the heap without it.
+// It returns the zero value of T and the heap itself if the heap is empty.
+// The complexity is O(log n).
+func (h Leftist[T]) DeleteMin() (T, Leftist[T]) {
+	if h.root == nil {
+		var zero T
+		return zero, h
+	}
+	item := h.root.item
+	h.root = h.merge(h.root.left, h.root.right)
+	h.size--
+	heap.DebugValidate(h)
+	return item, h
+}
+
+// FindMin returns the smallest item, or the zero value of T if the heap is
+// empty.
+// The complexity is O(1).
+func (h Leftist[T]) FindMin() T {
+	if h.root == nil {
+		var zero T
+		return zero
+	}
+	return h.root.item
+}
+
+// Meld returns a version of the heap holding the items of both heaps, which
+// must have the same order. Melding into an empty heap, such as the zero
+// value, returns other with its order.
+// The complexity is O(log n + log m).
+func (h Leftist[T]) Meld(other Leftist[T]) Leftist[T] {
+	if h.root == nil {
+		return other
+	}
+	h.root = h.merge(h.root, other.root)
+	h.size += other.size
+	heap.DebugValidate(h)
+	return h
+}
+
+// Len returns the number of items in the heap.
+// The complexity is O(1).
+func (h Leftist[T]) Len() int {
+	return h.size
+}
+
+// IsEmpty reports whether the heap has no items.
+func (h Leftist[T]) IsEmpty() bool {
+	return h.root == nil
+}
+
+// merge returns a tree of the items of a and b. It copies the nodes on the
+// right spines it walks and swaps children where the s-values require it.
+func (h Leftist[T]) merge(a, b *leftistNode[T]) *leftistNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.compare(b.item, a.item) < 0 {
+		a, b = b, a
+	}
+	left, right := a.left, h.merge(a.right, b)
+	if svalue(left) < svalue(right) {
+		left, right = right, left
+	}
+	return &leftistNode[T]{item: a.item, s: svalue(right) + 1, left: left, right: right}
+}
@@ -0,0 +1,139 @@
+package persistent
+
+import (
+	"cmp"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// Pairing implements the Heap interface
+var _ Heap[int, Pairing[int]] = Pairing[int]{}
+
+type pairingNode[T any] struct {
+	item     T
+	children *list[*pairingNode[T]]
+}
+
+// Pairing is a persistent pairing heap. Like the pairing package it links
+// the children of the removed root with two passes, but builds new nodes
+// instead of modifying them. Insert and Meld link two trees in O(1).
+// The amortized bounds of DeleteMin assume that every version is deleted
+// from at most once; a version with n children at its root costs O(n)
+// every time it is.
+// The zero value for Pairing is an empty heap ordered by go_heaps.Compare.
+type Pairing[T any] struct {
+	root *pairingNode[T]
+	size int
+	cmp  func(a, b T) int
+}
+
+// NewPairingFunc returns an empty Pairing heap ordered by compare.
+func NewPairingFunc[T any](compare func(a, b T) int) Pairing[T] {
+	return Pairing[T]{cmp: compare}
+}
+
+// NewPairingOrdered returns an empty Pairing heap of ordered values.
+func NewPairingOrdered[T cmp.Ordered]() Pairing[T] {
+	return NewPairingFunc(cmp.Compare[T])
+}
+
+func (h Pairing[T]) compare(a, b T) int {
+	if h.cmp == nil {
+		return heap.Compare(a, b)
+	}
+	return h.cmp(a, b)
+}
+
+// Insert returns a version of the heap with item added.
+// The complexity is O(1).
+func (h Pairing[T]) Insert(item T) Pairing[T] {
+	h.root = h.link(h.root, &pairingNode[T]{item: item})
+	h.size++
+	heap.DebugValidate(h)
+	return h
+}
+
+// DeleteMin returns the smallest item and a version of the heap without it.
+// It returns the zero value of T and the heap itself if the heap is empty.
+// The complexity is O(log n) amortized.
+func (h Pairing[T]) DeleteMin() (T, Pairing[T]) {
+	if h.root == nil {
+		var zero T
+		return zero, h
+	}
+	item := h.root.item
+	h.root = h.twoPass(h.root.children)
+	h.size--
+	heap.DebugValidate(h)
+	return item, h
+}
+
+// FindMin returns the smallest item, or the zero value of T if the heap is
+// empty.
+// The complexity is O(1).
+func (h Pairing[T]) FindMin() T {
+	if h.root == nil {
+		var zero T
+		return zero
+	}
+	return h.root.item
+}
+
+// Meld returns a version of the heap holding the items of both heaps, which
+// must have the same order. Melding into an empty heap, such as the zero
+// value, returns other with its order.
+// The complexity is O(1).
+func (h Pairing[T]) Meld(other Pairing[T]) Pairing[T] {
+	if h.root == nil {
+		return other
+	}
+	h.root = h.link(h.root, other.root)
+	h.size += other.size
+	heap.DebugValidate(h)
+	return h
+}
+
+// Len returns the number of items in the heap.
+// The complexity is O(1).
+func (h Pairing[T]) Len() int {
+	return h.size
+}
+
+// IsEmpty reports whether the heap has no items.
+func (h Pairing[T]) IsEmpty() bool {
+	return h.root == nil
+}
+
+// link returns a new node holding the smaller root of a and b, with the
+// other tree as its first child. a wins ties.
+func (h Pairing[T]) link(a, b *pairingNode[T]) *pairingNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.compare(b.item, a.item) < 0 {
+		a, b = b, a
+	}
+	return &pairingNode[T]{item: a.item, children: push(b, a.children)}
+}
+
+// twoPass links the trees of children in pairs from front to back and then
+// links the pairs into one tree from back to front.
+func (h Pairing[T]) twoPass(children *list[*pairingNode[T]]) *pairingNode[T] {
+	var pairs []*pairingNode[T]
+	for l := children; l != nil; l = l.tail {
+		t := l.head
+		if l.tail != nil {
+			l = l.tail
+			t = h.link(t, l.head)
+		}
+		pairs = append(pairs, t)
+	}
+	var root *pairingNode[T]
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = h.link(pairs[i], root)
+	}
+	return root
+}
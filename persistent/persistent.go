@@ -0,0 +1,66 @@
+// Package persistent implements purely functional heaps. Insert, DeleteMin
+// and Meld leave the heap they are called on unchanged and return a new
+// version of it that shares every unchanged node with the old one, so all
+// versions stay valid. Versions are never modified and can be used from
+// several goroutines at once.
+//
+// Leftist is a leftist heap, SkewBinomial a skew binomial heap with
+// Brodal and Okasaki's bootstrapping for O(1) Insert and Meld in the worst
+// case, and Pairing a pairing heap.
+//
+// Reference: Chris Okasaki, Purely Functional Data Structures, 1998
+// Reference: Gerth Stølting Brodal and Chris Okasaki, Optimal purely
+// functional priority queues, 1996
+package persistent
+
+// Heap is implemented by the heaps of this package. H is the type of the
+// heap itself, of which the operations return new versions.
+type Heap[T, H any] interface {
+	// Insert returns a version of the heap with item added
+	Insert(item T) H
+
+	// DeleteMin returns the smallest item and a version of the heap
+	// without it
+	DeleteMin() (T, H)
+
+	// FindMin returns the smallest item
+	FindMin() T
+
+	// Meld returns a version of the heap holding the items of both heaps
+	Meld(other H) H
+
+	// Len returns the number of items in the heap
+	Len() int
+
+	// IsEmpty reports whether the heap has no items
+	IsEmpty() bool
+}
+
+// list is an immutable singly linked list. The nil list is empty.
+type list[E any] struct {
+	head E
+	tail *list[E]
+}
+
+// push returns the list head followed by tail.
+func push[E any](head E, tail *list[E]) *list[E] {
+	return &list[E]{head: head, tail: tail}
+}
+
+// reverse returns the items of l in reverse order.
+func reverse[E any](l *list[E]) *list[E] {
+	var r *list[E]
+	for ; l != nil; l = l.tail {
+		r = push(l.head, r)
+	}
+	return r
+}
+
+// length returns the number of items in l.
+func length[E any](l *list[E]) int {
+	n := 0
+	for ; l != nil; l = l.tail {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,313 @@
+package persistent
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	heap "github.com/theodesp/go-heaps"
+	"github.com/theodesp/go-heaps/heaptest"
+)
+
+// testedHeap is a heap of ints with everything the tests check.
+type testedHeap[H any] interface {
+	Heap[int, H]
+	heap.Iterable[int]
+	heap.Validator
+}
+
+// checkVersions applies the operations that next chooses to random
+// versions of the heap, starting at empty, as long as more returns true.
+// Every operation makes a new version that is checked against a sorted
+// slice of its items, and all versions are checked again at the end, when
+// later operations could have changed them if they shared nodes wrongly.
+func checkVersions[H testedHeap[H]](t *testing.T, empty H, next func(n int) int, more func() bool) {
+	t.Helper()
+	versions := []H{empty}
+	items := [][]int{nil}
+	for more() {
+		i := next(len(versions))
+		h, want := versions[i], slices.Clone(items[i])
+		switch next(4) {
+		case 0, 1:
+			v := next(50)
+			h = h.Insert(v)
+			want = append(want, v)
+			slices.Sort(want)
+		case 2:
+			var v int
+			v, h = h.DeleteMin()
+			if len(want) > 0 {
+				if v != want[0] {
+					t.Fatalf("version %d: DeleteMin() = %d, want %d", i, v, want[0])
+				}
+				want = want[1:]
+			}
+		default:
+			j := next(len(versions))
+			h = h.Meld(versions[j])
+			want = append(want, items[j]...)
+			slices.Sort(want)
+		}
+		if err := h.Validate(); err != nil {
+			t.Fatalf("version %d: %v", len(versions), err)
+		}
+		versions = append(versions, h)
+		items = append(items, want)
+	}
+	for i, h := range versions {
+		if h.Len() != len(items[i]) || h.IsEmpty() != (len(items[i]) == 0) {
+			t.Fatalf("version %d: Len() = %d, IsEmpty() = %v, want %d items", i, h.Len(), h.IsEmpty(), len(items[i]))
+		}
+		if got := slices.Collect(h.Sorted()); !slices.Equal(got, items[i]) {
+			t.Fatalf("version %d: Sorted() = %v, want %v", i, got, items[i])
+		}
+	}
+}
+
+func testHeap[H testedHeap[H]](t *testing.T, empty H) {
+	t.Run("Empty", func(t *testing.T) {
+		v, h := empty.DeleteMin()
+		if v != 0 || !h.IsEmpty() || h.FindMin() != 0 {
+			t.Errorf("expected an empty heap, got %d, %d items", v, h.Len())
+		}
+	})
+	t.Run("MeldIntoZero", func(t *testing.T) {
+		// the zero value has no order for ints, so it takes the order of
+		// the heap melded into it
+		var zero H
+		h := zero.Meld(empty.Insert(3)).Insert(1).Insert(2)
+		if err := h.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		if got := slices.Collect(h.Sorted()); !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("expected %v, got %v", []int{1, 2, 3}, got)
+		}
+	})
+	t.Run("Versions", func(t *testing.T) {
+		for seed := int64(1); seed <= 20; seed++ {
+			r := rand.New(rand.NewSource(seed))
+			steps := 0
+			checkVersions(t, empty, r.Intn, func() bool { steps++; return steps <= 300 })
+		}
+	})
+	t.Run("Iterate", func(t *testing.T) {
+		h := empty
+		want := make([]int, 1000)
+		for i := range want {
+			want[i] = (i * 7919) % 1000
+			h = h.Insert(want[i])
+		}
+		slices.Sort(want)
+		got := slices.Collect(h.All())
+		slices.Sort(got)
+		if !slices.Equal(got, want) {
+			t.Errorf("All() = %v, want %v", got, want)
+		}
+		var prefix []int
+		for v := range h.Sorted() {
+			if len(prefix) == 10 {
+				break
+			}
+			prefix = append(prefix, v)
+		}
+		if !slices.Equal(prefix, want[:10]) {
+			t.Errorf("first 10 items of Sorted() = %v, want %v", prefix, want[:10])
+		}
+		if h.Len() != len(want) || h.FindMin() != 0 {
+			t.Errorf("iterating changed the heap to %d items with minimum %d", h.Len(), h.FindMin())
+		}
+	})
+	t.Run("Concurrent", func(t *testing.T) {
+		h := empty
+		for i := 500; i > 0; i-- {
+			h = h.Insert(i)
+		}
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i, v := range slices.Collect(h.Sorted()) {
+					if v != i+1 {
+						t.Errorf("item %d of Sorted() = %d, want %d", i, v, i+1)
+						return
+					}
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func TestLeftist(t *testing.T) {
+	testHeap(t, NewLeftistOrdered[int]())
+	h := Leftist[heap.Item]{}.Insert(heap.Integer(2)).Insert(heap.Integer(1))
+	if res, _ := h.DeleteMin(); res != heap.Integer(1) {
+		t.Errorf("expected 1, got %v", res)
+	}
+}
+
+func TestPairing(t *testing.T) {
+	testHeap(t, NewPairingOrdered[int]())
+	h := Pairing[heap.Item]{}.Insert(heap.Integer(2)).Insert(heap.Integer(1))
+	if res, _ := h.DeleteMin(); res != heap.Integer(1) {
+		t.Errorf("expected 1, got %v", res)
+	}
+}
+
+func TestSkewBinomial(t *testing.T) {
+	testHeap(t, NewSkewBinomialOrdered[int]())
+	h := SkewBinomial[heap.Item]{}.Insert(heap.Integer(2)).Insert(heap.Integer(1))
+	if res, _ := h.DeleteMin(); res != heap.Integer(1) {
+		t.Errorf("expected 1, got %v", res)
+	}
+}
+
+func TestSkewBinomialConstantInsert(t *testing.T) {
+	if heap.Debug {
+		t.Skip("validating after every operation allocates")
+	}
+	// Insert and Meld allocate a fixed number of nodes, whatever the size
+	h, other := NewSkewBinomialOrdered[int](), NewSkewBinomialOrdered[int]()
+	for i := range 1 << 12 {
+		h = h.Insert(i)
+		other = other.Insert(-i)
+	}
+	insert := testing.AllocsPerRun(100, func() { h.Insert(7) })
+	meld := testing.AllocsPerRun(100, func() { h.Meld(other) })
+	if insert > 4 || meld > 4 {
+		t.Errorf("expected O(1) allocations, got %v for Insert and %v for Meld", insert, meld)
+	}
+}
+
+func FuzzHeap(f *testing.F) {
+	heaptest.AddSeeds(f)
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		src := ops
+		next := func(n int) int {
+			if len(src) == 0 {
+				return 0
+			}
+			v := int(src[0]) % n
+			src = src[1:]
+			return v
+		}
+		more := func() bool { return len(src) > 0 }
+		for kind := range 3 {
+			src = ops
+			switch kind {
+			case 0:
+				checkVersions(t, NewLeftistOrdered[int](), next, more)
+			case 1:
+				checkVersions(t, NewPairingOrdered[int](), next, more)
+			default:
+				checkVersions(t, NewSkewBinomialOrdered[int](), next, more)
+			}
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	l := NewLeftistOrdered[int]().Insert(1).Insert(2)
+	l.root.left.item = -1
+	if err := l.Validate(); err == nil {
+		t.Error("expected an error for a child smaller than its parent")
+	}
+	p := NewPairingOrdered[int]().Insert(1).Insert(2)
+	p.size = 3
+	if err := p.Validate(); err == nil {
+		t.Error("expected an error for a wrong size")
+	}
+	s := NewSkewBinomialOrdered[int]()
+	for i := range 10 {
+		s = s.Insert(i)
+	}
+	s.root.heaps.head.rank++
+	if err := s.Validate(); err == nil {
+		t.Error("expected an error for a wrong rank")
+	}
+	for _, h := range []heap.Validator{Leftist[int]{}, Pairing[int]{}, SkewBinomial[int]{}} {
+		if err := h.Validate(); err != nil {
+			t.Errorf("empty heap: %v", err)
+		}
+	}
+}
+
+func testEncoding[H testedHeap[H]](t *testing.T, empty H, loaded interface {
+	encoding.BinaryUnmarshaler
+	heap.Iterable[int]
+}) {
+	t.Helper()
+	h := empty
+	for _, v := range []int{5, 1, 4, 1, 3} {
+		h = h.Insert(v)
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(h); err != nil {
+		t.Fatal(err)
+	}
+	if err := gob.NewDecoder(&buf).Decode(loaded); err != nil {
+		t.Fatal(err)
+	}
+	if got := slices.Collect(loaded.Sorted()); !slices.Equal(got, []int{1, 1, 3, 4, 5}) {
+		t.Errorf("expected [1 1 3 4 5], got %v", got)
+	}
+	if err := loaded.UnmarshalBinary([]byte("heap")); err == nil {
+		t.Error("expected an error for malformed data")
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	l, p, s := NewLeftistOrdered[int](), NewPairingOrdered[int](), NewSkewBinomialOrdered[int]()
+	testEncoding(t, NewLeftistOrdered[int](), &l)
+	testEncoding(t, NewPairingOrdered[int](), &p)
+	testEncoding(t, NewSkewBinomialOrdered[int](), &s)
+	if err := l.Validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func testJSON[H interface {
+	testedHeap[H]
+	heap.TreeViewer[int]
+}](t *testing.T, empty H, loaded interface {
+	json.Unmarshaler
+	heap.Iterable[int]
+}) {
+	t.Helper()
+	h := empty
+	for _, v := range []int{5, 1, 4, 1, 3} {
+		h = h.Insert(v)
+	}
+	data, err := json.Marshal(heap.ViewOf[int](h, true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, loaded); err != nil {
+		t.Fatal(err)
+	}
+	if got := slices.Collect(loaded.Sorted()); !slices.Equal(got, []int{1, 1, 3, 4, 5}) {
+		t.Errorf("expected [1 1 3 4 5], got %v", got)
+	}
+	if h.Tree()[0].Item != 1 {
+		t.Errorf("expected the tree view to start at 1, got %v", h.Tree()[0].Item)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	l, p, s := NewLeftistOrdered[int](), NewPairingOrdered[int](), NewSkewBinomialOrdered[int]()
+	testJSON(t, NewLeftistOrdered[int](), &l)
+	testJSON(t, NewPairingOrdered[int](), &p)
+	testJSON(t, NewSkewBinomialOrdered[int](), &s)
+
+	data, err := json.Marshal(NewLeftistOrdered[int]().Insert(2).Insert(1).Tree())
+	if want := `[{"item":1,"s":1,"children":[{"item":2,"s":1},null]}]`; err != nil || string(data) != want {
+		t.Errorf("expected %s, got %s: %v", want, data, err)
+	}
+}
@@ -0,0 +1,241 @@
+package persistent
+
+import (
+	"cmp"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// SkewBinomial implements the Heap interface
+var _ Heap[int, SkewBinomial[int]] = SkewBinomial[int]{}
+
+// skewTree is a skew binomial tree of some rank r. Besides its root it
+// holds up to r items that skew links set aside and children of the ranks
+// r-1 down to 0.
+type skewTree[E any] struct {
+	rank     int
+	root     E
+	extra    *list[E]
+	children *list[*skewTree[E]]
+}
+
+// A skew binomial forest is a list of skew binomial trees in increasing
+// order of rank, where only the first two trees may have the same rank.
+// The functions below follow the same steps as the binomial package's
+// SkewBinomial heap but are a separate implementation: those trees are
+// linked in place through parent and sibling pointers and carry handles,
+// none of which versions that share trees can have.
+
+// link links two trees of the same rank into a tree of the next rank.
+func link[E any](a, b *skewTree[E], compare func(a, b E) int) *skewTree[E] {
+	if compare(b.root, a.root) < 0 {
+		a, b = b, a
+	}
+	return &skewTree[E]{rank: a.rank + 1, root: a.root, extra: a.extra, children: push(b, a.children)}
+}
+
+// skewLink links a and b and adds item to the result as its root or as
+// one of its extra items.
+func skewLink[E any](item E, a, b *skewTree[E], compare func(a, b E) int) *skewTree[E] {
+	t := link(a, b, compare)
+	if compare(item, t.root) <= 0 {
+		return &skewTree[E]{rank: t.rank, root: item, extra: push(t.root, t.extra), children: t.children}
+	}
+	return &skewTree[E]{rank: t.rank, root: t.root, extra: push(item, t.extra), children: t.children}
+}
+
+// skewInsert adds item to the forest ts in O(1) worst case.
+func skewInsert[E any](item E, ts *list[*skewTree[E]], compare func(a, b E) int) *list[*skewTree[E]] {
+	if ts != nil && ts.tail != nil && ts.head.rank == ts.tail.head.rank {
+		return push(skewLink(item, ts.head, ts.tail.head, compare), ts.tail.tail)
+	}
+	return push(&skewTree[E]{root: item}, ts)
+}
+
+// insertTree adds t to ts, whose ranks are unique and not below t's rank,
+// carrying like a binary counter.
+func insertTree[E any](t *skewTree[E], ts *list[*skewTree[E]], compare func(a, b E) int) *list[*skewTree[E]] {
+	for ts != nil && ts.head.rank <= t.rank {
+		t = link(t, ts.head, compare)
+		ts = ts.tail
+	}
+	return push(t, ts)
+}
+
+// mergeTrees merges two forests with unique ranks.
+func mergeTrees[E any](a, b *list[*skewTree[E]], compare func(a, b E) int) *list[*skewTree[E]] {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case a.head.rank < b.head.rank:
+		return push(a.head, mergeTrees(a.tail, b, compare))
+	case b.head.rank < a.head.rank:
+		return push(b.head, mergeTrees(a, b.tail, compare))
+	default:
+		return insertTree(link(a.head, b.head, compare), mergeTrees(a.tail, b.tail, compare), compare)
+	}
+}
+
+// normalize links the first two trees of ts if they have the same rank.
+func normalize[E any](ts *list[*skewTree[E]], compare func(a, b E) int) *list[*skewTree[E]] {
+	if ts == nil {
+		return nil
+	}
+	return insertTree(ts.head, ts.tail, compare)
+}
+
+// meldForests returns a forest of the items of a and b.
+func meldForests[E any](a, b *list[*skewTree[E]], compare func(a, b E) int) *list[*skewTree[E]] {
+	return mergeTrees(normalize(a, compare), normalize(b, compare), compare)
+}
+
+// minTree returns the tree of ts with the smallest root. ts is not empty.
+func minTree[E any](ts *list[*skewTree[E]], compare func(a, b E) int) *skewTree[E] {
+	min := ts.head
+	for l := ts.tail; l != nil; l = l.tail {
+		if compare(l.head.root, min.root) < 0 {
+			min = l.head
+		}
+	}
+	return min
+}
+
+// deleteMinTree removes the tree with the smallest root from ts, melds its
+// children into the rest and inserts its extra items again.
+func deleteMinTree[E any](ts *list[*skewTree[E]], compare func(a, b E) int) *list[*skewTree[E]] {
+	min := minTree(ts, compare)
+	// copy the trees in front of min, which keeps the rest shared
+	var front []*skewTree[E]
+	rest := ts
+	for ; rest.head != min; rest = rest.tail {
+		front = append(front, rest.head)
+	}
+	rest = rest.tail
+	for i := len(front) - 1; i >= 0; i-- {
+		rest = push(front[i], rest)
+	}
+	rest = meldForests(reverse(min.children), rest, compare)
+	for l := min.extra; l != nil; l = l.tail {
+		rest = skewInsert(l.head, rest, compare)
+	}
+	return rest
+}
+
+// bootRoot is the root of a bootstrapped heap: its smallest item and a
+// skew binomial forest of the bootstrapped heaps holding the other items.
+type bootRoot[T any] struct {
+	item  T
+	heaps *list[*skewTree[*bootRoot[T]]]
+}
+
+// SkewBinomial is a persistent skew binomial heap. A skew binomial forest
+// inserts in O(1) but needs O(log n) to find the minimum and meld.
+// Bootstrapping keeps the minimum apart and stores the rest of the items
+// as a forest of heaps, so that melding two heaps inserts one of them into
+// the forest of the other, and Insert, Meld and FindMin all take O(1) in
+// the worst case.
+// The zero value for SkewBinomial is an empty heap ordered by
+// go_heaps.Compare.
+type SkewBinomial[T any] struct {
+	root *bootRoot[T]
+	size int
+	cmp  func(a, b T) int
+}
+
+// NewSkewBinomialFunc returns an empty SkewBinomial heap ordered by compare.
+func NewSkewBinomialFunc[T any](compare func(a, b T) int) SkewBinomial[T] {
+	return SkewBinomial[T]{cmp: compare}
+}
+
+// NewSkewBinomialOrdered returns an empty SkewBinomial heap of ordered
+// values.
+func NewSkewBinomialOrdered[T cmp.Ordered]() SkewBinomial[T] {
+	return NewSkewBinomialFunc(cmp.Compare[T])
+}
+
+func (h SkewBinomial[T]) compare(a, b T) int {
+	if h.cmp == nil {
+		return heap.Compare(a, b)
+	}
+	return h.cmp(a, b)
+}
+
+// compareRoots orders bootstrapped heaps by their smallest items.
+func (h SkewBinomial[T]) compareRoots(a, b *bootRoot[T]) int {
+	return h.compare(a.item, b.item)
+}
+
+// Insert returns a version of the heap with item added.
+// The complexity is O(1).
+func (h SkewBinomial[T]) Insert(item T) SkewBinomial[T] {
+	return h.Meld(SkewBinomial[T]{root: &bootRoot[T]{item: item}, size: 1, cmp: h.cmp})
+}
+
+// DeleteMin returns the smallest item and a version of the heap without it.
+// It returns the zero value of T and the heap itself if the heap is empty.
+// The heap with the smallest item in the forest becomes the new root, and
+// its own forest is melded with the rest.
+// The complexity is O(log n).
+func (h SkewBinomial[T]) DeleteMin() (T, SkewBinomial[T]) {
+	if h.root == nil {
+		var zero T
+		return zero, h
+	}
+	item, heaps := h.root.item, h.root.heaps
+	if heaps == nil {
+		h.root = nil
+	} else {
+		min := minTree(heaps, h.compareRoots).root
+		rest := deleteMinTree(heaps, h.compareRoots)
+		h.root = &bootRoot[T]{item: min.item, heaps: meldForests(min.heaps, rest, h.compareRoots)}
+	}
+	h.size--
+	heap.DebugValidate(h)
+	return item, h
+}
+
+// FindMin returns the smallest item, or the zero value of T if the heap is
+// empty.
+// The complexity is O(1).
+func (h SkewBinomial[T]) FindMin() T {
+	if h.root == nil {
+		var zero T
+		return zero
+	}
+	return h.root.item
+}
+
+// Meld returns a version of the heap holding the items of both heaps, which
+// must have the same order. The root with the larger item is inserted into
+// the forest of the other. Melding into an empty heap, such as the zero
+// value, returns other with its order.
+// The complexity is O(1).
+func (h SkewBinomial[T]) Meld(other SkewBinomial[T]) SkewBinomial[T] {
+	switch {
+	case other.root == nil:
+		return h
+	case h.root == nil:
+		return other
+	}
+	a, b := h.root, other.root
+	if h.compare(b.item, a.item) < 0 {
+		a, b = b, a
+	}
+	h.root = &bootRoot[T]{item: a.item, heaps: skewInsert(b, a.heaps, h.compareRoots)}
+	h.size += other.size
+	heap.DebugValidate(h)
+	return h
+}
+
+// Len returns the number of items in the heap.
+// The complexity is O(1).
+func (h SkewBinomial[T]) Len() int {
+	return h.size
+}
+
+// IsEmpty reports whether the heap has no items.
+func (h SkewBinomial[T]) IsEmpty() bool {
+	return h.root == nil
+}
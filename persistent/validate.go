@@ -0,0 +1,153 @@
+package persistent
+
+import (
+	"fmt"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+var (
+	// Leftist implements the Validator interface
+	_ heap.Validator = Leftist[int]{}
+	// Pairing implements the Validator interface
+	_ heap.Validator = Pairing[int]{}
+	// SkewBinomial implements the Validator interface
+	_ heap.Validator = SkewBinomial[int]{}
+)
+
+// Validate checks that no child is smaller than its parent, that every
+// s-value is correct and at most the s-value of the left sibling and that
+// the heap holds Len items.
+// The complexity is O(n).
+func (h Leftist[T]) Validate() error {
+	count, err := h.validate(h.root)
+	if err != nil {
+		return err
+	}
+	if count != h.size {
+		return fmt.Errorf("persistent: leftist heap has %d items but Len is %d", count, h.size)
+	}
+	return nil
+}
+
+// validate checks the subtree rooted at n and returns its number of items.
+func (h Leftist[T]) validate(n *leftistNode[T]) (int, error) {
+	if n == nil {
+		return 0, nil
+	}
+	for _, c := range []*leftistNode[T]{n.left, n.right} {
+		if c != nil && h.compare(c.item, n.item) < 0 {
+			return 0, fmt.Errorf("persistent: item %v is smaller than its parent %v", c.item, n.item)
+		}
+	}
+	if n.s != svalue(n.right)+1 {
+		return 0, fmt.Errorf("persistent: item %v has s-value %d, want %d", n.item, n.s, svalue(n.right)+1)
+	}
+	if svalue(n.left) < svalue(n.right) {
+		return 0, fmt.Errorf("persistent: item %v has a left s-value %d below its right s-value %d",
+			n.item, svalue(n.left), svalue(n.right))
+	}
+	left, err := h.validate(n.left)
+	if err != nil {
+		return 0, err
+	}
+	right, err := h.validate(n.right)
+	if err != nil {
+		return 0, err
+	}
+	return 1 + left + right, nil
+}
+
+// Validate checks that no child is smaller than its parent and that the
+// heap holds Len items.
+// The complexity is O(n).
+func (h Pairing[T]) Validate() error {
+	count := 0
+	var stack []*pairingNode[T]
+	if h.root != nil {
+		stack = append(stack, h.root)
+	}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		count++
+		for l := n.children; l != nil; l = l.tail {
+			if h.compare(l.head.item, n.item) < 0 {
+				return fmt.Errorf("persistent: item %v is smaller than its parent %v", l.head.item, n.item)
+			}
+			stack = append(stack, l.head)
+		}
+	}
+	if count != h.size {
+		return fmt.Errorf("persistent: pairing heap has %d items but Len is %d", count, h.size)
+	}
+	return nil
+}
+
+// Validate checks that the item of every bootstrapped heap is not larger
+// than the heaps in its forest, that the ranks of every forest increase
+// except for the first two trees, that a tree of rank r has at most r
+// extra items and children of the ranks r-1 down to 0, none of them
+// smaller than its root, and that the heap holds Len items.
+// The complexity is O(n).
+func (h SkewBinomial[T]) Validate() error {
+	count := 0
+	var stack []*bootRoot[T]
+	if h.root != nil {
+		stack = append(stack, h.root)
+	}
+	for len(stack) > 0 {
+		b := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		count++
+		rank := -1
+		for l := b.heaps; l != nil; l = l.tail {
+			t := l.head
+			if t.rank < rank || t.rank == rank && l != b.heaps.tail {
+				return fmt.Errorf("persistent: tree of rank %d follows a tree of rank %d", t.rank, rank)
+			}
+			rank = t.rank
+			if h.compare(t.root.item, b.item) < 0 {
+				return fmt.Errorf("persistent: item %v is smaller than its root %v", t.root.item, b.item)
+			}
+			if err := h.validateTree(t, func(b *bootRoot[T]) { stack = append(stack, b) }); err != nil {
+				return err
+			}
+		}
+	}
+	if count != h.size {
+		return fmt.Errorf("persistent: skew binomial heap has %d items but Len is %d", count, h.size)
+	}
+	return nil
+}
+
+// validateTree checks the skew binomial tree t and calls visit on the
+// bootstrapped heaps it holds.
+func (h SkewBinomial[T]) validateTree(t *skewTree[*bootRoot[T]], visit func(b *bootRoot[T])) error {
+	visit(t.root)
+	if n := length(t.extra); n > t.rank {
+		return fmt.Errorf("persistent: tree of rank %d has %d extra items", t.rank, n)
+	}
+	for l := t.extra; l != nil; l = l.tail {
+		if h.compareRoots(l.head, t.root) < 0 {
+			return fmt.Errorf("persistent: extra item %v is smaller than its root %v", l.head.item, t.root.item)
+		}
+		visit(l.head)
+	}
+	rank := t.rank
+	for l := t.children; l != nil; l = l.tail {
+		if rank--; l.head.rank != rank {
+			return fmt.Errorf("persistent: child of rank %d where rank %d is due", l.head.rank, rank)
+		}
+		if h.compareRoots(l.head.root, t.root) < 0 {
+			return fmt.Errorf("persistent: item %v is smaller than its parent %v", l.head.root.item, t.root.item)
+		}
+		if err := h.validateTree(l.head, visit); err != nil {
+			return err
+		}
+	}
+	if rank != 0 {
+		return fmt.Errorf("persistent: tree of rank %d lacks children below rank %d", t.rank, rank)
+	}
+	return nil
+}
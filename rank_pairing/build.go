@@ -0,0 +1,42 @@
+package rank_paring
+
+import (
+	"cmp"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// FromSliceFunc returns a Heap ordered by compare and configured by opts
+// that holds items.
+// Complexity: O(n)
+func FromSliceFunc[T any](items []T, compare func(a, b T) int, opts ...Option) *Heap[T] {
+	r := NewFunc(compare, opts...)
+	r.InsertAll(items)
+	return r
+}
+
+// FromSliceOrdered returns a Heap of ordered values configured by opts that
+// holds items.
+// Complexity: O(n)
+func FromSliceOrdered[T cmp.Ordered](items []T, opts ...Option) *Heap[T] {
+	return FromSliceFunc(items, cmp.Compare[T], opts...)
+}
+
+// FromSlice returns a rankPairingHeap configured by opts that holds items.
+// Complexity: O(n)
+func FromSlice(items []heap.Item, opts ...Option) *RPHeap {
+	r := New(opts...)
+	r.InsertAll(items)
+	return r
+}
+
+// InsertAll inserts items into the heap. Every item becomes a half tree of
+// rank 0 in the root list, which DeleteMin links later.
+// Complexity: O(k) for k items
+func (r *Heap[T]) InsertAll(items []T) {
+	for _, item := range items {
+		r.insertRoot(&node[T]{item: item, owner: r.owner})
+	}
+	r.size += len(items)
+	heap.DebugValidate(r)
+}
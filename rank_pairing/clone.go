@@ -0,0 +1,163 @@
+package rank_paring
+
+// owner identifies the heap that may modify a node in place. Heaps that
+// share nodes after a Snapshot have different owners, so each of them
+// copies a shared node before it modifies it.
+type owner struct {
+	_ byte
+}
+
+// mutable returns n if the heap owns it or has no snapshots and a copy of
+// n owned by the heap otherwise.
+func (r *Heap[T]) mutable(n *node[T]) *node[T] {
+	if r.owner == nil || n.owner == r.owner {
+		return n
+	}
+	c := *n
+	c.owner = r.owner
+	return &c
+}
+
+// checkHandles panics if the heap has snapshots, since handles need the
+// parent pointers that such heaps do not keep.
+func (r *Heap[T]) checkHandles() {
+	if r.owner != nil {
+		panic("handles are disabled on a heap with snapshots")
+	}
+}
+
+// Clone returns a deep copy of the heap with the same options. Handles of
+// the heap do not refer to items of the copy. The copy has no snapshots,
+// so it supports handles even if the heap does not.
+// Complexity: O(n)
+func (r *Heap[T]) Clone() *Heap[T] {
+	c := &Heap[T]{size: r.size, cmp: r.cmp, options: r.options}
+	for n := r.first; n != nil; n = n.next {
+		t := cloneHalfTree(n)
+		if n == r.head {
+			c.head = t
+		}
+		if c.first == nil {
+			c.first = t
+		} else {
+			c.last.next = t
+		}
+		c.last = t
+	}
+	return c
+}
+
+// Snapshot returns a copy of the heap with the same options that shares
+// all nodes with it. Afterwards both heaps copy a shared node before they
+// modify it: Insert only adds a new root, and DeleteMin copies the roots
+// and the half trees on the left spine of the minimum that it links, which
+// it visits anyway. Shared nodes cannot point to a single parent, so both
+// heaps disable handles until they are cleared: InsertHandle, Remove,
+// DecreaseKey and IncreaseKey panic, and Delete and Adjust copy the path
+// from the first root to the item instead.
+// Complexity: O(1)
+func (r *Heap[T]) Snapshot() *Heap[T] {
+	r.owner = new(owner)
+	return &Heap[T]{head: r.head, first: r.first, last: r.last, size: r.size,
+		cmp: r.cmp, options: r.options, owner: new(owner)}
+}
+
+// Clone returns a deep copy of the rankPairingHeap with the same options.
+// Complexity: O(n)
+func (r *RPHeap) Clone() *RPHeap {
+	return &RPHeap{*r.Heap.Clone()}
+}
+
+// Snapshot returns a copy of the rankPairingHeap that shares all nodes
+// with it, like Heap.Snapshot.
+// Complexity: O(1)
+func (r *RPHeap) Snapshot() *RPHeap {
+	return &RPHeap{*r.Heap.Snapshot()}
+}
+
+// findPath returns the path from the first root along left and next
+// pointers to a node holding val, or nil if there is none.
+func (r *Heap[T]) findPath(val T) []*node[T] {
+	type visit struct {
+		n     *node[T]
+		depth int
+	}
+	var path []*node[T]
+	stack := []visit{{r.first, 0}}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if v.n == nil {
+			continue
+		}
+		path = append(path[:v.depth], v.n)
+		if r.compare(v.n.item, val) == 0 {
+			return path
+		}
+		stack = append(stack, visit{v.n.next, v.depth + 1}, visit{v.n.left, v.depth + 1})
+	}
+	return nil
+}
+
+// copyPath replaces the nodes of a path returned by findPath with copies
+// owned by the heap and returns the copy of its last node. The copies
+// below the root point to their parents, so decrease can cut the last
+// node and update the ranks above it.
+func (r *Heap[T]) copyPath(path []*node[T]) *node[T] {
+	var prev *node[T]
+	inTree := false
+	for _, x := range path {
+		c := r.mutable(x)
+		switch {
+		case prev == nil:
+			r.first = c
+		case prev.left == x:
+			prev.left = c
+			inTree = true
+		default:
+			prev.next = c
+		}
+		if inTree {
+			c.parent = prev
+		}
+		if x == r.head {
+			r.head = c
+		}
+		if x == r.last {
+			r.last = c
+		}
+		prev = c
+	}
+	return prev
+}
+
+// copyNode returns a copy of n without its links.
+func copyNode[T any](n *node[T]) *node[T] {
+	c := *n
+	c.left, c.next, c.parent = nil, nil, nil
+	return &c
+}
+
+// cloneHalfTree returns a copy of the half tree rooted at the root n
+// without the root list.
+func cloneHalfTree[T any](n *node[T]) *node[T] {
+	root := copyNode(n)
+	// pairs of a node and its copy
+	stack := []*node[T]{n, root}
+	for len(stack) > 0 {
+		from, to := stack[len(stack)-2], stack[len(stack)-1]
+		stack = stack[:len(stack)-2]
+		if from.left != nil {
+			to.left = copyNode(from.left)
+			to.left.parent = to
+			stack = append(stack, from.left, to.left)
+		}
+		// the next pointer of a root links the root list
+		if from.parent != nil && from.next != nil {
+			to.next = copyNode(from.next)
+			to.next.parent = to
+			stack = append(stack, from.next, to.next)
+		}
+	}
+	return root
+}
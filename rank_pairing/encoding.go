@@ -0,0 +1,34 @@
+package rank_paring
+
+import (
+	"encoding"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// Heap implements the BinaryMarshaler and BinaryUnmarshaler interfaces
+var (
+	_ encoding.BinaryMarshaler   = (*Heap[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*Heap[int])(nil)
+)
+
+// MarshalBinary encodes the items of the heap in no particular order with the
+// go_heaps.Codec for T.
+// The complexity is O(n).
+func (r *Heap[T]) MarshalBinary() ([]byte, error) {
+	return heap.MarshalItems(r.All())
+}
+
+// UnmarshalBinary replaces the items of the heap with the items encoded in
+// data. The heap keeps its order and configuration and builds itself with
+// InsertAll.
+// The complexity is O(n).
+func (r *Heap[T]) UnmarshalBinary(data []byte) error {
+	items, err := heap.UnmarshalItems[T](data)
+	if err != nil {
+		return err
+	}
+	r.Clear()
+	r.InsertAll(items)
+	return nil
+}
@@ -0,0 +1,61 @@
+package rank_paring
+
+import (
+	"iter"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// Heap implements the Iterable interface
+var _ heap.Iterable[int] = (*Heap[int])(nil)
+
+// Do calls it on each item of the heap in no particular order until it
+// returns false.
+// Complexity: O(n)
+func (r *Heap[T]) Do(it func(item T) bool) {
+	heap.DoForest(r.roots, children[T], itemOf[T], it)
+}
+
+// Ascend calls it on each item of the heap in ascending order until it
+// returns false, without modifying the heap.
+// Complexity: O(n log n) to visit all n items
+func (r *Heap[T]) Ascend(it func(item T) bool) {
+	heap.AscendForest(r.roots, children[T], itemOf[T], r.compare, it)
+}
+
+// All returns an iterator over the items of the heap in no particular
+// order.
+func (r *Heap[T]) All() iter.Seq[T] {
+	return r.Do
+}
+
+// Sorted returns an iterator over the items of the heap in ascending order.
+func (r *Heap[T]) Sorted() iter.Seq[T] {
+	return r.Ascend
+}
+
+// roots visits head and then the other roots in the order of the root
+// list.
+func (r *Heap[T]) roots(visit func(n *node[T])) {
+	if r.head == nil {
+		return
+	}
+	visit(r.head)
+	for n := r.first; n != nil; n = n.next {
+		if n != r.head {
+			visit(n)
+		}
+	}
+}
+
+// children visits the nodes a half tree is half ordered against: the left
+// child of n and the right spine below it.
+func children[T any](n *node[T], visit func(c *node[T])) {
+	for c := n.left; c != nil; c = c.next {
+		visit(c)
+	}
+}
+
+func itemOf[T any](n *node[T]) T {
+	return n.item
+}
@@ -0,0 +1,61 @@
+package rank_paring
+
+import (
+	"encoding/json"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+var (
+	// Heap implements the Marshaler and Unmarshaler interfaces
+	_ json.Marshaler   = (*Heap[int])(nil)
+	_ json.Unmarshaler = (*Heap[int])(nil)
+	// Heap has a tree view
+	_ heap.TreeViewer[int] = (*Heap[int])(nil)
+)
+
+// MarshalJSON encodes the items of the heap in no particular order as a
+// go_heaps.JSONView without the tree view.
+// The complexity is O(n).
+func (r *Heap[T]) MarshalJSON() ([]byte, error) {
+	return heap.ViewOf[T](r, false).MarshalJSON()
+}
+
+// UnmarshalJSON replaces the items of the heap with the items of the
+// go_heaps.JSONView encoded in data. The heap keeps its order and options
+// and builds itself with InsertAll.
+// The complexity is O(n).
+func (r *Heap[T]) UnmarshalJSON(data []byte) error {
+	var v heap.JSONView[T]
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	r.Clear()
+	r.InsertAll(v.Items)
+	return nil
+}
+
+// Tree returns the half trees of the heap in the order of the root list,
+// starting at the minimum, in their binary form: a root has its left
+// child as only child, and any other node its left child and the next node
+// on its right spine, null where missing. Every node has its "rank".
+// The complexity is O(n).
+func (r *Heap[T]) Tree() []*heap.TreeNode[T] {
+	return heap.TreeOf(r.roots, halfChildren[T], itemOf[T], func(n *node[T]) []heap.Attr {
+		return []heap.Attr{{Name: "rank", Value: n.rank}}
+	})
+}
+
+// halfChildren visits the children of n in the binary form of its half
+// tree. The next node of a root is the next root instead.
+func halfChildren[T any](n *node[T], visit func(c *node[T])) {
+	switch {
+	case n.parent == nil:
+		if n.left != nil {
+			visit(n.left)
+		}
+	case n.left != nil || n.next != nil:
+		visit(n.left)
+		visit(n.next)
+	}
+}
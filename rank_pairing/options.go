@@ -0,0 +1,77 @@
+package rank_paring
+
+// RankRule selects how the rank of a node that is not a root follows from
+// the ranks of its two children. A missing child has rank -1.
+type RankRule int
+
+const (
+	// Type1 gives a node the rank of its larger child, plus one if both
+	// children have the same rank. It is the default.
+	Type1 RankRule = iota
+	// Type2 gives a node the rank of its larger child, plus one if the
+	// ranks of the children differ by at most one. It lets ranks fall less
+	// often during DecreaseKey, at the cost of somewhat larger ranks.
+	Type2
+)
+
+// Linking selects how DeleteMin links the remaining half trees.
+type Linking int
+
+const (
+	// MultiPass links half trees of equal rank until every rank appears
+	// at most once. It is the default.
+	MultiPass Linking = iota
+	// OnePass links half trees of equal rank once and keeps the results
+	// as roots without linking them again.
+	OnePass
+)
+
+// Option configures a Heap.
+type Option func(*options)
+
+type options struct {
+	rule    RankRule
+	linking Linking
+}
+
+// WithRankRule sets the rank rule of the heap.
+func WithRankRule(rule RankRule) Option {
+	return func(o *options) { o.rule = rule }
+}
+
+// WithLinking sets how DeleteMin links half trees.
+func WithLinking(linking Linking) Option {
+	return func(o *options) { o.linking = linking }
+}
+
+func (o *options) apply(opts []Option) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// RankRule returns the rank rule of the heap.
+func (r *Heap[T]) RankRule() RankRule {
+	return r.rule
+}
+
+// Linking returns how the heap links half trees in DeleteMin.
+func (r *Heap[T]) Linking() Linking {
+	return r.linking
+}
+
+// rankOf computes the rank of n, which is not a root, from the ranks of its
+// children.
+func (r *Heap[T]) rankOf(n *node[T]) int {
+	left, next := getrank(n.left), getrank(n.next)
+	if r.rule == Type2 {
+		if left-next <= 1 && next-left <= 1 {
+			return max(left, next) + 1
+		}
+		return max(left, next)
+	}
+	if left == next {
+		return left + 1
+	}
+	return max(left, next)
+}
@@ -1,203 +1,402 @@
 package rank_paring
 
 import (
+	"cmp"
 	"fmt"
 
 	heap "github.com/theodesp/go-heaps"
 )
 
-type node struct {
-	item               heap.Item
-	left, next, parent *node
+// RPHeap implements the Extended interface
+var _ heap.Extended = (*RPHeap)(nil)
+
+// Heap implements the ExtendedHeap interface
+var _ heap.ExtendedHeap[int] = (*Heap[int])(nil)
+
+type node[T any] struct {
+	item T
+	// next is the next root for a root and the right child otherwise;
+	// parent is nil for a root and not kept on a heap with snapshots
+	// otherwise, except on a path copied by copyPath
+	left, next, parent *node[T]
 	rank               int
+	// nInf marks a node that compares below every other node (negative inf)
+	nInf  bool
+	owner *owner
+}
+
+// Heap is an implementation of a rank Pairing Heap over values of type T.
+// The zero value for Heap is an empty type-1 Heap with multi-pass linking
+// ordered by go_heaps.Compare.
+type Heap[T any] struct {
+	// head is the root holding the smallest item
+	head *node[T]
+	// first and last are the ends of the root list
+	first, last *node[T]
+	size        int
+	cmp         func(a, b T) int
+	// owner is set once the Heap has snapshots
+	owner *owner
+	options
 }
 
 // RPHeap is an implementation of a rank Pairing Heap.
 // The zero value for RPHeap Root is an empty Heap.
 type RPHeap struct {
-	head *node
-	size int
+	Heap[heap.Item]
 }
 
-type nInf struct{}
-
-func (a nInf) Compare(b heap.Item) int {
-	switch b.(type) {
-	case nInf:
-		return 0
-	default:
-		return -1
+func (r *Heap[T]) compare(a, b T) int {
+	if r.cmp == nil {
+		return heap.Compare(a, b)
 	}
+	return r.cmp(a, b)
 }
 
-// wrap compare function to support nInf (negative inf) type
-func compare(a, b heap.Item) int {
-	switch b.(type) {
-	case nInf:
-		switch a.(type) {
-		case nInf:
-			return 0
-		default:
-			return 1
-		}
-	default:
-		return a.Compare(b)
+// less reports whether node a orders before node b, taking nInf into account
+func (r *Heap[T]) less(a, b *node[T]) bool {
+	if a.nInf || b.nInf {
+		return a.nInf && !b.nInf
 	}
+	return r.compare(a.item, b.item) < 0
+}
+
+// Init initializes or clears the Heap
+func (r *Heap[T]) Init() *Heap[T] {
+	r.head, r.first, r.last = nil, nil, nil
+	r.size = 0
+	r.owner = nil
+	return r
+}
+
+// NewFunc returns an initialized Heap ordered by compare and configured by
+// opts.
+func NewFunc[T any](compare func(a, b T) int, opts ...Option) *Heap[T] {
+	r := &Heap[T]{cmp: compare}
+	r.apply(opts)
+	return r.Init()
+}
+
+// NewOrdered returns an initialized Heap of ordered values configured by
+// opts.
+func NewOrdered[T cmp.Ordered](opts ...Option) *Heap[T] {
+	return NewFunc(cmp.Compare[T], opts...)
 }
 
 // Init initializes or clears the rankPairingHeap
-func (p *RPHeap) Init() *RPHeap {
-	p.head = &node{}
-	p.size = 0
-	return p
+func (r *RPHeap) Init() *RPHeap {
+	r.Heap.Init()
+	return r
+}
+
+// New returns an initialized rankPairingHeap configured by opts.
+func New(opts ...Option) *RPHeap {
+	r := &RPHeap{}
+	r.apply(opts)
+	return r.Init()
 }
 
-// New returns an initialized rankPairingHeap.
-func New() *RPHeap { return new(RPHeap).Init() }
+// NewWithComparator returns an initialized rankPairingHeap ordered by
+// compare instead of Item.Compare and configured by opts.
+func NewWithComparator(compare func(a, b heap.Item) int, opts ...Option) *RPHeap {
+	r := New(opts...)
+	r.cmp = compare
+	return r
+}
 
 // FindMin returns the value of root
 // Complexity: O(1)
-func (r *RPHeap) FindMin() heap.Item {
+func (r *Heap[T]) FindMin() T {
+	if r.head == nil {
+		var zero T
+		return zero
+	}
 	return r.head.item
 }
 
 // Insert the value val into the heap and return it
 // Complexity: O(1)
-func (r *RPHeap) Insert(val heap.Item) heap.Item {
-	ptr := &node{
-		item: val,
+func (r *Heap[T]) Insert(val T) T {
+	ptr := &node[T]{
+		item:  val,
+		owner: r.owner,
 	}
 	r.insertRoot(ptr)
 	r.size++
+	heap.DebugValidate(r)
 	return val
 }
 
 // DeleteMin removes the top most value from the rankPairingHeap and returns it
 // Complexity: O(log n)
-func (r *RPHeap) DeleteMin() heap.Item {
-	bucket := make([]*node, r.maxBucketSize())
-	ret := r.head.item
-	// an empty heap will panic here
-	if ret == nil {
-		return nil
+func (r *Heap[T]) DeleteMin() T {
+	if r.head == nil {
+		var zero T
+		return zero
 	}
+	bucket := make([]*node[T], r.maxBucketSize())
+	var linked []*node[T]
+	ret := r.head.item
 	r.size--
 	for ptr := r.head.left; ptr != nil; {
 		nextPtr := ptr.next
+		ptr = r.mutable(ptr)
 		ptr.next = nil
 		ptr.parent = nil
-		bucket = multiPass(bucket, ptr)
+		ptr.rank = getrank(ptr.left) + 1
+		bucket, linked = r.pass(bucket, linked, ptr)
 		ptr = nextPtr
 	}
-	for ptr := r.head.next; ptr != r.head; {
+	for ptr := r.first; ptr != nil; {
 		nextPtr := ptr.next
-		ptr.next = nil
-		bucket = multiPass(bucket, ptr)
+		if ptr != r.head {
+			ptr = r.mutable(ptr)
+			ptr.next = nil
+			bucket, linked = r.pass(bucket, linked, ptr)
+		}
 		ptr = nextPtr
 	}
-	r.head = &node{}
+	r.head, r.first, r.last = nil, nil, nil
 	for _, ptr := range bucket {
 		if ptr != nil {
 			r.insertRoot(ptr)
 		}
 	}
+	for _, ptr := range linked {
+		r.insertRoot(ptr)
+	}
+	heap.DebugValidate(r)
 	return ret
 }
 
 // Clear the whole rankPairingHeap
-func (r *RPHeap) Clear() {
+func (r *Heap[T]) Clear() {
 	r.Init()
 }
 
+// Merge a rankPairingHeap r0 into a heap r, then clear r0.
+// It panics if the heaps have different rank rules. The heaps may use
+// different linking methods, since linking only decides how DeleteMin
+// consolidates half trees; the melded heap keeps the linking of r.
+// If r0 has snapshots and r does not, Meld merges a Clone of r0, so that r
+// keeps its handles; this takes O(m). If the last roots of both heaps are
+// shared with snapshots, Meld copies the k roots of r0 instead; this takes
+// O(k).
+// Complexity: O(1)
+func (r *Heap[T]) Meld(a heap.Heap[T]) heap.Heap[T] {
+	if a == nil {
+		return r
+	}
+	r0, ok := a.(*Heap[T])
+	if !ok {
+		panic(fmt.Sprintf("unexpected type %T", a))
+	}
+	if r0 == r || r0.head == nil {
+		return r
+	}
+	if r0.rule != r.rule {
+		panic("heaps have different rank rules")
+	}
+	src := r0
+	if r0.owner != nil && r.owner == nil {
+		src = r0.Clone()
+	}
+	// concatenate the root lists, modifying a last root that is not shared
+	switch {
+	case r.head == nil:
+		r.head, r.first, r.last = src.head, src.first, src.last
+	case r.owner == nil || r.last.owner == r.owner:
+		r.last.next = src.first
+		r.last = src.last
+	case src.owner == nil || src.last.owner == src.owner:
+		src.last.next = r.first
+		r.first = src.first
+	default:
+		for ptr := src.first; ptr != nil; ptr = ptr.next {
+			c := r.mutable(ptr)
+			c.next = r.first
+			r.first = c
+			if ptr == src.head {
+				src.head = c
+			}
+		}
+	}
+	if r.less(src.head, r.head) {
+		r.head = src.head
+	}
+	r.size += r0.size
+	r0.Clear()
+	heap.DebugValidate(r)
+	return r
+}
+
 // Merge a rankPairingHeap r0 into a heap r, then clear r0
 // Complexity: O(1)
 func (r *RPHeap) Meld(a heap.Interface) heap.Interface {
-	switch a.(type) {
+	if a == nil {
+		return r
+	}
+	switch r0 := a.(type) {
 	case *RPHeap:
+		r.Heap.Meld(&r0.Heap)
 	default:
 		panic(fmt.Sprintf("unexpected type %T", a))
 	}
-	r0 := a.(*RPHeap)
-	if r.head.item == nil {
-		r.head = r0.head
-		r.size = r0.size
-		r0.Clear()
-		return r
+	return r
+}
+
+// Len returns the number of items in the Heap
+// Complexity: O(1)
+func (r *Heap[T]) Len() int {
+	return r.size
+}
+
+// Size returns the size of the Heap. It is the same as Len.
+func (r *Heap[T]) Size() int {
+	return r.size
+}
+
+// IsEmpty returns true if the Heap has no items
+// Complexity: O(1)
+func (r *Heap[T]) IsEmpty() bool {
+	return r.head == nil
+}
+
+// Handle is an opaque reference to an item inserted with InsertHandle.
+// It stays valid until the item is removed from the heap.
+type Handle[T any] node[T]
+
+// Item returns the item referenced by the Handle.
+func (h *Handle[T]) Item() T {
+	return h.item
+}
+
+// InsertHandle inserts the value val into the heap and returns a Handle
+// to it that can be passed to DecreaseKey, IncreaseKey and Remove.
+// Complexity: O(1)
+func (r *Heap[T]) InsertHandle(val T) *Handle[T] {
+	r.checkHandles()
+	ptr := &node[T]{
+		item: val,
 	}
-	if r0.head.item == nil {
-		return r
+	r.insertRoot(ptr)
+	r.size++
+	heap.DebugValidate(r)
+	return (*Handle[T])(ptr)
+}
+
+// DecreaseKey replaces the item referenced by h with a smaller item.
+// Complexity: O(1) amortized
+func (r *Heap[T]) DecreaseKey(h *Handle[T], val T) {
+	r.checkHandles()
+	ptr := (*node[T])(h)
+	if r.compare(val, ptr.item) > 0 {
+		panic("new item is greater than the previous one")
 	}
-	if compare(r.head.item, r0.head.item) < 0 {
-		mergeRes := merge(r, r0)
-		r0.Clear()
-		return mergeRes
-	} else {
-		mergeRes := merge(r0, r)
-		r0.Clear()
-		return mergeRes
+	ptr.item = val
+	r.decrease(ptr)
+	heap.DebugValidate(r)
+}
+
+// IncreaseKey replaces the item referenced by h with a greater item.
+// Complexity: O(log n) amortized
+func (r *Heap[T]) IncreaseKey(h *Handle[T], val T) {
+	r.checkHandles()
+	ptr := (*node[T])(h)
+	if r.compare(val, ptr.item) < 0 {
+		panic("new item is smaller than the previous one")
 	}
+	r.remove(ptr)
+	ptr.item, ptr.left, ptr.rank = val, nil, 0
+	r.insertRoot(ptr)
+	r.size++
+	heap.DebugValidate(r)
 }
 
-// Size returns the size of the RPHeap
-func (r *RPHeap) Size() int {
-	return r.size
+// Remove deletes the item referenced by h from the heap and returns it
+// Complexity: O(log n) amortized
+func (r *Heap[T]) Remove(h *Handle[T]) T {
+	r.checkHandles()
+	return r.remove((*node[T])(h))
+}
+
+// remove deletes the node ptr from the heap and returns its item.
+func (r *Heap[T]) remove(ptr *node[T]) T {
+	if ptr != r.head {
+		ptr.nInf = true
+		r.decrease(ptr)
+	}
+	ret := r.DeleteMin()
+	ptr.nInf = false
+	return ret
 }
 
 // Adjust the value of an item, since we have to find the item
 // Complexity is O(n)
-func (r *RPHeap) Adjust(old, new heap.Item) heap.Item {
-	ptr := r.find(r.head, old)
+func (r *Heap[T]) Adjust(old, new T) T {
+	ptr := r.findAny(old)
 	if ptr == nil {
-		return nil
+		var zero T
+		return zero
 	}
-	if compare(ptr.item, new) < 0 {
-		r.decrease(ptr, nInf{})
-		r.DeleteMin()
+	if r.owner != nil {
+		r.remove(ptr)
 		r.Insert(new)
+		return new
+	}
+	if r.compare(ptr.item, new) < 0 {
+		r.IncreaseKey((*Handle[T])(ptr), new)
 	} else {
-		r.decrease(ptr, new)
+		r.DecreaseKey((*Handle[T])(ptr), new)
 	}
 	return new
 }
 
 // Delete an item from the heap
 // Complexity is O(n)
-func (r *RPHeap) Delete(val heap.Item) heap.Item {
-	ptr := r.find(r.head, val)
+func (r *Heap[T]) Delete(val T) T {
+	ptr := r.findAny(val)
 	if ptr == nil {
-		return nil
+		var zero T
+		return zero
 	}
-	if ptr == r.head {
-		return r.DeleteMin()
+	return r.remove(ptr)
+}
+
+// findAny returns the node holding val or nil if there is none. On a heap
+// with snapshots it copies the path to the node first, so that decrease
+// can cut it and update the ranks above it.
+func (r *Heap[T]) findAny(val T) *node[T] {
+	if r.owner == nil {
+		return r.find(r.first, val)
 	}
-	r.decrease(ptr, nInf{})
-	r.DeleteMin()
-	return val
+	path := r.findPath(val)
+	if path == nil {
+		return nil
+	}
+	return r.copyPath(path)
 }
 
-// Decrease the value of an item
+// Restore the heap after the value of ptr has been decreased
 // Complexity is O(log n)
-func (r *RPHeap) decrease(ptr *node, val heap.Item) {
-	if compare(val, ptr.item) < 0 {
-		ptr.item = val
-	}
+func (r *Heap[T]) decrease(ptr *node[T]) {
 	if ptr == r.head {
 		return
 	}
 	if ptr.parent == nil {
-		if compare(ptr.item, r.head.item) < 0 {
+		if r.less(ptr, r.head) {
 			r.head = ptr
 		}
 	} else {
 		parent := ptr.parent
 		if ptr == parent.left {
 			parent.left = ptr.next
-			if parent.left != nil {
+			if parent.left != nil && r.owner == nil {
 				parent.left.parent = parent
 			}
 		} else {
 			parent.next = ptr.next
-			if parent.next != nil {
+			if parent.next != nil && r.owner == nil {
 				parent.next.parent = parent
 			}
 		}
@@ -208,43 +407,33 @@ func (r *RPHeap) decrease(ptr *node, val heap.Item) {
 			ptr.rank = 0
 		}
 		r.insertRoot(ptr)
+		for parent.parent != nil {
+			newrank := r.rankOf(parent)
+			if newrank >= parent.rank {
+				break
+			}
+			parent.rank = newrank
+			parent = parent.parent
+		}
 		if parent.parent == nil {
 			parent.rank = getrank(parent.left) + 1
-		} else {
-			for parent.parent != nil {
-				leftrank := getrank(parent.left)
-				nextrank := getrank(parent.next)
-				newrank := leftrank + 1
-				if leftrank != nextrank {
-					if leftrank > nextrank {
-						newrank = leftrank
-					} else {
-						newrank = nextrank
-					}
-				}
-				if newrank >= parent.rank {
-					break
-				}
-				parent.rank = newrank
-				parent = parent.parent
-			}
 		}
 	}
 }
 
 // Find the pointer to an item
 // Complexity: O(n)
-func (r *RPHeap) find(root *node, val heap.Item) *node {
+func (r *Heap[T]) find(root *node[T], val T) *node[T] {
 	if root == nil {
 		return nil
-	} else if compare(root.item, val) == 0 {
+	} else if r.compare(root.item, val) == 0 {
 		return root
 	} else {
 		if leftfind := r.find(root.left, val); leftfind != nil {
 			return leftfind
 		}
 		for ptr := root.next; ptr != nil && ptr != root; ptr = ptr.next {
-			if compare(ptr.item, val) == 0 {
+			if r.compare(ptr.item, val) == 0 {
 				return ptr
 			}
 			if leftfind := r.find(ptr.left, val); leftfind != nil {
@@ -255,35 +444,14 @@ func (r *RPHeap) find(root *node, val heap.Item) *node {
 	return nil
 }
 
-func getrank(root *node) int {
+func getrank[T any](root *node[T]) int {
 	if root == nil {
 		return -1
 	}
 	return root.rank
 }
 
-func merge(r0, r1 *RPHeap) *RPHeap {
-	if r1.Size() == 1 {
-		ptr := r1.head
-		ptr.next, ptr.parent, ptr.left, ptr.rank = nil, nil, nil, 0
-		r0.insertRoot(ptr)
-		r0.size++
-		return &RPHeap{
-			head: r0.head,
-			size: r0.size,
-		}
-	} else if r0.Size() == 1 {
-		return merge(r1, r0)
-	}
-	r0.head.next, r1.head.next = r1.head.next, r0.head.next
-	r0.size += r1.size
-	return &RPHeap{
-		head: r0.head,
-		size: r0.size,
-	}
-}
-
-func (r *RPHeap) maxBucketSize() int {
+func (r *Heap[T]) maxBucketSize() int {
 	bit, cnt := 1, r.size
 	for cnt > 1 {
 		cnt /= 2
@@ -292,35 +460,63 @@ func (r *RPHeap) maxBucketSize() int {
 	return bit + 1
 }
 
-func (r *RPHeap) insertRoot(ptr *node) {
-	if r.head.item == nil {
+// insertRoot puts ptr, which the heap owns, at the front of the root list.
+func (r *Heap[T]) insertRoot(ptr *node[T]) {
+	ptr.next = r.first
+	r.first = ptr
+	if r.last == nil {
+		r.last = ptr
+	}
+	if r.head == nil || r.less(ptr, r.head) {
 		r.head = ptr
-		ptr.next = ptr
-	} else {
-		ptr.next = r.head.next
-		r.head.next = ptr
-		if compare(ptr.item, r.head.item) < 0 {
-			r.head = ptr
-		}
 	}
 }
 
-func multiPass(bucket []*node, ptr *node) []*node {
+// pass adds the half tree ptr to the bucket of its rank using the linking
+// method of the heap. Half trees linked by one-pass linking are appended to
+// linked instead of going back into the bucket.
+func (r *Heap[T]) pass(bucket, linked []*node[T], ptr *node[T]) ([]*node[T], []*node[T]) {
+	for ptr.rank >= len(bucket) {
+		bucket = append(bucket, nil)
+	}
+	if r.linking == MultiPass {
+		return r.multiPass(bucket, ptr), linked
+	}
+	return r.onePass(bucket, linked, ptr)
+}
+
+func (r *Heap[T]) multiPass(bucket []*node[T], ptr *node[T]) []*node[T] {
 	for bucket[ptr.rank] != nil {
 		rank := ptr.rank
-		ptr = link(ptr, bucket[rank])
+		ptr = r.link(ptr, bucket[rank])
 		bucket[rank] = nil
+		for ptr.rank >= len(bucket) {
+			bucket = append(bucket, nil)
+		}
 	}
 	bucket[ptr.rank] = ptr
 	return bucket
 }
 
-func link(left *node, right *node) *node {
+// onePass links ptr with the half tree of the same rank in the bucket, if
+// there is one, and sets the result aside in linked.
+func (r *Heap[T]) onePass(bucket, linked []*node[T], ptr *node[T]) ([]*node[T], []*node[T]) {
+	rank := ptr.rank
+	if bucket[rank] == nil {
+		bucket[rank] = ptr
+		return bucket, linked
+	}
+	linked = append(linked, r.link(ptr, bucket[rank]))
+	bucket[rank] = nil
+	return bucket, linked
+}
+
+func (r *Heap[T]) link(left *node[T], right *node[T]) *node[T] {
 	if right == nil {
 		return left
 	}
-	var winner, loser *node
-	if compare(right.item, left.item) < 0 {
+	var winner, loser *node[T]
+	if r.less(right, left) {
 		winner = right
 		loser = left
 	} else {
@@ -330,7 +526,9 @@ func link(left *node, right *node) *node {
 	loser.parent = winner
 	if winner.left != nil {
 		loser.next = winner.left
-		loser.next.parent = loser
+		if r.owner == nil {
+			loser.next.parent = loser
+		}
 	}
 	winner.left = loser
 	winner.rank = loser.rank + 1
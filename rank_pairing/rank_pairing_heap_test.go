@@ -1,10 +1,13 @@
 package rank_paring
 
 import (
+	"fmt"
+	"slices"
 	"sort"
 	"testing"
 
 	heap "github.com/theodesp/go-heaps"
+	"github.com/theodesp/go-heaps/heaptest"
 )
 
 func TestRPHeapInteger(t *testing.T) {
@@ -103,6 +106,26 @@ func TestRPHeapDelete(t *testing.T) {
 	}
 }
 
+func TestRPHeapLen(t *testing.T) {
+	rpheap := New()
+	if !rpheap.IsEmpty() || rpheap.Len() != 0 {
+		t.Fail()
+	}
+	for _, number := range []int{4, 3, 2, 5} {
+		rpheap.Insert(Int(number))
+	}
+	rpheap.Delete(Int(3))
+	rpheap.DeleteMin()
+	if rpheap.IsEmpty() || rpheap.Len() != 2 {
+		t.Fail()
+	}
+	rpheap.DeleteMin()
+	rpheap.DeleteMin()
+	if rpheap.DeleteMin() != nil || !rpheap.IsEmpty() || rpheap.Len() != 0 {
+		t.Fail()
+	}
+}
+
 func TestRPHeapString(t *testing.T) {
 	rpheap := New()
 
@@ -177,6 +200,90 @@ func runTestMerge(arr1, arr2 []int, t *testing.T) {
 	}
 }
 
+func TestRPHeapMaxHeap(t *testing.T) {
+	rpheap := NewWithComparator(func(a, b heap.Item) int { return b.Compare(a) })
+	reversed := New()
+
+	for _, number := range []int{4, 3, 7, 5} {
+		rpheap.Insert(heap.Integer(number))
+		reversed.Insert(heap.Reverse(heap.Integer(number)))
+	}
+
+	for _, number := range []int{7, 5, 4, 3} {
+		if heap.Integer(number) != rpheap.DeleteMin() {
+			t.Fail()
+		}
+		if heap.Integer(number) != reversed.DeleteMin().(heap.Reversed).Item {
+			t.Fail()
+		}
+	}
+}
+
+func TestHeapOrdered(t *testing.T) {
+	rpheap := NewOrdered[int]()
+	numbers := []int{9, 2, 4, 3, 1, 5, 6, 8, 7, 0}
+	for _, number := range numbers {
+		rpheap.Insert(number)
+	}
+	rpheap.Adjust(4, -1)
+	rpheap.Delete(8)
+	other := NewOrdered[int]()
+	other.Insert(10)
+	rpheap.Meld(other)
+	ans := []int{-1, 0, 1, 2, 3, 5, 6, 7, 9, 10}
+	for _, number := range ans {
+		if res := rpheap.DeleteMin(); number != res {
+			t.Errorf("expected %d, got %d", number, res)
+		}
+	}
+	if rpheap.Size() != 0 || rpheap.FindMin() != 0 {
+		t.Fail()
+	}
+}
+
+func TestHeapFunc(t *testing.T) {
+	rpheap := NewFunc(func(a, b string) int { return len(a) - len(b) })
+	strs := []string{"ccc", "a", "dddd", "bb"}
+	for _, str := range strs {
+		rpheap.Insert(str)
+	}
+	for _, str := range []string{"a", "bb", "ccc", "dddd"} {
+		if res := rpheap.DeleteMin(); str != res {
+			t.Errorf("expected %s, got %s", str, res)
+		}
+	}
+}
+
+func TestHandle(t *testing.T) {
+	rpheap := NewOrdered[int]()
+	handles := make([]*Handle[int], 10)
+	for i := range handles {
+		handles[i] = rpheap.InsertHandle(i * 10)
+	}
+	rpheap.DeleteMin()
+	rpheap.DecreaseKey(handles[9], -5)
+	if rpheap.FindMin() != -5 {
+		t.Fail()
+	}
+	rpheap.IncreaseKey(handles[9], 95)
+	rpheap.IncreaseKey(handles[1], 55)
+	if res := rpheap.Remove(handles[5]); res != 50 {
+		t.Errorf("expected 50, got %d", res)
+	}
+	if handles[1].Item() != 55 {
+		t.Fail()
+	}
+	ans := []int{20, 30, 40, 55, 60, 70, 80, 95}
+	for _, number := range ans {
+		if res := rpheap.DeleteMin(); number != res {
+			t.Errorf("expected %d, got %d", number, res)
+		}
+	}
+	if rpheap.Size() != 0 {
+		t.Fail()
+	}
+}
+
 func Int(value int) heap.Integer {
 	return heap.Integer(value)
 }
@@ -184,3 +291,277 @@ func Int(value int) heap.Integer {
 func Str(value string) heap.String {
 	return heap.String(value)
 }
+
+func TestExtended(t *testing.T) {
+	heaptest.RunExtendedTests(t, func() heap.Extended { return New() })
+}
+
+var variants = map[string][]Option{
+	"type2":          {WithRankRule(Type2)},
+	"one_pass":       {WithLinking(OnePass)},
+	"type2_one_pass": {WithRankRule(Type2), WithLinking(OnePass)},
+}
+
+func TestExtendedVariants(t *testing.T) {
+	for name, opts := range variants {
+		t.Run(name, func(t *testing.T) {
+			heaptest.RunExtendedTests(t, func() heap.Extended { return New(opts...) })
+		})
+	}
+}
+
+func FuzzHeap(f *testing.F) {
+	heaptest.AddSeeds(f)
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		heaptest.FuzzExtended(t, func() heap.Extended { return New() }, ops)
+		for _, opts := range variants {
+			heaptest.FuzzExtended(t, func() heap.Extended { return New(opts...) }, ops)
+			heaptest.FuzzHandles(t, func() heaptest.HandleHeap[*Handle[int]] { return NewOrdered[int](opts...) }, ops)
+		}
+	})
+}
+
+func TestOptions(t *testing.T) {
+	h := NewOrdered[int](WithRankRule(Type2), WithLinking(OnePass))
+	if h.RankRule() != Type2 || h.Linking() != OnePass {
+		t.Errorf("expected type-2 one-pass, got %d %d", h.RankRule(), h.Linking())
+	}
+	h = NewOrdered[int]()
+	if h.RankRule() != Type1 || h.Linking() != MultiPass {
+		t.Errorf("expected type-1 multi-pass, got %d %d", h.RankRule(), h.Linking())
+	}
+}
+
+func TestVariantHandles(t *testing.T) {
+	for name, opts := range variants {
+		t.Run(name, func(t *testing.T) {
+			h := NewOrdered[int](opts...)
+			handles := make([]*Handle[int], 200)
+			for i := range handles {
+				handles[i] = h.InsertHandle(1000 + i)
+			}
+			h.DeleteMin()
+			for i := len(handles) - 1; i > 0; i-- {
+				h.DecreaseKey(handles[i], 1000-i)
+				if err := h.Validate(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for i := len(handles) - 1; i > 0; i-- {
+				if res := h.DeleteMin(); res != 1000-i {
+					t.Errorf("expected %d, got %d", 1000-i, res)
+				}
+				if err := h.Validate(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if !h.IsEmpty() {
+				t.Errorf("expected an empty heap, got %d items", h.Len())
+			}
+		})
+	}
+}
+
+func TestMeldDifferentRankRules(t *testing.T) {
+	h := NewOrdered[int]()
+	o := NewOrdered[int](WithRankRule(Type2))
+	o.Insert(1)
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected a panic")
+			}
+		}()
+		h.Meld(o)
+	}()
+	// heaps that only differ in linking meld and keep the receiver's linking
+	o = NewOrdered[int](WithLinking(OnePass))
+	for _, number := range []int{4, 1, 3, 2} {
+		o.Insert(number)
+	}
+	h.Meld(o)
+	if h.Len() != 4 || h.Linking() != MultiPass {
+		t.Errorf("expected 4 items with multi-pass linking, got %d with %v", h.Len(), h.Linking())
+	}
+	for want := 1; want <= 4; want++ {
+		if got := h.DeleteMin(); got != want {
+			t.Fatalf("expected %d, got %d", want, got)
+		}
+		if err := h.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestMeldNil(t *testing.T) {
+	h := NewOrdered[int]()
+	h.Insert(1)
+	if h.Meld(nil) != h || h.Len() != 1 {
+		t.Error("expected Meld(nil) to leave the heap unchanged")
+	}
+	r := &RPHeap{}
+	r.Insert(Int(1))
+	if r.Meld(nil) != r || r.Len() != 1 {
+		t.Error("expected Meld(nil) to leave the RPHeap unchanged")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	h := NewOrdered[int]()
+	for _, number := range []int{5, 3, 8, 1, 9, 4} {
+		h.Insert(number)
+	}
+	h.DeleteMin()
+	if err := h.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	h.head.rank++
+	if h.Validate() == nil {
+		t.Fail()
+	}
+}
+
+func TestValidateType2(t *testing.T) {
+	h := NewOrdered[int](WithRankRule(Type2))
+	handles := make([]*Handle[int], 64)
+	for i := range handles {
+		handles[i] = h.InsertHandle(i)
+	}
+	h.DeleteMin()
+	for i := 63; i > 32; i -= 3 {
+		h.DecreaseKey(handles[i], -i)
+	}
+	if err := h.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	// the ranks after the cuts do not follow the type-1 rule
+	h.rule = Type1
+	if h.Validate() == nil {
+		t.Fail()
+	}
+}
+
+func TestIterable(t *testing.T) {
+	heaptest.RunIterableTests(t, func() heaptest.IterableHeap { return NewOrdered[int]() })
+	for name, opts := range variants {
+		t.Run(name, func(t *testing.T) {
+			heaptest.RunIterableTests(t, func() heaptest.IterableHeap { return NewOrdered[int](opts...) })
+		})
+	}
+}
+
+func TestInsertAll(t *testing.T) {
+	heaptest.RunBulkTests(t, func() heaptest.BulkHeap { return NewOrdered[int]() })
+	for name, opts := range variants {
+		t.Run(name, func(t *testing.T) {
+			heaptest.RunBulkTests(t, func() heaptest.BulkHeap { return NewOrdered[int](opts...) })
+		})
+	}
+}
+
+func TestFromSlice(t *testing.T) {
+	h := FromSliceOrdered([]int{5, 3, 8, 1}, WithRankRule(Type2))
+	if h.RankRule() != Type2 {
+		t.Errorf("expected type-2, got %d", h.RankRule())
+	}
+	if got := slices.Collect(h.Sorted()); !slices.Equal(got, []int{1, 3, 5, 8}) {
+		t.Errorf("expected [1 3 5 8], got %v", got)
+	}
+	r := FromSlice([]heap.Item{Int(2), Int(1)})
+	if res := r.DeleteMin(); res != Int(1) {
+		t.Errorf("expected 1, got %v", res)
+	}
+}
+
+func TestClone(t *testing.T) {
+	heaptest.RunCloneTests(t, func() *Heap[int] { return NewOrdered[int]() }, (*Heap[int]).Clone)
+	for name, opts := range variants {
+		t.Run(name, func(t *testing.T) {
+			heaptest.RunCloneTests(t, func() *Heap[int] { return NewOrdered[int](opts...) }, (*Heap[int]).Clone)
+		})
+	}
+	r := New(WithRankRule(Type2))
+	r.Insert(Int(2))
+	c := r.Clone()
+	r.Insert(Int(1))
+	if c.RankRule() != Type2 {
+		t.Errorf("expected type-2, got %d", c.RankRule())
+	}
+	if res := c.FindMin(); res != Int(2) {
+		t.Errorf("expected 2, got %v", res)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	test := func(t *testing.T, opts ...Option) {
+		heaptest.RunSnapshotTests[*Heap[int], *Handle[int]](t, func() *Heap[int] { return NewOrdered[int](opts...) })
+
+		h := FromSliceOrdered([]int{1, 2, 3, 4, 5, 6, 7, 8}, opts...)
+		h.DeleteMin()
+		h.Insert(1)
+		s := h.Snapshot()
+		if h.first != s.first || h.head != s.head {
+			t.Fatal("expected the snapshot to share the roots")
+		}
+		// deleting, adjusting and melding copy the shared nodes
+		h.Delete(6)
+		h.Adjust(3, 9)
+		h.Meld(FromSliceOrdered([]int{0, 10}, opts...))
+		h.DeleteMin()
+		if got := slices.Collect(s.Sorted()); !slices.Equal(got, []int{1, 2, 3, 4, 5, 6, 7, 8}) {
+			t.Errorf("expected the snapshot to keep %v, got %v", []int{1, 2, 3, 4, 5, 6, 7, 8}, got)
+		}
+		if got := slices.Collect(h.Sorted()); !slices.Equal(got, []int{1, 2, 4, 5, 7, 8, 9, 10}) {
+			t.Errorf("expected %v, got %v", []int{1, 2, 4, 5, 7, 8, 9, 10}, got)
+		}
+		for _, x := range []*Heap[int]{h, s} {
+			if err := x.Validate(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	test(t)
+	for name, opts := range variants {
+		t.Run(name, func(t *testing.T) { test(t, opts...) })
+	}
+	r := New(WithRankRule(Type2))
+	r.Insert(Int(2))
+	s := r.Snapshot()
+	r.Insert(Int(1))
+	if s.RankRule() != Type2 {
+		t.Errorf("expected type-2, got %d", s.RankRule())
+	}
+	if res := s.FindMin(); res != Int(2) {
+		t.Errorf("expected 2, got %v", res)
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	heaptest.RunEncodingTests(t, func() *Heap[int] { return NewOrdered[int]() }, (*Heap[int]).MarshalBinary)
+	for name, opts := range variants {
+		t.Run(name, func(t *testing.T) {
+			heaptest.RunEncodingTests(t, func() *Heap[int] { return NewOrdered[int](opts...) }, (*Heap[int]).MarshalBinary)
+		})
+	}
+}
+
+// halfOrdered checks the rank of n and that its left child, which comes
+// first in the binary form of its half tree, is not less than n.
+func halfOrdered(n *heap.TreeNode[int]) error {
+	if len(n.Attrs) != 1 || n.Attrs[0].Name != "rank" || n.Attrs[0].Value.(int) < 0 {
+		return fmt.Errorf("attributes %v, want a rank", n.Attrs)
+	}
+	if len(n.Children) > 0 && n.Children[0] != nil && n.Children[0].Item < n.Item {
+		return fmt.Errorf("left child %d is less than its parent", n.Children[0].Item)
+	}
+	return nil
+}
+
+func TestJSON(t *testing.T) {
+	heaptest.RunJSONTests(t, func() *Heap[int] { return NewOrdered[int]() }, halfOrdered)
+	for name, opts := range variants {
+		t.Run(name, func(t *testing.T) {
+			heaptest.RunJSONTests(t, func() *Heap[int] { return NewOrdered[int](opts...) }, halfOrdered)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package rank_paring
+
+import (
+	"errors"
+	"fmt"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// Heap implements the Validator interface
+var _ heap.Validator = (*Heap[int])(nil)
+
+// Validate checks that head is the smallest root, that last is the tail of
+// the root list, that every half tree is half ordered and points to its
+// parents, unless the Heap has snapshots, that the ranks follow the rank
+// rule of the heap and that the Heap holds Len items.
+// Complexity: O(n)
+func (r *Heap[T]) Validate() error {
+	if r.head == nil {
+		if r.size != 0 {
+			return fmt.Errorf("rank_pairing: empty heap has size %d", r.size)
+		}
+		if r.first != nil || r.last != nil {
+			return errors.New("rank_pairing: empty heap has roots")
+		}
+		return nil
+	}
+	count := 0
+	headFound := false
+	var last *node[T]
+	for root := r.first; root != nil; root = root.next {
+		if count++; count > r.size {
+			return fmt.Errorf("rank_pairing: more than %d nodes or a cycle at %v", r.size, root.item)
+		}
+		if root.parent != nil {
+			return fmt.Errorf("rank_pairing: root %v has a parent", root.item)
+		}
+		if r.less(root, r.head) {
+			return fmt.Errorf("rank_pairing: root %v is smaller than the minimum %v", root.item, r.head.item)
+		}
+		if want := getrank(root.left) + 1; root.rank != want {
+			return fmt.Errorf("rank_pairing: root %v has rank %d, want %d", root.item, root.rank, want)
+		}
+		if err := r.validate(root.left, root, root, &count); err != nil {
+			return err
+		}
+		headFound = headFound || root == r.head
+		last = root
+	}
+	if !headFound {
+		return errors.New("rank_pairing: head is not in the root list")
+	}
+	if last != r.last {
+		return errors.New("rank_pairing: last does not point to the tail of the root list")
+	}
+	if count != r.size {
+		return fmt.Errorf("rank_pairing: heap has %d nodes but size %d", count, r.size)
+	}
+	return nil
+}
+
+// validate checks the subtree rooted at n, whose parent is parent and
+// whose items must not be smaller than the item of min.
+func (r *Heap[T]) validate(n, parent, min *node[T], count *int) error {
+	if n == nil {
+		return nil
+	}
+	if *count++; *count > r.size {
+		return fmt.Errorf("rank_pairing: more than %d nodes or a cycle at %v", r.size, n.item)
+	}
+	if n.parent != parent && r.owner == nil {
+		return fmt.Errorf("rank_pairing: node %v does not point to its parent", n.item)
+	}
+	if n.nInf {
+		return fmt.Errorf("rank_pairing: node %v is marked as negative infinity", n.item)
+	}
+	if r.compare(n.item, min.item) < 0 {
+		return fmt.Errorf("rank_pairing: node %v is smaller than its ancestor %v", n.item, min.item)
+	}
+	if want := r.rankOf(n); n.rank != want {
+		return fmt.Errorf("rank_pairing: node %v has rank %d, want %d", n.item, n.rank, want)
+	}
+	if err := r.validate(n.left, n, n, count); err != nil {
+		return err
+	}
+	return r.validate(n.next, n, min, count)
+}
@@ -0,0 +1,267 @@
+package skew
+
+import (
+	"cmp"
+	"fmt"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// BottomUp implements the Heap interface
+var _ heap.Heap[int] = (*BottomUp[int])(nil)
+
+// BottomUp implements the Validator interface
+var _ heap.Validator = (*BottomUp[int])(nil)
+
+// upNode is a leaf in a bottom-up skew heap. The root of every subtree,
+// which is the root of the heap or a left child, points up to the bottom
+// of its right path, and every right child points up to its parent. This
+// lets a meld walk the right paths from the bottom. A heap with snapshots
+// does not keep up.
+type upNode[T any] struct {
+	item        T
+	left, right *upNode[T]
+	up          *upNode[T]
+	// owner is the heap that may modify the node in place once the heap
+	// has snapshots
+	owner *owner
+}
+
+// BottomUp is a bottom-up skew heap over values of type T. It melds the
+// right paths of two heaps starting from their largest items and stops as
+// soon as one of them runs out, which makes Insert and Meld O(1) amortized.
+// The zero value for BottomUp is an empty heap ordered by go_heaps.Compare.
+//
+// Reference: D. D. Sleator and R. E. Tarjan, "Self-Adjusting Heaps",
+// SIAM Journal on Computing 15(1), 1986.
+type BottomUp[T any] struct {
+	root *upNode[T]
+	size int
+	cmp  func(a, b T) int
+	// owner is set once the heap has snapshots
+	owner *owner
+}
+
+func (h *BottomUp[T]) compare(a, b T) int {
+	if h.cmp == nil {
+		return heap.Compare(a, b)
+	}
+	return h.cmp(a, b)
+}
+
+// Init initializes or clears the BottomUp heap
+func (h *BottomUp[T]) Init() *BottomUp[T] {
+	h.root = nil
+	h.size = 0
+	h.owner = nil
+	return h
+}
+
+// NewBottomUpFunc returns an initialized BottomUp heap ordered by compare.
+func NewBottomUpFunc[T any](compare func(a, b T) int) *BottomUp[T] {
+	return (&BottomUp[T]{cmp: compare}).Init()
+}
+
+// NewBottomUpOrdered returns an initialized BottomUp heap of ordered values.
+func NewBottomUpOrdered[T cmp.Ordered]() *BottomUp[T] {
+	return NewBottomUpFunc(cmp.Compare[T])
+}
+
+// meld merges the heaps rooted at a and b. It repeatedly takes the larger
+// of the two bottoms of their right paths and puts it on top of the merged
+// part m, which becomes its left child while its old left child moves to
+// the right. Once the right path of one heap is used up, m is hung below
+// the bottom of the other right path. On a heap with snapshots it merges
+// top-down instead.
+func (h *BottomUp[T]) meld(a, b *upNode[T]) *upNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.owner != nil {
+		return h.merge(a, b)
+	}
+	bottomA, bottomB := a.up, b.up
+	var m *upNode[T]
+	for {
+		// take the items from the right path of a
+		if h.compare(bottomA.item, bottomB.item) < 0 {
+			a, b = b, a
+			bottomA, bottomB = bottomB, bottomA
+		}
+		x, next := bottomA, bottomA.up
+		l := x.left
+		x.left, x.right = m, l
+		if l == nil {
+			x.up = x
+		} else {
+			x.up, l.up = l.up, x
+		}
+		m = x
+		if x == a {
+			break
+		}
+		bottomA = next
+	}
+	bottomB.right = m
+	m.up, b.up = bottomB, m.up
+	return b
+}
+
+// merge merges x and y top-down like Heap.merge, copying the nodes on the
+// merge path that the heap does not own. It leaves up as it is.
+func (h *BottomUp[T]) merge(x, y *upNode[T]) *upNode[T] {
+	var root *upNode[T]
+	link := &root
+	for x != nil && y != nil {
+		// x should point to the smaller item
+		if h.compare(x.item, y.item) > 0 {
+			x, y = y, x
+		}
+		x = h.mutable(x)
+		*link = x
+		rest := x.right
+		x.right = x.left
+		link, x = &x.left, rest
+	}
+	if x == nil {
+		x = y
+	}
+	*link = x
+	return root
+}
+
+// Insert adds an item into the heap.
+// The complexity is O(1) amortized, or O(log n) amortized on a heap with
+// snapshots.
+func (h *BottomUp[T]) Insert(v T) T {
+	n := &upNode[T]{item: v, owner: h.owner}
+	n.up = n
+	h.root = h.meld(h.root, n)
+	h.size++
+	heap.DebugValidate(h)
+
+	return v
+}
+
+// DeleteMin deletes the minimum value and returns it.
+// It returns the zero value of T if the heap is empty.
+// The complexity is O(log n) amortized.
+func (h *BottomUp[T]) DeleteMin() T {
+	v := h.root
+	if v == nil {
+		var zero T
+		return zero
+	}
+	// the right child becomes a root and has to point to its bottom
+	if v.right != nil && h.owner == nil {
+		v.right.up = v.up
+	}
+	h.root = h.meld(v.left, v.right)
+	h.size--
+	heap.DebugValidate(h)
+
+	return v.item
+}
+
+// FindMin finds the minimum value.
+func (h *BottomUp[T]) FindMin() T {
+	if h.root == nil {
+		var zero T
+		return zero
+	}
+	return h.root.item
+}
+
+// Meld moves all items of a into the heap. If a has snapshots and the heap
+// does not, Meld melds a Clone of a, whose up pointers it needs; this
+// takes O(m).
+// The complexity is O(1) amortized, or O(log n) amortized on a heap with
+// snapshots.
+func (h *BottomUp[T]) Meld(a heap.Heap[T]) heap.Heap[T] {
+	if a == nil {
+		return h
+	}
+	switch o := a.(type) {
+	case *BottomUp[T]:
+		if o == h || o.root == nil {
+			return h
+		}
+		src := o
+		if o.owner != nil && h.owner == nil {
+			src = o.Clone()
+		}
+		h.root = h.meld(h.root, src.root)
+		h.size += o.size
+		o.Init()
+		heap.DebugValidate(h)
+	default:
+		panic(fmt.Sprintf("unexpected type %T", a))
+	}
+	return h
+}
+
+// Len returns the number of items in the heap.
+func (h *BottomUp[T]) Len() int {
+	return h.size
+}
+
+// IsEmpty returns true if the heap has no items.
+func (h *BottomUp[T]) IsEmpty() bool {
+	return h.root == nil
+}
+
+// Clear removes all items from the heap.
+func (h *BottomUp[T]) Clear() {
+	h.Init()
+}
+
+// Validate checks the heap order, that every right child points up to its
+// parent and that every other node points up to the bottom of its right
+// path. It does not check up on a heap with snapshots, which does not keep
+// it.
+// The complexity is O(n).
+func (h *BottomUp[T]) Validate() error {
+	count := 0
+	if err := h.validate(h.root, &count); err != nil {
+		return err
+	}
+	if count != h.size {
+		return fmt.Errorf("skew: heap has %d nodes but size %d", count, h.size)
+	}
+	return nil
+}
+
+// validate checks the subtree whose root n is not a right child.
+func (h *BottomUp[T]) validate(n *upNode[T], count *int) error {
+	if n == nil {
+		return nil
+	}
+	bottom := n
+	for x := n; x != nil; x = x.right {
+		if *count++; *count > h.size {
+			return fmt.Errorf("skew: more than %d nodes or a cycle at %v", h.size, x.item)
+		}
+		if x.right != nil {
+			if x.right.up != x && h.owner == nil {
+				return fmt.Errorf("skew: right child %v does not point to its parent %v", x.right.item, x.item)
+			}
+			if h.compare(x.right.item, x.item) < 0 {
+				return fmt.Errorf("skew: child %v is smaller than its parent %v", x.right.item, x.item)
+			}
+		}
+		if x.left != nil && h.compare(x.left.item, x.item) < 0 {
+			return fmt.Errorf("skew: child %v is smaller than its parent %v", x.left.item, x.item)
+		}
+		if err := h.validate(x.left, count); err != nil {
+			return err
+		}
+		bottom = x
+	}
+	if n.up != bottom && h.owner == nil {
+		return fmt.Errorf("skew: node %v does not point to the bottom %v of its right path", n.item, bottom.item)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package skew
+
+import (
+	"cmp"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// FromSliceFunc returns a Heap ordered by compare that holds items.
+// The complexity is O(n).
+func FromSliceFunc[T any](items []T, compare func(a, b T) int) *Heap[T] {
+	h := NewFunc(compare)
+	h.InsertAll(items)
+	return h
+}
+
+// FromSliceOrdered returns a Heap of ordered values that holds items.
+// The complexity is O(n).
+func FromSliceOrdered[T cmp.Ordered](items []T) *Heap[T] {
+	return FromSliceFunc(items, cmp.Compare[T])
+}
+
+// FromSlice returns a SkewHeap that holds items.
+// The complexity is O(n).
+func FromSlice(items []heap.Item) *SkewHeap {
+	h := New()
+	h.InsertAll(items)
+	return h
+}
+
+// InsertAll inserts items into the heap. It keeps a queue of heaps that
+// starts with one heap per item, repeatedly merges the two heaps at its
+// front into one at its back and merges the last one into the heap.
+// The complexity is O(k + log n) amortized for k items.
+func (h *Heap[T]) InsertAll(items []T) {
+	if len(items) == 0 {
+		return
+	}
+	queue := make([]*node[T], len(items))
+	for i, item := range items {
+		queue[i] = &node[T]{item: item, owner: h.owner}
+	}
+	for len(queue) > 1 {
+		queue = append(queue[2:], h.merge(queue[0], queue[1]))
+	}
+	h.setRoot(h.merge(queue[0], h.root))
+	h.size += len(items)
+	heap.DebugValidate(h)
+}
+
+// InsertAll inserts items into the heap. Insert is O(1) amortized, so it
+// inserts them one by one.
+// The complexity is O(k) amortized for k items.
+func (h *BottomUp[T]) InsertAll(items []T) {
+	for _, item := range items {
+		n := &upNode[T]{item: item, owner: h.owner}
+		n.up = n
+		h.root = h.meld(h.root, n)
+	}
+	h.size += len(items)
+	heap.DebugValidate(h)
+}
@@ -0,0 +1,198 @@
+package skew
+
+import (
+	heap "github.com/theodesp/go-heaps"
+)
+
+// owner identifies the heap that may modify a node in place. Heaps that
+// share nodes after a Snapshot have different owners, so each of them
+// copies a shared node before it modifies it.
+type owner struct {
+	_ byte
+}
+
+// mutable returns n if the heap owns it or has no snapshots and a copy of
+// n owned by the heap otherwise.
+func (h *Heap[T]) mutable(n *node[T]) *node[T] {
+	if h.owner == nil || n.owner == h.owner {
+		return n
+	}
+	c := *n
+	c.owner = h.owner
+	return &c
+}
+
+// checkHandles panics if the heap has snapshots, since handles need the
+// parent pointers that such heaps do not keep.
+func (h *Heap[T]) checkHandles() {
+	if h.owner != nil {
+		panic("handles are disabled on a heap with snapshots")
+	}
+}
+
+// Clone returns a deep copy of the heap. Handles of the heap do not refer
+// to items of the copy. The copy has no snapshots, so it supports handles
+// even if the heap does not.
+// The complexity is O(n).
+func (h *Heap[T]) Clone() *Heap[T] {
+	return &Heap[T]{root: cloneTree(h.root), size: h.size, cmp: h.cmp}
+}
+
+// Snapshot returns a copy of the heap that shares all nodes with it.
+// Afterwards both heaps copy a shared node before they modify it, so each
+// operation copies only the nodes on the paths it changes. Shared nodes
+// cannot point to a single parent, so both heaps disable handles until
+// they are cleared: InsertHandle, Remove, DecreaseKey and IncreaseKey
+// panic, and Delete and Adjust copy the path from the root to the item
+// instead.
+//
+// The bounds of a skew heap are amortized over a sequence of operations
+// on one heap. Repeating an expensive operation on the same version from
+// several snapshots repeats its cost, which can be O(n) each time.
+// The complexity is O(1).
+func (h *Heap[T]) Snapshot() *Heap[T] {
+	h.owner = new(owner)
+	return &Heap[T]{root: h.root, size: h.size, cmp: h.cmp, owner: new(owner)}
+}
+
+// Clone returns a deep copy of the SkewHeap.
+// The complexity is O(n).
+func (h *SkewHeap) Clone() *SkewHeap {
+	return &SkewHeap{*h.Heap.Clone()}
+}
+
+// Snapshot returns a copy of the SkewHeap that shares all nodes with it,
+// like Heap.Snapshot.
+// The complexity is O(1).
+func (h *SkewHeap) Snapshot() *SkewHeap {
+	return &SkewHeap{*h.Heap.Snapshot()}
+}
+
+// findPath returns the path from the root to a node holding item, or nil
+// if there is none.
+func (h *Heap[T]) findPath(item T) []*node[T] {
+	type visit struct {
+		n     *node[T]
+		depth int
+	}
+	var path []*node[T]
+	stack := []visit{{h.root, 0}}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if v.n == nil {
+			continue
+		}
+		path = append(path[:v.depth], v.n)
+		if h.compare(v.n.item, item) == 0 {
+			return path
+		}
+		stack = append(stack, visit{v.n.right, v.depth + 1}, visit{v.n.left, v.depth + 1})
+	}
+	return nil
+}
+
+// removePath removes the last node of path, which leads to it from the
+// root, and returns its item. It copies the ancestors it changes, so it
+// works on a heap with snapshots.
+// The complexity is O(log n) amortized plus the length of path.
+func (h *Heap[T]) removePath(path []*node[T]) T {
+	n := path[len(path)-1]
+	sub := h.merge(n.right, n.left)
+	for i := len(path) - 2; i >= 0; i-- {
+		p := h.mutable(path[i])
+		if p.left == path[i+1] {
+			p.left = sub
+		} else {
+			p.right = sub
+		}
+		sub = p
+	}
+	h.setRoot(sub)
+	h.size--
+	heap.DebugValidate(h)
+	return n.item
+}
+
+// cloneTree returns a copy of the tree rooted at n. It keeps the nodes
+// still to copy on a stack, since the paths of a skew heap can be as long
+// as the heap.
+func cloneTree[T any](n *node[T]) *node[T] {
+	if n == nil {
+		return nil
+	}
+	root := &node[T]{item: n.item}
+	// pairs of a node and its copy
+	stack := []*node[T]{n, root}
+	for len(stack) > 0 {
+		from, to := stack[len(stack)-2], stack[len(stack)-1]
+		stack = stack[:len(stack)-2]
+		if from.left != nil {
+			to.left = &node[T]{item: from.left.item, parent: to}
+			stack = append(stack, from.left, to.left)
+		}
+		if from.right != nil {
+			to.right = &node[T]{item: from.right.item, parent: to}
+			stack = append(stack, from.right, to.right)
+		}
+	}
+	return root
+}
+
+// mutable returns n if the heap owns it or has no snapshots and a copy of
+// n owned by the heap otherwise.
+func (h *BottomUp[T]) mutable(n *upNode[T]) *upNode[T] {
+	if h.owner == nil || n.owner == h.owner {
+		return n
+	}
+	c := *n
+	c.owner = h.owner
+	return &c
+}
+
+// Snapshot returns a copy of the heap that shares all nodes with it.
+// Afterwards both heaps copy a shared node before they modify it. Shared
+// nodes cannot point up to the bottoms of the right paths of both heaps,
+// so both heaps merge top-down like Heap until they are cleared, which
+// makes Insert and Meld O(log n) amortized.
+// The complexity is O(1).
+func (h *BottomUp[T]) Snapshot() *BottomUp[T] {
+	h.owner = new(owner)
+	return &BottomUp[T]{root: h.root, size: h.size, cmp: h.cmp, owner: new(owner)}
+}
+
+// Clone returns a deep copy of the heap. The copy has no snapshots, so it
+// melds bottom-up even if the heap does not.
+// The complexity is O(n).
+func (h *BottomUp[T]) Clone() *BottomUp[T] {
+	c := &BottomUp[T]{size: h.size, cmp: h.cmp}
+	if h.root == nil {
+		return c
+	}
+	c.root = &upNode[T]{item: h.root.item}
+	// the root and the left children start right paths
+	heads := []*upNode[T]{c.root}
+	// pairs of a node and its copy
+	stack := []*upNode[T]{h.root, c.root}
+	for len(stack) > 0 {
+		from, to := stack[len(stack)-2], stack[len(stack)-1]
+		stack = stack[:len(stack)-2]
+		if from.left != nil {
+			to.left = &upNode[T]{item: from.left.item}
+			heads = append(heads, to.left)
+			stack = append(stack, from.left, to.left)
+		}
+		if from.right != nil {
+			to.right = &upNode[T]{item: from.right.item, up: to}
+			stack = append(stack, from.right, to.right)
+		}
+	}
+	for _, head := range heads {
+		bottom := head
+		for bottom.right != nil {
+			bottom = bottom.right
+		}
+		head.up = bottom
+	}
+	return c
+}
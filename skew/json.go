@@ -0,0 +1,85 @@
+package skew
+
+import (
+	"encoding/json"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+var (
+	// Heap implements the Marshaler and Unmarshaler interfaces
+	_ json.Marshaler   = (*Heap[int])(nil)
+	_ json.Unmarshaler = (*Heap[int])(nil)
+	// BottomUp implements the Marshaler and Unmarshaler interfaces
+	_ json.Marshaler   = (*BottomUp[int])(nil)
+	_ json.Unmarshaler = (*BottomUp[int])(nil)
+	// Heap and BottomUp have a tree view
+	_ heap.TreeViewer[int] = (*Heap[int])(nil)
+	_ heap.TreeViewer[int] = (*BottomUp[int])(nil)
+)
+
+// MarshalJSON encodes the items of the heap in no particular order as a
+// go_heaps.JSONView without the tree view.
+// The complexity is O(n).
+func (h *Heap[T]) MarshalJSON() ([]byte, error) {
+	return heap.ViewOf[T](h, false).MarshalJSON()
+}
+
+// UnmarshalJSON replaces the items of the heap with the items of the
+// go_heaps.JSONView encoded in data. The heap keeps its order and builds
+// itself with InsertAll.
+// The complexity is O(n).
+func (h *Heap[T]) UnmarshalJSON(data []byte) error {
+	var v heap.JSONView[T]
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	h.Clear()
+	h.InsertAll(v.Items)
+	return nil
+}
+
+// Tree returns the tree of the heap. A node that has a child lists both its
+// left and right child, null where missing. The nodes have no attributes.
+// The complexity is O(n).
+func (h *Heap[T]) Tree() []*heap.TreeNode[T] {
+	return heap.TreeOf(h.roots, func(n *node[T], visit func(c *node[T])) {
+		if n.left != nil || n.right != nil {
+			visit(n.left)
+			visit(n.right)
+		}
+	}, itemOf[T], nil)
+}
+
+// MarshalJSON encodes the items of the heap in no particular order as a
+// go_heaps.JSONView without the tree view.
+// The complexity is O(n).
+func (h *BottomUp[T]) MarshalJSON() ([]byte, error) {
+	return heap.ViewOf[T](h, false).MarshalJSON()
+}
+
+// UnmarshalJSON replaces the items of the heap with the items of the
+// go_heaps.JSONView encoded in data. The heap keeps its order and builds
+// itself with InsertAll.
+// The complexity is O(n).
+func (h *BottomUp[T]) UnmarshalJSON(data []byte) error {
+	var v heap.JSONView[T]
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	h.Clear()
+	h.InsertAll(v.Items)
+	return nil
+}
+
+// Tree returns the tree of the heap like Heap.Tree does. The up links of
+// the right paths are not shown.
+// The complexity is O(n).
+func (h *BottomUp[T]) Tree() []*heap.TreeNode[T] {
+	return heap.TreeOf(h.roots, func(n *upNode[T], visit func(c *upNode[T])) {
+		if n.left != nil || n.right != nil {
+			visit(n.left)
+			visit(n.right)
+		}
+	}, upItemOf[T], nil)
+}
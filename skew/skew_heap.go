@@ -6,72 +6,355 @@
 package skew
 
 import (
+	"cmp"
+	"fmt"
+
 	heap "github.com/theodesp/go-heaps"
 )
 
+// SkewHeap implements the Extended interface
+var _ heap.Extended = (*SkewHeap)(nil)
+
+// Heap implements the ExtendedHeap interface
+var _ heap.ExtendedHeap[int] = (*Heap[int])(nil)
+
 // Node is a leaf in the heap.
-type node struct {
-	item        heap.Item
-	right, left *node
+type node[T any] struct {
+	item                T
+	right, left, parent *node[T]
+	// owner is the heap that may modify the node in place once the heap
+	// has snapshots
+	owner *owner
 }
 
-func merge(x, y *node) *node {
-	if x == nil {
-		return y
+// merge merges x and y top-down without recursion, since the right paths
+// of a skew heap can be as long as the heap. Every node on the merge path
+// has its children swapped and the rest of the merge continues on its left.
+func (h *Heap[T]) merge(x, y *node[T]) *node[T] {
+	var root, parent *node[T]
+	link := &root
+	for x != nil && y != nil {
+		// x should point to the smaller item
+		if h.compare(x.item, y.item) > 0 {
+			x, y = y, x
+		}
+		x = h.mutable(x)
+		x.parent = parent
+		*link = x
+		rest := x.right
+		x.right = x.left
+		parent, link, x = x, &x.left, rest
 	}
-
-	if y == nil {
-		return x
-	}
-	// x should point to the smaller item
-	if x.item.Compare(y.item) > 0 {
-		x, y = y, x
+	if x == nil {
+		x = y
 	}
+	*link = x
+	h.setParent(x, parent)
 
-	x.left, x.right = x.right, x.left
-	x.left = merge(y, x.left)
+	return root
+}
 
-	return x
+// Heap is a skew heap implementation over values of type T.
+// The zero value for Heap is an empty Heap ordered by go_heaps.Compare.
+type Heap[T any] struct {
+	root *node[T]
+	size int
+	cmp  func(a, b T) int
+	// owner is set once the heap has snapshots
+	owner *owner
 }
 
 // SkewHeap is a skew heap implementation.
 type SkewHeap struct {
-	root *node
+	Heap[heap.Item]
+}
+
+func (h *Heap[T]) compare(a, b T) int {
+	if h.cmp == nil {
+		return heap.Compare(a, b)
+	}
+	return h.cmp(a, b)
+}
+
+// Init initializes or clears the Heap. A cleared Heap has no snapshots
+// and supports handles again.
+func (h *Heap[T]) Init() *Heap[T] {
+	h.root = nil
+	h.size = 0
+	h.owner = nil
+	return h
 }
 
+// NewFunc returns an initialized Heap ordered by compare.
+func NewFunc[T any](compare func(a, b T) int) *Heap[T] {
+	return (&Heap[T]{cmp: compare}).Init()
+}
+
+// NewOrdered returns an initialized Heap of ordered values.
+func NewOrdered[T cmp.Ordered]() *Heap[T] { return NewFunc(cmp.Compare[T]) }
+
 // Init initializes or clears the SkewHeap
 func (h *SkewHeap) Init() *SkewHeap {
-	h.root = &node{}
+	h.Heap.Init()
 	return h
 }
 
 // New returns an initialized SkewHeap.
 func New() *SkewHeap { return new(SkewHeap).Init() }
 
-// Insert adds an item into the heap.
-func (h *SkewHeap) Insert(v heap.Item) heap.Item {
-	h.root = merge(&node{
-		item: v,
-	}, h.root)
+// NewWithComparator returns an initialized SkewHeap ordered by compare
+// instead of Item.Compare.
+func NewWithComparator(compare func(a, b heap.Item) int) *SkewHeap {
+	h := &SkewHeap{}
+	h.cmp = compare
+	return h.Init()
+}
+
+// setRoot makes n the root of the heap.
+func (h *Heap[T]) setRoot(n *node[T]) {
+	h.root = n
+	h.setParent(n, nil)
+}
 
+// setParent points n, which may be missing, to its parent p. Heaps with
+// snapshots share nodes between different parents and keep no parent
+// pointers.
+func (h *Heap[T]) setParent(n, p *node[T]) {
+	if n != nil && h.owner == nil {
+		n.parent = p
+	}
+}
+
+// Insert adds an item into the heap.
+func (h *Heap[T]) Insert(v T) T {
+	h.insert(&node[T]{item: v, owner: h.owner})
 	return v
 }
 
+// Handle is an opaque reference to an item inserted with InsertHandle.
+// It stays valid until the item is removed from the heap.
+type Handle[T any] node[T]
+
+// Item returns the item referenced by the Handle.
+func (hd *Handle[T]) Item() T {
+	return hd.item
+}
+
+// InsertHandle adds an item into the heap and returns a Handle to it that
+// can be passed to DecreaseKey, IncreaseKey and Remove. It panics if the
+// heap has snapshots.
+func (h *Heap[T]) InsertHandle(v T) *Handle[T] {
+	h.checkHandles()
+	n := &node[T]{item: v}
+	h.insert(n)
+	return (*Handle[T])(n)
+}
+
+func (h *Heap[T]) insert(n *node[T]) {
+	h.setRoot(h.merge(n, h.root))
+	h.size++
+	heap.DebugValidate(h)
+}
+
 // DeleteMin deletes the minimum value and returns it.
-func (h *SkewHeap) DeleteMin() heap.Item {
-	v := h.root
+// It returns the zero value of T if the heap is empty.
+func (h *Heap[T]) DeleteMin() T {
+	if h.root == nil {
+		var zero T
+		return zero
+	}
+	if h.owner != nil {
+		return h.removePath([]*node[T]{h.root})
+	}
+	return h.Remove((*Handle[T])(h.root))
+}
+
+// Remove deletes the item referenced by hd from the heap and returns it.
+// It panics if the heap has snapshots.
+// The complexity is O(log n) amortized.
+func (h *Heap[T]) Remove(hd *Handle[T]) T {
+	h.checkHandles()
+	n := (*node[T])(hd)
+	h.replace(n, h.merge(n.right, n.left))
+	n.left, n.right = nil, nil
+	h.size--
+	heap.DebugValidate(h)
+	return n.item
+}
+
+// DecreaseKey replaces the item referenced by hd with a smaller item.
+// It panics if the heap has snapshots.
+// The complexity is O(log n) amortized.
+func (h *Heap[T]) DecreaseKey(hd *Handle[T], v T) {
+	h.checkHandles()
+	n := (*node[T])(hd)
+	if h.compare(v, n.item) > 0 {
+		panic("new item is greater than the previous one")
+	}
+	n.item = v
+	if n.parent != nil && h.compare(v, n.parent.item) < 0 {
+		h.replace(n, nil)
+		h.setRoot(h.merge(h.root, n))
+	}
+	heap.DebugValidate(h)
+}
+
+// IncreaseKey replaces the item referenced by hd with a greater item.
+// It panics if the heap has snapshots.
+// The complexity is O(log n) amortized.
+func (h *Heap[T]) IncreaseKey(hd *Handle[T], v T) {
+	h.checkHandles()
+	n := (*node[T])(hd)
+	if h.compare(v, n.item) < 0 {
+		panic("new item is smaller than the previous one")
+	}
+	h.Remove(hd)
+	n.item = v
+	h.insert(n)
+}
+
+// replace puts sub in the place of n.
+func (h *Heap[T]) replace(n, sub *node[T]) {
+	p := n.parent
+	n.parent = nil
+	if sub != nil {
+		sub.parent = p
+	}
+	switch {
+	case p == nil:
+		h.root = sub
+	case p.left == n:
+		p.left = sub
+	default:
+		p.right = sub
+	}
+}
+
+// Adjust replaces the item old with new and returns new.
+// The complexity is O(n) to find the item plus O(log n) amortized.
+func (h *Heap[T]) Adjust(old, new T) T {
+	if h.owner != nil {
+		path := h.findPath(old)
+		if path == nil {
+			var zero T
+			return zero
+		}
+		h.removePath(path)
+		return h.Insert(new)
+	}
+	n := h.find(h.root, old)
+	if n == nil {
+		var zero T
+		return zero
+	}
+	if h.compare(new, n.item) <= 0 {
+		h.DecreaseKey((*Handle[T])(n), new)
+	} else {
+		h.IncreaseKey((*Handle[T])(n), new)
+	}
+	return new
+}
+
+// Delete removes item from the heap and returns it.
+// The complexity is O(n) to find the item plus O(log n) amortized.
+func (h *Heap[T]) Delete(item T) T {
+	if h.owner != nil {
+		path := h.findPath(item)
+		if path == nil {
+			var zero T
+			return zero
+		}
+		return h.removePath(path)
+	}
+	n := h.find(h.root, item)
+	if n == nil {
+		var zero T
+		return zero
+	}
+	return h.Remove((*Handle[T])(n))
+}
 
-	h.root = merge(v.right, v.left)
+// find returns the node holding item in the subtree rooted at n or nil.
+// It uses an explicit stack since paths can be as long as the heap.
+func (h *Heap[T]) find(n *node[T], item T) *node[T] {
+	var stack []*node[T]
+	for n != nil || len(stack) > 0 {
+		if n == nil {
+			n, stack = stack[len(stack)-1], stack[:len(stack)-1]
+		}
+		if h.compare(n.item, item) == 0 {
+			return n
+		}
+		if n.right != nil {
+			stack = append(stack, n.right)
+		}
+		n = n.left
+	}
+	return nil
+}
+
+// Meld moves all items of a into the heap by merging both trees. If a has
+// snapshots and the heap does not, Meld merges a Clone of a, so that the
+// heap keeps its handles; this takes O(m).
+// The complexity is O(log n + log m) amortized.
+func (h *Heap[T]) Meld(a heap.Heap[T]) heap.Heap[T] {
+	if a == nil {
+		return h
+	}
+	switch o := a.(type) {
+	case *Heap[T]:
+		if o == h || o.root == nil {
+			return h
+		}
+		src := o
+		if o.owner != nil && h.owner == nil {
+			src = o.Clone()
+		}
+		h.setRoot(h.merge(h.root, src.root))
+		h.size += o.size
+		o.Init()
+		heap.DebugValidate(h)
+	default:
+		panic(fmt.Sprintf("unexpected type %T", a))
+	}
+	return h
+}
 
-	return v.item
+// Meld moves all items of a into the heap by merging both trees.
+// The complexity is O(log n + log m) amortized.
+func (h *SkewHeap) Meld(a heap.Interface) heap.Interface {
+	if a == nil {
+		return h
+	}
+	switch o := a.(type) {
+	case *SkewHeap:
+		h.Heap.Meld(&o.Heap)
+	default:
+		panic(fmt.Sprintf("unexpected type %T", a))
+	}
+	return h
 }
 
 // FindMin finds the minimum value.
-func (h *SkewHeap) FindMin() heap.Item {
+func (h *Heap[T]) FindMin() T {
+	if h.root == nil {
+		var zero T
+		return zero
+	}
 	return h.root.item
 }
 
+// Len returns the number of items in the heap.
+func (h *Heap[T]) Len() int {
+	return h.size
+}
+
+// IsEmpty returns true if the heap has no items.
+func (h *Heap[T]) IsEmpty() bool {
+	return h.root == nil
+}
+
 // Clear removes all items from the heap.
-func (h *SkewHeap) Clear() {
+func (h *Heap[T]) Clear() {
 	h.Init()
 }
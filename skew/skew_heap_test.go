@@ -6,10 +6,13 @@
 package skew
 
 import (
+	"fmt"
+	"slices"
 	"sort"
 	"testing"
 
 	heap "github.com/theodesp/go-heaps"
+	"github.com/theodesp/go-heaps/heaptest"
 )
 
 func TestSkewHeapInteger(t *testing.T) {
@@ -67,6 +70,82 @@ func TestSkewHeap(t *testing.T) {
 	}
 }
 
+func TestSkewHeapLen(t *testing.T) {
+	skew := &SkewHeap{}
+
+	if !skew.IsEmpty() || skew.Len() != 0 || skew.DeleteMin() != nil {
+		t.Fail()
+	}
+
+	for _, number := range []int{4, 3, 2, 5} {
+		skew.Insert(Int(number))
+	}
+	skew.DeleteMin()
+
+	if skew.IsEmpty() || skew.Len() != 3 {
+		t.Fail()
+	}
+
+	skew.Clear()
+	if !skew.IsEmpty() || skew.Len() != 0 {
+		t.Fail()
+	}
+}
+
+func TestSkewHeapMaxHeap(t *testing.T) {
+	skew := NewWithComparator(func(a, b heap.Item) int { return b.Compare(a) })
+	reversed := New()
+
+	for _, number := range []int{4, 3, 7, 5} {
+		skew.Insert(heap.Integer(number))
+		reversed.Insert(heap.Reverse(heap.Integer(number)))
+	}
+
+	for _, number := range []int{7, 5, 4, 3} {
+		if heap.Integer(number) != skew.DeleteMin() {
+			t.Fail()
+		}
+		if heap.Integer(number) != reversed.DeleteMin().(heap.Reversed).Item {
+			t.Fail()
+		}
+	}
+}
+
+func TestHeapOrdered(t *testing.T) {
+	skew := NewOrdered[int]()
+
+	numbers := []int{4, 3, -1, 5, 9}
+
+	for _, number := range numbers {
+		skew.Insert(number)
+	}
+
+	sort.Ints(numbers)
+
+	for _, number := range numbers {
+		if number != skew.DeleteMin() {
+			t.Fail()
+		}
+	}
+	if skew.FindMin() != 0 {
+		t.Fail()
+	}
+}
+
+func TestHeapFunc(t *testing.T) {
+	skew := NewFunc(func(a, b string) int { return len(a) - len(b) })
+
+	for _, str := range []string{"ccc", "a", "dddd", "bb"} {
+		skew.Insert(str)
+	}
+
+	for _, str := range []string{"a", "bb", "ccc", "dddd"} {
+		if str != skew.DeleteMin() {
+			t.Fail()
+		}
+	}
+}
+
 func Int(value int) heap.Integer {
 	return heap.Integer(value)
 }
@@ -74,3 +153,291 @@ func Int(value int) heap.Integer {
 func Str(value string) heap.String {
 	return heap.String(value)
 }
+
+func TestSkewHeapMeld(t *testing.T) {
+	skew := New()
+	other := New()
+	for _, number := range []int{4, 8, 1} {
+		skew.Insert(Int(number))
+	}
+	for _, number := range []int{7, 0, 3} {
+		other.Insert(Int(number))
+	}
+
+	skew.Meld(other)
+	if skew.Len() != 6 || !other.IsEmpty() {
+		t.Fatalf("Len() = %d, other.Len() = %d", skew.Len(), other.Len())
+	}
+	for _, number := range []int{0, 1, 3, 4, 7, 8} {
+		if Int(number) != skew.DeleteMin() {
+			t.Fail()
+		}
+	}
+}
+
+func TestHandle(t *testing.T) {
+	h := NewOrdered[int]()
+	handles := make([]*Handle[int], 10)
+	for i := range handles {
+		handles[i] = h.InsertHandle(i * 10)
+	}
+
+	h.DecreaseKey(handles[9], -5)
+	if h.FindMin() != -5 {
+		t.Fatalf("FindMin() = %d, want -5", h.FindMin())
+	}
+	h.IncreaseKey(handles[9], 95)
+	h.IncreaseKey(handles[0], 55)
+	if got := h.Remove(handles[5]); got != 50 {
+		t.Fatalf("Remove() = %d, want 50", got)
+	}
+	if handles[0].Item() != 55 {
+		t.Fail()
+	}
+
+	var got []int
+	for !h.IsEmpty() {
+		got = append(got, h.DeleteMin())
+	}
+	if !slices.Equal(got, []int{10, 20, 30, 40, 55, 60, 70, 80, 95}) {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestExtended(t *testing.T) {
+	heaptest.RunExtendedTests(t, func() heap.Extended { return New() })
+}
+
+func FuzzHeap(f *testing.F) {
+	heaptest.AddSeeds(f)
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		heaptest.FuzzExtended(t, func() heap.Extended { return New() }, ops)
+		heaptest.FuzzHandles(t, func() heaptest.HandleHeap[*Handle[int]] { return NewOrdered[int]() }, ops)
+		heaptest.FuzzInterface(t, func() heap.Interface { return &BottomUp[heap.Item]{} }, ops)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	h := NewOrdered[int]()
+	for _, number := range []int{5, 3, 8, 1, 9, 4} {
+		h.Insert(number)
+	}
+	if err := h.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	h.root.left.item = -1
+	if h.Validate() == nil {
+		t.Fail()
+	}
+}
+
+func TestBottomUpInterface(t *testing.T) {
+	heaptest.RunInterfaceTests(t, func() heap.Interface { return &BottomUp[heap.Item]{} })
+}
+
+func TestBottomUpMeld(t *testing.T) {
+	h := NewBottomUpOrdered[int]()
+	other := NewBottomUpOrdered[int]()
+	for _, number := range []int{4, 8, 1, 6} {
+		h.Insert(number)
+	}
+	for _, number := range []int{7, 0, 3, 9, 2} {
+		other.Insert(number)
+	}
+
+	h.Meld(other)
+	if h.Len() != 9 || !other.IsEmpty() {
+		t.Fatalf("Len() = %d, other.Len() = %d", h.Len(), other.Len())
+	}
+	if err := h.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	for _, number := range []int{0, 1, 2, 3, 4, 6, 7, 8, 9} {
+		if got := h.DeleteMin(); got != number {
+			t.Fatalf("DeleteMin() = %d, want %d", got, number)
+		}
+	}
+}
+
+func TestBottomUpValidate(t *testing.T) {
+	h := NewBottomUpOrdered[int]()
+	for _, number := range []int{5, 3, 8, 1, 9, 4} {
+		h.Insert(number)
+	}
+	if err := h.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	h.root.up = h.root
+	if h.Validate() == nil {
+		t.Fail()
+	}
+}
+
+// largeInputs returns sorted and reverse sorted inputs of millions of items,
+// which build the longest paths in skew heaps.
+func largeInputs(t *testing.T) map[string][]int {
+	if testing.Short() || heap.Debug {
+		t.Skip("skipping large inputs")
+	}
+	const n = 1 << 21
+	sorted := make([]int, n)
+	reversed := make([]int, n)
+	for i := range sorted {
+		sorted[i] = i
+		reversed[i] = n - 1 - i
+	}
+	return map[string][]int{"sorted": sorted, "reversed": reversed}
+}
+
+type melder interface {
+	heap.Heap[int]
+	Meld(a heap.Heap[int]) heap.Heap[int]
+}
+
+func TestLargeInputs(t *testing.T) {
+	heaps := map[string]func() melder{
+		"top-down":  func() melder { return NewOrdered[int]() },
+		"bottom-up": func() melder { return NewBottomUpOrdered[int]() },
+	}
+	for name, input := range largeInputs(t) {
+		for variant, newHeap := range heaps {
+			t.Run(name+"/"+variant, func(t *testing.T) {
+				// insert the first half and meld in the second half
+				h, other := newHeap(), newHeap()
+				for _, v := range input[:len(input)/2] {
+					h.Insert(v)
+				}
+				for _, v := range input[len(input)/2:] {
+					other.Insert(v)
+				}
+				h.Meld(other)
+				for i := range input {
+					if got := h.DeleteMin(); got != i {
+						t.Fatalf("DeleteMin() = %d, want %d", got, i)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestIterable(t *testing.T) {
+	heaptest.RunIterableTests(t, func() heaptest.IterableHeap { return NewOrdered[int]() })
+	t.Run("BottomUp", func(t *testing.T) {
+		heaptest.RunIterableTests(t, func() heaptest.IterableHeap { return NewBottomUpOrdered[int]() })
+	})
+}
+
+func TestInsertAll(t *testing.T) {
+	heaptest.RunBulkTests(t, func() heaptest.BulkHeap { return NewOrdered[int]() })
+	t.Run("BottomUp", func(t *testing.T) {
+		heaptest.RunBulkTests(t, func() heaptest.BulkHeap { return NewBottomUpOrdered[int]() })
+	})
+}
+
+func TestFromSlice(t *testing.T) {
+	h := FromSliceOrdered([]int{5, 3, 8, 1})
+	if got := slices.Collect(h.Sorted()); !slices.Equal(got, []int{1, 3, 5, 8}) {
+		t.Errorf("expected %v, got %v", []int{1, 3, 5, 8}, got)
+	}
+	s := FromSlice([]heap.Item{heap.Integer(2), heap.Integer(1)})
+	if res := s.DeleteMin(); res != heap.Integer(1) {
+		t.Errorf("expected 1, got %v", res)
+	}
+}
+
+func TestClone(t *testing.T) {
+	heaptest.RunCloneTests(t, NewOrdered[int], (*Heap[int]).Clone)
+	t.Run("BottomUp", func(t *testing.T) {
+		heaptest.RunCloneTests(t, NewBottomUpOrdered[int], (*BottomUp[int]).Clone)
+	})
+	s := New()
+	s.Insert(heap.Integer(2))
+	c := s.Clone()
+	s.Insert(heap.Integer(1))
+	if res := c.FindMin(); res != heap.Integer(2) {
+		t.Errorf("expected 2, got %v", res)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	heaptest.RunSnapshotTests[*Heap[int], *Handle[int]](t, NewOrdered[int])
+
+	h := FromSliceOrdered([]int{1, 2, 3, 4, 5, 6, 7, 8})
+	s := h.Snapshot()
+	if h.root != s.root {
+		t.Fatal("expected the snapshot to share the root")
+	}
+	// deleting, adjusting and melding copy the shared nodes
+	h.Delete(6)
+	h.Adjust(3, 9)
+	h.Meld(FromSliceOrdered([]int{0, 10}))
+	h.DeleteMin()
+	if got := slices.Collect(s.Sorted()); !slices.Equal(got, []int{1, 2, 3, 4, 5, 6, 7, 8}) {
+		t.Errorf("expected the snapshot to keep %v, got %v", []int{1, 2, 3, 4, 5, 6, 7, 8}, got)
+	}
+	if got := slices.Collect(h.Sorted()); !slices.Equal(got, []int{1, 2, 4, 5, 7, 8, 9, 10}) {
+		t.Errorf("expected %v, got %v", []int{1, 2, 4, 5, 7, 8, 9, 10}, got)
+	}
+	for _, x := range []*Heap[int]{h, s} {
+		if err := x.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("BottomUp", func(t *testing.T) {
+		heaptest.RunCloneTests(t, NewBottomUpOrdered[int], (*BottomUp[int]).Snapshot)
+
+		fromSlice := func(items []int) *BottomUp[int] {
+			h := NewBottomUpOrdered[int]()
+			h.InsertAll(items)
+			return h
+		}
+		h := fromSlice([]int{1, 2, 3, 4, 5, 6, 7, 8})
+		s := h.Snapshot()
+		if h.root != s.root {
+			t.Fatal("expected the snapshot to share the root")
+		}
+		// melding copies the shared nodes, and a clone melds bottom-up again
+		h.Meld(fromSlice([]int{0, 10}))
+		h.DeleteMin()
+		h.Insert(9)
+		c := s.Clone()
+		c.Meld(s.Snapshot())
+		if got := slices.Collect(s.Sorted()); !slices.Equal(got, []int{1, 2, 3, 4, 5, 6, 7, 8}) {
+			t.Errorf("expected the snapshot to keep %v, got %v", []int{1, 2, 3, 4, 5, 6, 7, 8}, got)
+		}
+		if got := slices.Collect(h.Sorted()); !slices.Equal(got, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}) {
+			t.Errorf("expected %v, got %v", []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, got)
+		}
+		for _, x := range []*BottomUp[int]{h, s, c} {
+			if err := x.Validate(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if c.Len() != 16 || c.owner != nil {
+			t.Errorf("expected a clone without snapshots with 16 items, got %d", c.Len())
+		}
+	})
+}
+
+func TestEncoding(t *testing.T) {
+	heaptest.RunEncodingTests(t, NewOrdered[int], (*Heap[int]).MarshalBinary)
+	t.Run("BottomUp", func(t *testing.T) {
+		heaptest.RunEncodingTests(t, NewBottomUpOrdered[int], (*BottomUp[int]).MarshalBinary)
+	})
+}
+
+func TestJSON(t *testing.T) {
+	// a node with a child lists both slots
+	binary := func(n *heap.TreeNode[int]) error {
+		if len(n.Children) != 0 && len(n.Children) != 2 || len(n.Attrs) != 0 {
+			return fmt.Errorf("%d children and attributes %v", len(n.Children), n.Attrs)
+		}
+		return nil
+	}
+	heaptest.RunJSONTests(t, NewOrdered[int], binary)
+	t.Run("BottomUp", func(t *testing.T) {
+		heaptest.RunJSONTests(t, NewBottomUpOrdered[int], binary)
+	})
+}
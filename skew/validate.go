@@ -0,0 +1,48 @@
+package skew
+
+import (
+	"fmt"
+
+	heap "github.com/theodesp/go-heaps"
+)
+
+// Heap implements the Validator interface
+var _ heap.Validator = (*Heap[int])(nil)
+
+// Validate checks the heap order, the parent pointers unless the Heap has
+// snapshots and that the Heap holds Len items.
+// The complexity is O(n).
+func (h *Heap[T]) Validate() error {
+	if h.root != nil && h.root.parent != nil && h.owner == nil {
+		return fmt.Errorf("skew: root %v has a parent", h.root.item)
+	}
+	count := 0
+	if err := h.validate(h.root, &count); err != nil {
+		return err
+	}
+	if count != h.size {
+		return fmt.Errorf("skew: heap has %d nodes but size %d", count, h.size)
+	}
+	return nil
+}
+
+func (h *Heap[T]) validate(n *node[T], count *int) error {
+	if n == nil {
+		return nil
+	}
+	if *count++; *count > h.size {
+		return fmt.Errorf("skew: more than %d nodes or a cycle at %v", h.size, n.item)
+	}
+	for _, c := range []*node[T]{n.left, n.right} {
+		if c != nil && h.compare(c.item, n.item) < 0 {
+			return fmt.Errorf("skew: child %v is smaller than its parent %v", c.item, n.item)
+		}
+		if c != nil && c.parent != n && h.owner == nil {
+			return fmt.Errorf("skew: child %v does not point to its parent %v", c.item, n.item)
+		}
+		if err := h.validate(c, count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package treap
+
+import (
+	"cmp"
+	"slices"
+
+	goheap "github.com/theodesp/go-heaps"
+)
+
+// FromSliceFunc returns a Heap ordered by compare that holds items.
+// The complexity is O(n log n) to sort the items plus O(n).
+func FromSliceFunc[T any](items []T, compare func(a, b T) int) *Heap[T] {
+	h := NewFunc(compare)
+	h.InsertAll(items)
+	return h
+}
+
+// FromSliceOrdered returns a Heap of ordered values that holds items.
+// The complexity is O(n log n) to sort the items plus O(n).
+func FromSliceOrdered[T cmp.Ordered](items []T) *Heap[T] {
+	return FromSliceFunc(items, cmp.Compare[T])
+}
+
+// FromSlice returns a Treap that holds items.
+// The complexity is O(n log n) to sort the items plus O(n).
+func FromSlice(items []goheap.Item) *Treap {
+	h := New()
+	h.InsertAll(items)
+	return h
+}
+
+// InsertAll inserts items into the Heap. It sorts a copy of the items and
+// builds a treap of them along its right spine, then unites it with the
+// Heap.
+// The complexity is O(k log k) to sort the items plus O(k) to build the
+// treap and O(k log(n/k + 1)) expected to unite it with the Heap.
+func (h *Heap[T]) InsertAll(items []T) {
+	if len(items) == 0 {
+		return
+	}
+	sorted := slices.Clone(items)
+	slices.SortStableFunc(sorted, h.compare)
+	h.Root = h.union(h.Root, build(sorted, h.own()))
+	goheap.DebugValidate(h)
+}
+
+// build returns a treap of the sorted keys. It keeps the right spine of
+// the treap built so far on a stack. A new key goes to the bottom of the
+// spine, taking the nodes of lower priority as its left subtree, which are
+// final from then on. The new nodes belong to owner.
+func build[T any](keys []T, owner *owner) *NodeOf[T] {
+	var spine []*NodeOf[T]
+	for _, key := range keys {
+		n := &NodeOf[T]{Priority: generatePriority(), Key: key, owner: owner}
+		var left *NodeOf[T]
+		for len(spine) > 0 && spine[len(spine)-1].Priority < n.Priority {
+			left = spine[len(spine)-1]
+			spine = spine[:len(spine)-1]
+			left.update()
+		}
+		n.Left = left
+		if len(spine) > 0 {
+			spine[len(spine)-1].Right = n
+		}
+		spine = append(spine, n)
+	}
+	for i := len(spine) - 1; i >= 0; i-- {
+		spine[i].update()
+	}
+	return spine[0]
+}
+
+// union merges the treaps x and y, whose keys may interleave. The root of
+// higher priority splits the other treap by its key.
+func (h *Heap[T]) union(x, y *NodeOf[T]) *NodeOf[T] {
+	if x == nil {
+		return y
+	}
+	if y == nil {
+		return x
+	}
+	if x.Priority < y.Priority {
+		x, y = y, x
+	}
+	left, right := h.split(y, x.Key)
+	x = h.mutable(x)
+	x.Left = h.union(x.Left, left)
+	x.Right = h.union(x.Right, right)
+	x.update()
+	return x
+}
@@ -0,0 +1,67 @@
+package treap
+
+// owner identifies the heap that may modify a node in place. Heaps that
+// share nodes after a Snapshot have different owners, so each of them
+// copies a shared node before it modifies it.
+type owner struct {
+	_ byte
+}
+
+// own returns the owner of the Heap, which a zero Heap gets on first use.
+func (h *Heap[T]) own() *owner {
+	if h.owner == nil {
+		h.owner = new(owner)
+	}
+	return h.owner
+}
+
+// mutable returns t if the Heap owns it and a copy of t owned by the Heap
+// otherwise. Nodes taken from another heap, for example by Join, belong to
+// that heap's owner and are copied like shared ones.
+func (h *Heap[T]) mutable(t *NodeOf[T]) *NodeOf[T] {
+	if t.owner == h.own() {
+		return t
+	}
+	c := *t
+	c.owner = h.owner
+	return &c
+}
+
+// Clone returns a deep copy of the Heap.
+// The complexity is O(n).
+func (h *Heap[T]) Clone() *Heap[T] {
+	c := &Heap[T]{cmp: h.cmp, owner: new(owner)}
+	c.Root = c.clone(h.Root)
+	return c
+}
+
+// clone returns a copy of the subtree rooted at t owned by the Heap.
+func (h *Heap[T]) clone(t *NodeOf[T]) *NodeOf[T] {
+	if t == nil {
+		return nil
+	}
+	c := &NodeOf[T]{Priority: t.Priority, Key: t.Key, Size: t.Size, owner: h.owner}
+	c.Left, c.Right = h.clone(t.Left), h.clone(t.Right)
+	return c
+}
+
+// Snapshot returns a copy of the Heap that shares all nodes with it.
+// Afterwards both heaps copy a shared node before they modify it, so each
+// operation copies only the nodes on the paths it changes.
+// The complexity is O(1).
+func (h *Heap[T]) Snapshot() *Heap[T] {
+	h.owner = new(owner)
+	return &Heap[T]{Root: h.Root, cmp: h.cmp, owner: new(owner)}
+}
+
+// Clone returns a deep copy of the Treap.
+// The complexity is O(n).
+func (h *Treap) Clone() *Treap {
+	return &Treap{*h.Heap.Clone()}
+}
+
+// Snapshot returns a copy of the Treap that shares all nodes with it.
+// The complexity is O(1).
+func (h *Treap) Snapshot() *Treap {
+	return &Treap{*h.Heap.Snapshot()}
+}
@@ -0,0 +1,34 @@
+package treap
+
+import (
+	"encoding"
+
+	goheap "github.com/theodesp/go-heaps"
+)
+
+// Heap implements the BinaryMarshaler and BinaryUnmarshaler interfaces
+var (
+	_ encoding.BinaryMarshaler   = (*Heap[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*Heap[int])(nil)
+)
+
+// MarshalBinary encodes the items of the heap in ascending order with the
+// go_heaps.Codec for T.
+// The complexity is O(n).
+func (h *Heap[T]) MarshalBinary() ([]byte, error) {
+	return goheap.MarshalItems(h.Sorted())
+}
+
+// UnmarshalBinary replaces the items of the heap with the items encoded in
+// data. The heap keeps its order and configuration and builds itself with
+// InsertAll.
+// The complexity is O(n log n).
+func (h *Heap[T]) UnmarshalBinary(data []byte) error {
+	items, err := goheap.UnmarshalItems[T](data)
+	if err != nil {
+		return err
+	}
+	h.Clear()
+	h.InsertAll(items)
+	return nil
+}
@@ -0,0 +1,370 @@
+// Package implicit implements an implicit treap, a sequence Data structure
+// that is ordered by position instead of by key. Like the treap package it
+// keeps its nodes in heap order of random priorities and is built on split
+// and merge, but splits by the number of elements, so inserting, deleting,
+// cutting, concatenating and reversing ranges all take O(log n) expected.
+//
+// A sequence can aggregate the values of any range with an associative and
+// commutative function such as Sum or Min.
+//
+// Structure is not thread safe.
+//
+// Reference: https://en.wikipedia.org/wiki/Treap#Implicit_treap
+package implicit
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+
+	goheap "github.com/theodesp/go-heaps"
+)
+
+// Number is a constraint for the types that Sum can add.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Sum adds a and b. It can be passed to NewFunc to aggregate ranges.
+func Sum[T Number](a, b T) T {
+	return a + b
+}
+
+// Min returns the smaller of a and b. It can be passed to NewFunc to
+// aggregate ranges.
+func Min[T cmp.Ordered](a, b T) T {
+	return min(a, b)
+}
+
+type node[T any] struct {
+	value    T
+	priority int
+	// size is the number of values in the subtree
+	size int
+	// agg is the aggregate of the values in the subtree
+	agg T
+	// reversed marks a subtree whose children still have to be swapped
+	reversed    bool
+	left, right *node[T]
+}
+
+func size[T any](n *node[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// push hands a pending reversal of n down to its children.
+func (n *node[T]) push() {
+	if !n.reversed {
+		return
+	}
+	n.left, n.right = n.right, n.left
+	if n.left != nil {
+		n.left.reversed = !n.left.reversed
+	}
+	if n.right != nil {
+		n.right.reversed = !n.right.reversed
+	}
+	n.reversed = false
+}
+
+// Sequence is an implicit treap holding values of type T.
+// The zero value for Sequence is an empty Sequence without aggregate.
+type Sequence[T any] struct {
+	root    *node[T]
+	combine func(a, b T) T
+}
+
+// Sequence implements the Validator interface
+var _ goheap.Validator = (*Sequence[int])(nil)
+
+// New returns an empty Sequence without aggregate.
+func New[T any]() *Sequence[T] {
+	return &Sequence[T]{}
+}
+
+// NewFunc returns an empty Sequence whose ranges are aggregated by
+// combine, which must be associative and commutative.
+func NewFunc[T any](combine func(a, b T) T) *Sequence[T] {
+	return &Sequence[T]{combine: combine}
+}
+
+// update recomputes the size and aggregate of n from its children.
+func (s *Sequence[T]) update(n *node[T]) {
+	n.size = 1 + size(n.left) + size(n.right)
+	if s.combine == nil {
+		return
+	}
+	n.agg = n.value
+	if n.left != nil {
+		n.agg = s.combine(n.left.agg, n.agg)
+	}
+	if n.right != nil {
+		n.agg = s.combine(n.agg, n.right.agg)
+	}
+}
+
+// split splits the subtree t into its first k values and the rest.
+func (s *Sequence[T]) split(t *node[T], k int) (*node[T], *node[T]) {
+	if t == nil {
+		return nil, nil
+	}
+	t.push()
+	if k <= size(t.left) {
+		left, right := s.split(t.left, k)
+		t.left = right
+		s.update(t)
+		return left, t
+	}
+	left, right := s.split(t.right, k-size(t.left)-1)
+	t.right = left
+	s.update(t)
+	return t, right
+}
+
+// merge concatenates the subtrees x and y.
+func (s *Sequence[T]) merge(x, y *node[T]) *node[T] {
+	if x == nil {
+		return y
+	}
+	if y == nil {
+		return x
+	}
+	if x.priority > y.priority {
+		x.push()
+		x.right = s.merge(x.right, y)
+		s.update(x)
+		return x
+	}
+	y.push()
+	y.left = s.merge(x, y.left)
+	s.update(y)
+	return y
+}
+
+// cut splits the Sequence into the values before i, the values in [i, j)
+// and the values from j on.
+func (s *Sequence[T]) cut(i, j int) (left, middle, right *node[T]) {
+	s.checkRange(i, j)
+	left, right = s.split(s.root, i)
+	middle, right = s.split(right, j-i)
+	return left, middle, right
+}
+
+func checkIndex(i, n int) {
+	if i < 0 || i >= n {
+		panic(fmt.Sprintf("index %d out of range [0:%d]", i, n))
+	}
+}
+
+func (s *Sequence[T]) checkRange(i, j int) {
+	if i < 0 || j < i || j > s.Len() {
+		panic(fmt.Sprintf("range [%d:%d] out of range [0:%d]", i, j, s.Len()))
+	}
+}
+
+// Len returns the number of values in the Sequence.
+// The complexity is O(1).
+func (s *Sequence[T]) Len() int {
+	return size(s.root)
+}
+
+// IsEmpty returns true if the Sequence has no values.
+// The complexity is O(1).
+func (s *Sequence[T]) IsEmpty() bool {
+	return s.root == nil
+}
+
+// Clear removes all values from the Sequence.
+func (s *Sequence[T]) Clear() {
+	s.root = nil
+}
+
+// Insert inserts v at index i, shifting the values from i on by one.
+// It panics if i is not in [0, Len()].
+// The complexity is O(log n) expected.
+func (s *Sequence[T]) Insert(i int, v T) {
+	checkIndex(i, s.Len()+1)
+	n := &node[T]{value: v, priority: rand.Int()}
+	s.update(n)
+	left, right := s.split(s.root, i)
+	s.root = s.merge(s.merge(left, n), right)
+	goheap.DebugValidate(s)
+}
+
+// Append adds values to the end of the Sequence.
+// The complexity is O(log n) expected per value.
+func (s *Sequence[T]) Append(values ...T) {
+	for _, v := range values {
+		n := &node[T]{value: v, priority: rand.Int()}
+		s.update(n)
+		s.root = s.merge(s.root, n)
+	}
+	goheap.DebugValidate(s)
+}
+
+// Delete removes the value at index i and returns it.
+// It panics if i is not in [0, Len()).
+// The complexity is O(log n) expected.
+func (s *Sequence[T]) Delete(i int) T {
+	checkIndex(i, s.Len())
+	left, middle, right := s.cut(i, i+1)
+	s.root = s.merge(left, right)
+	goheap.DebugValidate(s)
+	return middle.value
+}
+
+// Get returns the value at index i.
+// It panics if i is not in [0, Len()).
+// The complexity is O(log n) expected.
+func (s *Sequence[T]) Get(i int) T {
+	checkIndex(i, s.Len())
+	t := s.root
+	for {
+		t.push()
+		left := size(t.left)
+		switch {
+		case i < left:
+			t = t.left
+		case i == left:
+			return t.value
+		default:
+			i -= left + 1
+			t = t.right
+		}
+	}
+}
+
+// Set replaces the value at index i with v.
+// It panics if i is not in [0, Len()).
+// The complexity is O(log n) expected.
+func (s *Sequence[T]) Set(i int, v T) {
+	checkIndex(i, s.Len())
+	left, middle, right := s.cut(i, i+1)
+	middle.value = v
+	s.update(middle)
+	s.root = s.merge(s.merge(left, middle), right)
+}
+
+// Slice returns a copy of the values in [i, j).
+// The complexity is O(log n + j - i) expected.
+func (s *Sequence[T]) Slice(i, j int) []T {
+	left, middle, right := s.cut(i, j)
+	values := make([]T, 0, j-i)
+	values = appendValues(values, middle)
+	s.root = s.merge(s.merge(left, middle), right)
+	return values
+}
+
+// Values returns a copy of all values in order.
+// The complexity is O(n).
+func (s *Sequence[T]) Values() []T {
+	return appendValues(make([]T, 0, s.Len()), s.root)
+}
+
+func appendValues[T any](values []T, t *node[T]) []T {
+	if t == nil {
+		return values
+	}
+	t.push()
+	values = appendValues(values, t.left)
+	values = append(values, t.value)
+	return appendValues(values, t.right)
+}
+
+// Cut removes the values in [i, j) from the Sequence and returns them as
+// a new Sequence with the same aggregate.
+// The complexity is O(log n) expected.
+func (s *Sequence[T]) Cut(i, j int) *Sequence[T] {
+	left, middle, right := s.cut(i, j)
+	s.root = s.merge(left, right)
+	goheap.DebugValidate(s)
+	return &Sequence[T]{root: middle, combine: s.combine}
+}
+
+// Split removes the values from index i on and returns them as a new
+// Sequence with the same aggregate.
+// The complexity is O(log n) expected.
+func (s *Sequence[T]) Split(i int) *Sequence[T] {
+	return s.Cut(i, s.Len())
+}
+
+// Concat appends all values of other to the Sequence and clears other.
+// Both must use the same aggregate.
+// The complexity is O(log n + log m) expected.
+func (s *Sequence[T]) Concat(other *Sequence[T]) {
+	if other == s {
+		return
+	}
+	s.root = s.merge(s.root, other.root)
+	other.Clear()
+	goheap.DebugValidate(s)
+}
+
+// Reverse reverses the order of the values in [i, j). The reversal is
+// only marked on the root of the range and handed down lazily.
+// The complexity is O(log n) expected.
+func (s *Sequence[T]) Reverse(i, j int) {
+	left, middle, right := s.cut(i, j)
+	if middle != nil {
+		middle.reversed = !middle.reversed
+	}
+	s.root = s.merge(s.merge(left, middle), right)
+	goheap.DebugValidate(s)
+}
+
+// Fold returns the aggregate of the values in [i, j), or the zero value
+// of T if the range is empty. It panics if the Sequence has no aggregate.
+// The complexity is O(log n) expected.
+func (s *Sequence[T]) Fold(i, j int) T {
+	if s.combine == nil {
+		panic("sequence has no aggregate")
+	}
+	left, middle, right := s.cut(i, j)
+	var agg T
+	if middle != nil {
+		agg = middle.agg
+	}
+	s.root = s.merge(s.merge(left, middle), right)
+	return agg
+}
+
+// Validate checks that no node has a higher priority than its parent and
+// that every subtree size is correct.
+// The complexity is O(n).
+func (s *Sequence[T]) Validate() error {
+	_, err := validate(s.root, s.Len())
+	return err
+}
+
+// validate checks the subtree rooted at t, which may hold at most limit
+// values, and returns its size.
+func validate[T any](t *node[T], limit int) (int, error) {
+	if t == nil {
+		return 0, nil
+	}
+	if limit <= 0 {
+		return 0, fmt.Errorf("implicit: more nodes than the root size or a cycle at %v", t.value)
+	}
+	for _, c := range []*node[T]{t.left, t.right} {
+		if c != nil && c.priority > t.priority {
+			return 0, fmt.Errorf("implicit: value %v has a higher priority than its parent %v", c.value, t.value)
+		}
+	}
+	left, err := validate(t.left, limit-1)
+	if err != nil {
+		return 0, err
+	}
+	right, err := validate(t.right, limit-1-left)
+	if err != nil {
+		return 0, err
+	}
+	if n := 1 + left + right; t.size != n {
+		return 0, fmt.Errorf("implicit: value %v has size %d but %d values in its subtree", t.value, t.size, n)
+	}
+	return 1 + left + right, nil
+}
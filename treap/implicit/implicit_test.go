@@ -0,0 +1,128 @@
+package implicit
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSequence(t *testing.T) {
+	s := NewFunc(Sum[int])
+	s.Append(1, 2, 3, 4, 5)
+	s.Insert(0, 0)
+	s.Insert(6, 6)
+	s.Insert(3, 10)
+	if got := s.Values(); !slices.Equal(got, []int{0, 1, 2, 10, 3, 4, 5, 6}) {
+		t.Fatalf("Values() = %v", got)
+	}
+	if s.Delete(3) != 10 || s.Get(3) != 3 || s.Len() != 7 {
+		t.Fail()
+	}
+	s.Set(0, 7)
+	if s.Fold(0, 3) != 10 || s.Fold(2, 2) != 0 || s.Fold(0, s.Len()) != 28 {
+		t.Fail()
+	}
+	if got := s.Slice(2, 5); !slices.Equal(got, []int{2, 3, 4}) {
+		t.Errorf("Slice(2, 5) = %v", got)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	s := NewFunc(Min[int])
+	s.Append(5, 1, 4, 2, 3, 0)
+	s.Reverse(1, 5)
+	if got := s.Values(); !slices.Equal(got, []int{5, 3, 2, 4, 1, 0}) {
+		t.Fatalf("Values() = %v", got)
+	}
+	s.Reverse(0, 6)
+	if got := s.Values(); !slices.Equal(got, []int{0, 1, 4, 2, 3, 5}) {
+		t.Fatalf("Values() = %v", got)
+	}
+	if s.Fold(2, 5) != 2 || s.Get(2) != 4 {
+		t.Fail()
+	}
+}
+
+func TestCutConcat(t *testing.T) {
+	s := New[string]()
+	s.Append("a", "b", "c", "d", "e")
+	middle := s.Cut(1, 3)
+	tail := s.Split(2)
+	if got := s.Values(); !slices.Equal(got, []string{"a", "d"}) {
+		t.Errorf("Values() = %v", got)
+	}
+	if got := middle.Values(); !slices.Equal(got, []string{"b", "c"}) {
+		t.Errorf("Cut(1, 3) = %v", got)
+	}
+	if got := tail.Values(); !slices.Equal(got, []string{"e"}) {
+		t.Errorf("Split(2) = %v", got)
+	}
+	middle.Concat(tail)
+	s.Concat(middle)
+	if got := s.Values(); !slices.Equal(got, []string{"a", "d", "b", "c", "e"}) || !middle.IsEmpty() {
+		t.Errorf("Values() after Concat = %v", got)
+	}
+}
+
+func TestIndexOutOfRange(t *testing.T) {
+	s := New[int]()
+	s.Append(1, 2)
+	for name, op := range map[string]func(){
+		"Get":     func() { s.Get(2) },
+		"Delete":  func() { s.Delete(-1) },
+		"Insert":  func() { s.Insert(3, 0) },
+		"Reverse": func() { s.Reverse(1, 0) },
+		"Fold":    func() { s.Fold(0, 1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			op()
+		}()
+	}
+}
+
+func TestModel(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	s := NewFunc(Sum[int])
+	var model []int
+	for step := 0; step < 2000; step++ {
+		n := len(model)
+		switch op := r.Intn(6); {
+		case op < 2 || n == 0:
+			i, v := r.Intn(n+1), r.Intn(100)
+			s.Insert(i, v)
+			model = slices.Insert(model, i, v)
+		case op == 2:
+			i := r.Intn(n)
+			if got := s.Delete(i); got != model[i] {
+				t.Fatalf("step %d: Delete(%d) = %d, want %d", step, i, got, model[i])
+			}
+			model = slices.Delete(model, i, i+1)
+		case op == 3:
+			i := r.Intn(n + 1)
+			j := i + r.Intn(n-i+1)
+			s.Reverse(i, j)
+			slices.Reverse(model[i:j])
+		default:
+			i := r.Intn(n + 1)
+			j := i + r.Intn(n-i+1)
+			want := 0
+			for _, v := range model[i:j] {
+				want += v
+			}
+			if got := s.Fold(i, j); got != want {
+				t.Fatalf("step %d: Fold(%d, %d) = %d, want %d", step, i, j, got, want)
+			}
+		}
+		if err := s.Validate(); err != nil {
+			t.Fatalf("step %d: %v", step, err)
+		}
+	}
+	if got := s.Values(); !slices.Equal(got, model) {
+		t.Fatalf("Values() = %v, want %v", got, model)
+	}
+}
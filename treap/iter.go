@@ -0,0 +1,61 @@
+package treap
+
+import (
+	"iter"
+
+	goheap "github.com/theodesp/go-heaps"
+)
+
+// Heap implements the Iterable interface
+var _ goheap.Iterable[int] = (*Heap[int])(nil)
+
+// Do calls iter on every key of the Treap in preorder until iter returns
+// false.
+// The complexity is O(n).
+func (h *Heap[T]) Do(iter func(item T) bool) {
+	goheap.DoForest(h.roots, children[T], keyAt[T], iter)
+}
+
+// Ascend calls iter on every key of the Treap in ascending order until
+// iter returns false. The keys form a binary search tree, so it walks the
+// Treap in order without an auxiliary heap.
+// The complexity is O(n).
+func (h *Heap[T]) Ascend(iter func(item T) bool) {
+	h.ascend(h.Root, iter)
+}
+
+func (h *Heap[T]) ascend(t *NodeOf[T], iter func(item T) bool) bool {
+	if t == nil {
+		return true
+	}
+	return h.ascend(t.Left, iter) && iter(t.Key) && h.ascend(t.Right, iter)
+}
+
+// All returns an iterator over the keys of the Treap in preorder.
+func (h *Heap[T]) All() iter.Seq[T] {
+	return h.Do
+}
+
+// Sorted returns an iterator over the keys of the Treap in ascending order.
+func (h *Heap[T]) Sorted() iter.Seq[T] {
+	return h.Ascend
+}
+
+func (h *Heap[T]) roots(visit func(t *NodeOf[T])) {
+	if h.Root != nil {
+		visit(h.Root)
+	}
+}
+
+func children[T any](t *NodeOf[T], visit func(c *NodeOf[T])) {
+	if t.Left != nil {
+		visit(t.Left)
+	}
+	if t.Right != nil {
+		visit(t.Right)
+	}
+}
+
+func keyAt[T any](t *NodeOf[T]) T {
+	return t.Key
+}
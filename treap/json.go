@@ -0,0 +1,52 @@
+package treap
+
+import (
+	"encoding/json"
+	"slices"
+
+	goheap "github.com/theodesp/go-heaps"
+)
+
+var (
+	// Heap implements the Marshaler and Unmarshaler interfaces
+	_ json.Marshaler   = (*Heap[int])(nil)
+	_ json.Unmarshaler = (*Heap[int])(nil)
+	// Heap has a tree view
+	_ goheap.TreeViewer[int] = (*Heap[int])(nil)
+)
+
+// MarshalJSON encodes the keys of the Treap in ascending order as a
+// go_heaps.JSONView without the tree view.
+// The complexity is O(n).
+func (h *Heap[T]) MarshalJSON() ([]byte, error) {
+	return goheap.JSONView[T]{Items: slices.Collect(h.Sorted())}.MarshalJSON()
+}
+
+// UnmarshalJSON replaces the keys of the Treap with the items of the
+// go_heaps.JSONView encoded in data. The Treap keeps its order and builds
+// itself with InsertAll, drawing new priorities.
+// The complexity is O(n log n).
+func (h *Heap[T]) UnmarshalJSON(data []byte) error {
+	var v goheap.JSONView[T]
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	h.Clear()
+	h.InsertAll(v.Items)
+	return nil
+}
+
+// Tree returns the tree of the Treap. A node that has a child lists both
+// its left and right child, null where missing. Every node has its
+// "priority" and the "size" of its subtree.
+// The complexity is O(n).
+func (h *Heap[T]) Tree() []*goheap.TreeNode[T] {
+	return goheap.TreeOf(h.roots, func(t *NodeOf[T], visit func(c *NodeOf[T])) {
+		if t.Left != nil || t.Right != nil {
+			visit(t.Left)
+			visit(t.Right)
+		}
+	}, keyAt[T], func(t *NodeOf[T]) []goheap.Attr {
+		return []goheap.Attr{{Name: "priority", Value: t.Priority}, {Name: "size", Value: t.Size}}
+	})
+}
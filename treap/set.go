@@ -0,0 +1,216 @@
+package treap
+
+import (
+	goheap "github.com/theodesp/go-heaps"
+)
+
+// Split moves all keys greater than key into a new Heap and returns it.
+// The Heap keeps the keys less than or equal to key. Both heaps get new
+// owners, so neither modifies in place the nodes the other may take back
+// with Join.
+// The complexity is O(log n) expected.
+func (h *Heap[T]) Split(key T) *Heap[T] {
+	left, right := h.split(h.Root, key)
+	h.Root, h.owner = left, new(owner)
+	return &Heap[T]{Root: right, cmp: h.cmp, owner: new(owner)}
+}
+
+// Join moves all keys of other into the Heap and clears other.
+// Every key of other must be greater than or equal to every key of the
+// Heap, otherwise Join panics. The Heap copies the nodes of other that it
+// changes, so snapshots sharing them are not affected.
+// The complexity is O(log n + log m) expected.
+func (h *Heap[T]) Join(other *Heap[T]) {
+	if other == h || other.Root == nil {
+		return
+	}
+	if h.Root != nil {
+		max := h.Root
+		for ; max.Right != nil; max = max.Right {
+		}
+		if min := other.FindMin(); h.compare(max.Key, min) > 0 {
+			panic("keys of other are smaller than the keys of the heap")
+		}
+	}
+	h.Root = h.merge(h.Root, other.Root)
+	other.Init()
+}
+
+// Contains returns true if the Heap holds a key equal to item.
+// The complexity is O(log n) expected.
+func (h *Heap[T]) Contains(item T) bool {
+	for t := h.Root; t != nil; {
+		switch c := h.compare(item, t.Key); {
+		case c == 0:
+			return true
+		case c < 0:
+			t = t.Left
+		default:
+			t = t.Right
+		}
+	}
+	return false
+}
+
+// Delete removes one key equal to item from the Heap and returns it.
+// It returns the zero value of T if there is no such key.
+// The complexity is O(log n) expected.
+func (h *Heap[T]) Delete(item T) T {
+	var removed *NodeOf[T]
+	h.Root, removed = h.remove(h.Root, item)
+	if removed == nil {
+		var zero T
+		return zero
+	}
+	goheap.DebugValidate(h)
+	return removed.Key
+}
+
+// remove deletes a node with a key equal to item from the subtree rooted
+// at t and returns the new subtree and the removed node.
+func (h *Heap[T]) remove(t *NodeOf[T], item T) (*NodeOf[T], *NodeOf[T]) {
+	if t == nil {
+		return nil, nil
+	}
+	c := h.compare(item, t.Key)
+	if c == 0 {
+		return h.merge(t.Left, t.Right), t
+	}
+	var sub, removed *NodeOf[T]
+	if c < 0 {
+		sub, removed = h.remove(t.Left, item)
+	} else {
+		sub, removed = h.remove(t.Right, item)
+	}
+	if removed == nil {
+		return t, nil
+	}
+	t = h.mutable(t)
+	if c < 0 {
+		t.Left = sub
+	} else {
+		t.Right = sub
+	}
+	t.Size--
+	return t, removed
+}
+
+// Rank returns the number of keys in the Heap that are smaller than item.
+// The complexity is O(log n) expected.
+func (h *Heap[T]) Rank(item T) int {
+	rank := 0
+	for t := h.Root; t != nil; {
+		if h.compare(item, t.Key) <= 0 {
+			t = t.Left
+		} else {
+			rank += size(t.Left) + 1
+			t = t.Right
+		}
+	}
+	return rank
+}
+
+// Select returns the key of rank k, which is the k+1-th smallest key, and
+// true, or the zero value of T and false if k is out of range.
+// The complexity is O(log n) expected.
+func (h *Heap[T]) Select(k int) (T, bool) {
+	if k < 0 || k >= h.Len() {
+		var zero T
+		return zero, false
+	}
+	t := h.Root
+	for {
+		left := size(t.Left)
+		switch {
+		case k < left:
+			t = t.Left
+		case k == left:
+			return t.Key, true
+		default:
+			k -= left + 1
+			t = t.Right
+		}
+	}
+}
+
+// Predecessor returns the greatest key that is smaller than item and true,
+// or the zero value of T and false if there is none.
+// The complexity is O(log n) expected.
+func (h *Heap[T]) Predecessor(item T) (T, bool) {
+	var found *NodeOf[T]
+	for t := h.Root; t != nil; {
+		if h.compare(t.Key, item) < 0 {
+			found = t
+			t = t.Right
+		} else {
+			t = t.Left
+		}
+	}
+	return keyOf(found)
+}
+
+// Successor returns the smallest key that is greater than item and true,
+// or the zero value of T and false if there is none.
+// The complexity is O(log n) expected.
+func (h *Heap[T]) Successor(item T) (T, bool) {
+	var found *NodeOf[T]
+	for t := h.Root; t != nil; {
+		if h.compare(t.Key, item) > 0 {
+			found = t
+			t = t.Left
+		} else {
+			t = t.Right
+		}
+	}
+	return keyOf(found)
+}
+
+func keyOf[T any](t *NodeOf[T]) (T, bool) {
+	if t == nil {
+		var zero T
+		return zero, false
+	}
+	return t.Key, true
+}
+
+// Range calls iter in ascending order for every key k with lo <= k <= hi
+// until iter returns false.
+// The complexity is O(log n + m) expected for m visited keys.
+func (h *Heap[T]) Range(lo, hi T, iter func(item T) bool) {
+	h.rangeNodes(h.Root, lo, hi, iter)
+}
+
+// rangeNodes visits the subtree rooted at t and reports whether iter asked
+// to continue.
+func (h *Heap[T]) rangeNodes(t *NodeOf[T], lo, hi T, iter func(item T) bool) bool {
+	if t == nil {
+		return true
+	}
+	aboveLo := h.compare(t.Key, lo) >= 0
+	belowHi := h.compare(t.Key, hi) <= 0
+	if aboveLo && !h.rangeNodes(t.Left, lo, hi, iter) {
+		return false
+	}
+	if aboveLo && belowHi && !iter(t.Key) {
+		return false
+	}
+	if belowHi {
+		return h.rangeNodes(t.Right, lo, hi, iter)
+	}
+	return true
+}
+
+// Split moves all keys greater than key into a new Treap and returns it.
+// The Treap keeps the keys less than or equal to key.
+// The complexity is O(log n) expected.
+func (h *Treap) Split(key goheap.Item) *Treap {
+	return &Treap{Heap: *h.Heap.Split(key)}
+}
+
+// Join moves all keys of other into the Treap and clears other.
+// Every key of other must be greater than or equal to every key of the
+// Treap, otherwise Join panics.
+// The complexity is O(log n + log m) expected.
+func (h *Treap) Join(other *Treap) {
+	h.Heap.Join(&other.Heap)
+}
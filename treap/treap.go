@@ -10,36 +10,67 @@
 package treap
 
 import (
+	"cmp"
 	"math/rand"
-	"time"
 
 	goheap "github.com/theodesp/go-heaps"
 )
 
+// Treap implements the Interface interface
+var _ goheap.Interface = (*Treap)(nil)
+
+// Heap implements the Heap interface
+var _ goheap.Heap[int] = (*Heap[int])(nil)
+
 const MaxInt = int(^uint(0) >> 1)
 
-type Node struct {
+// NodeOf is a treap node holding a key of type T.
+type NodeOf[T any] struct {
 	Priority    goheap.Integer
-	Key         goheap.Item
-	Left, Right *Node
+	Key         T
+	Left, Right *NodeOf[T]
+	// Size is the number of keys in the subtree rooted at the node
+	Size int
+	// owner is the heap that may modify the node in place
+	owner *owner
+}
+
+// size returns the number of keys in the subtree rooted at t.
+func size[T any](t *NodeOf[T]) int {
+	if t == nil {
+		return 0
+	}
+	return t.Size
+}
+
+// update recomputes the subtree size of t from its children.
+func (t *NodeOf[T]) update() {
+	t.Size = 1 + size(t.Left) + size(t.Right)
 }
 
+// Node is a treap node holding a go_heaps.Item key.
+type Node = NodeOf[goheap.Item]
+
 // Split treap into 2 treaps:
 // - All key in left treap <= key
 // - All key in right treap > key
-func split(t *Node, key goheap.Item) (*Node, *Node) {
+func (h *Heap[T]) split(t *NodeOf[T], key T) (*NodeOf[T], *NodeOf[T]) {
 	var (
-		left, right *Node
+		left, right *NodeOf[T]
 	)
 
 	if t == nil {
 		return nil, nil
-	} else if t.Key.Compare(key) <= 0 {
-		t.Right, right = split(t.Right, key)
+	}
+	t = h.mutable(t)
+	if h.compare(t.Key, key) <= 0 {
+		t.Right, right = h.split(t.Right, key)
+		t.update()
 		left := t
 		return left, right
 	} else {
-		left, t.Left = split(t.Left, key)
+		left, t.Left = h.split(t.Left, key)
+		t.update()
 		right := t
 		return left, right
 	}
@@ -47,7 +78,7 @@ func split(t *Node, key goheap.Item) (*Node, *Node) {
 
 // Merge 2 treaps into one with condition:
 // max key on left treap is <= than min key on right treap
-func merge(x, y *Node) *Node {
+func (h *Heap[T]) merge(x, y *NodeOf[T]) *NodeOf[T] {
 	if x == nil {
 		return y
 	}
@@ -57,29 +88,36 @@ func merge(x, y *Node) *Node {
 	}
 
 	if x.Priority.Compare(y.Priority) > 0 {
-		x.Right = merge(x.Right, y)
+		x = h.mutable(x)
+		x.Right = h.merge(x.Right, y)
+		x.update()
 		return x
 	} else {
-		y.Left = merge(x, y.Left)
+		y = h.mutable(y)
+		y.Left = h.merge(x, y.Left)
+		y.update()
 		return y
 	}
 }
 
-func (t *Node) insert(pnode *Node) *Node {
+func (h *Heap[T]) insert(t, pnode *NodeOf[T]) *NodeOf[T] {
 	if t == nil {
 		return pnode
 	}
 
 	if pnode.Priority.Compare(t.Priority) > 0 {
-		pnode.Left, pnode.Right = split(t, pnode.Key)
+		pnode.Left, pnode.Right = h.split(t, pnode.Key)
+		pnode.update()
 		return pnode
 	}
 
-	if t.Key.Compare(pnode.Key) != 1 {
-		t.Right = t.Right.insert(pnode)
+	t = h.mutable(t)
+	if h.compare(t.Key, pnode.Key) <= 0 {
+		t.Right = h.insert(t.Right, pnode)
 	} else {
-		t.Left = t.Left.insert(pnode)
+		t.Left = h.insert(t.Left, pnode)
 	}
+	t.update()
 	return t
 }
 
@@ -88,60 +126,112 @@ func generatePriority() goheap.Integer {
 	return goheap.Integer(rand.Intn(MaxInt))
 }
 
+// Heap is a Treap implementation over keys of type T.
+// The zero value for Heap is an empty Heap ordered by go_heaps.Compare.
+type Heap[T any] struct {
+	Root  *NodeOf[T]
+	cmp   func(a, b T) int
+	owner *owner
+}
+
 // Treap implementation.
 type Treap struct {
-	Root *Node
+	Heap[goheap.Item]
 }
 
+func (h *Heap[T]) compare(a, b T) int {
+	if h.cmp == nil {
+		return goheap.Compare(a, b)
+	}
+	return h.cmp(a, b)
+}
+
+// Init initializes or clears the Heap. The Heap gets a new owner, so it
+// no longer modifies in place the nodes it held before.
+func (h *Heap[T]) Init() *Heap[T] {
+	h.Root = nil
+	h.owner = new(owner)
+	return h
+}
+
+// NewFunc returns an initialized Heap ordered by compare.
+func NewFunc[T any](compare func(a, b T) int) *Heap[T] {
+	return (&Heap[T]{cmp: compare}).Init()
+}
+
+// NewOrdered returns an initialized Heap of ordered keys.
+func NewOrdered[T cmp.Ordered]() *Heap[T] { return NewFunc(cmp.Compare[T]) }
+
 // Init initializes or clears the Treap
 func (h *Treap) Init() *Treap {
-	rand.Seed(time.Now().UnixNano())
-	return &Treap{}
+	h.Heap.Init()
+	return h
 }
 
 // New returns an initialized Treap.
 func New() *Treap { return new(Treap).Init() }
 
+// NewWithComparator returns an initialized Treap ordered by compare
+// instead of Item.Compare.
+func NewWithComparator(compare func(a, b goheap.Item) int) *Treap {
+	h := &Treap{}
+	h.cmp = compare
+	return h.Init()
+}
+
 // Insert adds an item into the heap.
-func (h *Treap) Insert(v goheap.Item) goheap.Item {
-	pnode := &Node{
+func (h *Heap[T]) Insert(v T) T {
+	pnode := &NodeOf[T]{
 		Priority: generatePriority(),
 		Key:      v,
+		Size:     1,
+		owner:    h.own(),
 	}
 
 	if h.Root == nil {
 		h.Root = pnode
 	} else {
-		h.Root = h.Root.insert(pnode)
+		h.Root = h.insert(h.Root, pnode)
 	}
+	goheap.DebugValidate(h)
 	return v
 }
 
 // DeleteMin deletes the minimum value and returns it.
-func (h *Treap) DeleteMin() goheap.Item {
+// It returns the zero value of T if the heap is empty.
+func (h *Heap[T]) DeleteMin() T {
 	v := h.Root
 	if v == nil {
-		return nil
+		var zero T
+		return zero
 	}
 
 	if v.Left == nil {
 		h.Root = v.Right
+		goheap.DebugValidate(h)
 		return v.Key
 	}
 
-	for ; v.Left.Left != nil; v = v.Left {
+	h.Root = h.mutable(v)
+	for v = h.Root; v.Left.Left != nil; v = v.Left {
+		v.Size--
+		v.Left = h.mutable(v.Left)
 	}
+	v.Size--
 
 	min := v.Left
-	v.Left = merge(v.Left.Left, v.Left.Right)
+	v.Left = h.merge(min.Left, min.Right)
+	goheap.DebugValidate(h)
 	return min.Key
 }
 
 // FindMin finds the minimum value.
-func (h *Treap) FindMin() goheap.Item {
+// The complexity is O(log n) expected.
+func (h *Heap[T]) FindMin() T {
 	v := h.Root
 	if v == nil {
-		return nil
+		var zero T
+		return zero
 	}
 
 	for ; v.Left != nil; v = v.Left {
@@ -150,7 +240,18 @@ func (h *Treap) FindMin() goheap.Item {
 	return v.Key
 }
 
+// Len returns the number of items in the heap.
+// The complexity is O(1).
+func (h *Heap[T]) Len() int {
+	return size(h.Root)
+}
+
+// IsEmpty returns true if the heap has no items.
+func (h *Heap[T]) IsEmpty() bool {
+	return h.Root == nil
+}
+
 // Clear removes all items from the heap.
-func (h *Treap) Clear() {
+func (h *Heap[T]) Clear() {
 	h.Root = nil
 }
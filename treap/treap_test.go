@@ -1,10 +1,15 @@
 package treap
 
 import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"slices"
 	"sort"
 	"testing"
 
 	goheap "github.com/theodesp/go-heaps"
+	"github.com/theodesp/go-heaps/heaptest"
 )
 
 func TestTreapInteger(t *testing.T) {
@@ -42,3 +47,374 @@ func TestTreapString(t *testing.T) {
 		}
 	}
 }
+
+func TestHeapOrdered(t *testing.T) {
+	treap := NewOrdered[int]()
+
+	numbers := []int{4, 3, 2, 5, 3}
+
+	for _, number := range numbers {
+		treap.Insert(number)
+	}
+
+	sort.Ints(numbers)
+
+	for _, number := range numbers {
+		if number != treap.DeleteMin() {
+			t.Fail()
+		}
+	}
+	if treap.FindMin() != 0 {
+		t.Fail()
+	}
+}
+
+func TestHeapFunc(t *testing.T) {
+	treap := NewFunc(func(a, b string) int { return len(a) - len(b) })
+
+	for _, str := range []string{"ccc", "a", "dddd", "bb"} {
+		treap.Insert(str)
+	}
+
+	for _, str := range []string{"a", "bb", "ccc", "dddd"} {
+		if str != treap.DeleteMin() {
+			t.Fail()
+		}
+	}
+}
+
+func TestTreapLen(t *testing.T) {
+	treap := New()
+
+	if !treap.IsEmpty() || treap.Len() != 0 || treap.DeleteMin() != nil {
+		t.Fail()
+	}
+
+	for _, number := range []int{4, 3, 2, 5} {
+		treap.Insert(goheap.Integer(number))
+	}
+	treap.DeleteMin()
+
+	if treap.IsEmpty() || treap.Len() != 3 {
+		t.Fail()
+	}
+
+	treap.Clear()
+	if !treap.IsEmpty() || treap.Len() != 0 {
+		t.Fail()
+	}
+}
+
+func TestTreapMaxHeap(t *testing.T) {
+	treap := NewWithComparator(func(a, b goheap.Item) int { return b.Compare(a) })
+	reversed := New()
+
+	for _, number := range []int{4, 3, 7, 5} {
+		treap.Insert(goheap.Integer(number))
+		reversed.Insert(goheap.Reverse(goheap.Integer(number)))
+	}
+
+	for _, number := range []int{7, 5, 4, 3} {
+		if goheap.Integer(number) != treap.DeleteMin() {
+			t.Fail()
+		}
+		if goheap.Integer(number) != reversed.DeleteMin().(goheap.Reversed).Item {
+			t.Fail()
+		}
+	}
+}
+
+func TestInterface(t *testing.T) {
+	heaptest.RunInterfaceTests(t, func() goheap.Interface { return New() })
+}
+
+func FuzzHeap(f *testing.F) {
+	heaptest.AddSeeds(f)
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		heaptest.FuzzInterface(t, func() goheap.Interface { return New() }, ops)
+		heaptest.FuzzSplit(t, func() *Heap[int] { return NewOrdered[int]() }, ops)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	h := NewOrdered[int]()
+	for _, number := range []int{5, 3, 8, 1, 9, 4} {
+		h.Insert(number)
+	}
+	if err := h.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	h.Root.Priority = -1
+	if h.Validate() == nil {
+		t.Fail()
+	}
+}
+
+func TestOrderedSet(t *testing.T) {
+	h := NewOrdered[int]()
+	for _, number := range []int{50, 10, 40, 20, 30, 20} {
+		h.Insert(number)
+	}
+	if h.Rank(30) != 3 || h.Rank(5) != 0 || h.Rank(99) != 6 {
+		t.Error("Rank")
+	}
+	for k, want := range []int{10, 20, 20, 30, 40, 50} {
+		if got, ok := h.Select(k); !ok || got != want {
+			t.Errorf("Select(%d) = %d, want %d", k, got, want)
+		}
+	}
+	if _, ok := h.Select(6); ok {
+		t.Error("Select out of range")
+	}
+	if got, ok := h.Predecessor(30); !ok || got != 20 {
+		t.Errorf("Predecessor(30) = %d", got)
+	}
+	if got, ok := h.Successor(30); !ok || got != 40 {
+		t.Errorf("Successor(30) = %d", got)
+	}
+	if _, ok := h.Predecessor(10); ok {
+		t.Error("Predecessor of the minimum")
+	}
+	if _, ok := h.Successor(50); ok {
+		t.Error("Successor of the maximum")
+	}
+	if !h.Contains(40) || h.Contains(35) {
+		t.Error("Contains")
+	}
+
+	var got []int
+	h.Range(15, 40, func(item int) bool {
+		got = append(got, item)
+		return item < 30
+	})
+	if !slices.Equal(got, []int{20, 20, 30}) {
+		t.Errorf("Range = %v", got)
+	}
+
+	if h.Delete(20) != 20 || h.Delete(35) != 0 || h.Len() != 5 {
+		t.Error("Delete")
+	}
+	if err := h.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	right := h.Split(30)
+	if h.Len() != 3 || right.Len() != 2 || right.FindMin() != 40 {
+		t.Error("Split")
+	}
+	h.Join(right)
+	if !right.IsEmpty() || h.Len() != 5 {
+		t.Error("Join")
+	}
+	for _, number := range []int{10, 20, 30, 40, 50} {
+		if res := h.DeleteMin(); res != number {
+			t.Errorf("expected %d, got %d", number, res)
+		}
+	}
+}
+
+func TestJoinOverlapping(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fail()
+		}
+	}()
+	h, other := New(), New()
+	h.Insert(goheap.Integer(5))
+	other.Insert(goheap.Integer(3))
+	h.Join(other)
+}
+
+func TestOrderedSetRandom(t *testing.T) {
+	h := NewOrdered[int]()
+	r := rand.New(rand.NewSource(1))
+	var model []int
+	for i := 0; i < 1000; i++ {
+		v := r.Intn(200)
+		if r.Intn(3) == 0 {
+			if j := sort.SearchInts(model, v); j < len(model) && model[j] == v {
+				model = append(model[:j], model[j+1:]...)
+			}
+			h.Delete(v)
+		} else {
+			j := sort.SearchInts(model, v)
+			model = append(model[:j], append([]int{v}, model[j:]...)...)
+			h.Insert(v)
+		}
+	}
+	if err := h.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	for v := -1; v <= 200; v++ {
+		if h.Rank(v) != sort.SearchInts(model, v) {
+			t.Fatalf("Rank(%d) = %d, want %d", v, h.Rank(v), sort.SearchInts(model, v))
+		}
+	}
+	for k, want := range model {
+		if got, _ := h.Select(k); got != want {
+			t.Fatalf("Select(%d) = %d, want %d", k, got, want)
+		}
+	}
+}
+
+func TestIterable(t *testing.T) {
+	heaptest.RunIterableTests(t, func() heaptest.IterableHeap { return NewOrdered[int]() })
+}
+
+func TestInsertAll(t *testing.T) {
+	heaptest.RunBulkTests(t, func() heaptest.BulkHeap { return NewOrdered[int]() })
+}
+
+func TestFromSlice(t *testing.T) {
+	h := FromSliceOrdered([]int{5, 3, 8, 1, 3})
+	if got := slices.Collect(h.Sorted()); !slices.Equal(got, []int{1, 3, 3, 5, 8}) {
+		t.Errorf("expected %v, got %v", []int{1, 3, 3, 5, 8}, got)
+	}
+	tr := FromSlice([]goheap.Item{goheap.Integer(2), goheap.Integer(1)})
+	if res := tr.DeleteMin(); res != goheap.Integer(1) {
+		t.Errorf("expected 1, got %v", res)
+	}
+	if r := h.Rank(5); r != 3 {
+		t.Errorf("expected rank 3, got %d", r)
+	}
+}
+
+func TestClone(t *testing.T) {
+	heaptest.RunCloneTests(t, NewOrdered[int], (*Heap[int]).Clone)
+	tr := New()
+	tr.Insert(goheap.Integer(2))
+	c := tr.Clone()
+	tr.Insert(goheap.Integer(1))
+	if res := c.FindMin(); res != goheap.Integer(2) {
+		t.Errorf("expected 2, got %v", res)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	heaptest.RunCloneTests(t, NewOrdered[int], (*Heap[int]).Snapshot)
+
+	h := FromSliceOrdered([]int{1, 2, 3, 4, 5, 6, 7, 8})
+	s := h.Snapshot()
+	if h.Root != s.Root {
+		t.Fatal("expected the snapshot to share the root")
+	}
+	// splitting, joining and deleting copy the shared nodes
+	right := h.Split(4)
+	right.Delete(6)
+	h.Join(right)
+	h.Delete(2)
+	if got := slices.Collect(s.Sorted()); !slices.Equal(got, []int{1, 2, 3, 4, 5, 6, 7, 8}) {
+		t.Errorf("expected the snapshot to keep %v, got %v", []int{1, 2, 3, 4, 5, 6, 7, 8}, got)
+	}
+	if got := slices.Collect(h.Sorted()); !slices.Equal(got, []int{1, 3, 4, 5, 7, 8}) {
+		t.Errorf("expected %v, got %v", []int{1, 3, 4, 5, 7, 8}, got)
+	}
+	for _, x := range []*Heap[int]{h, s} {
+		if err := x.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.InsertAll([]int{0, 9})
+	if r := h.Rank(9); r != 6 {
+		t.Errorf("expected rank 6, got %d", r)
+	}
+	tr := New()
+	tr.Insert(goheap.Integer(1))
+	if res := tr.Snapshot().DeleteMin(); res != goheap.Integer(1) || tr.Len() != 1 {
+		t.Errorf("expected 1 and an unchanged treap, got %v and %d items", res, tr.Len())
+	}
+}
+
+// checkKeys checks that h is valid and holds want.
+func checkKeys(t *testing.T, name string, h *Heap[int], want []int) {
+	t.Helper()
+	if err := h.Validate(); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	if got := slices.Collect(h.Sorted()); !slices.Equal(got, want) {
+		t.Errorf("%s: expected %v, got %v", name, want, got)
+	}
+}
+
+func TestSnapshotJoin(t *testing.T) {
+	// joining a snapshot into an unrelated heap leaves the original alone
+	for name, into := range map[string]func() *Heap[int]{
+		"new":  NewOrdered[int],
+		// a literal Heap has no owner until its first insertion
+		"literal": func() *Heap[int] { return &Heap[int]{cmp: func(a, b int) int { return a - b }} },
+	} {
+		h := FromSliceOrdered([]int{50, 60, 70, 80})
+		other := into()
+		other.Insert(3)
+		other.Join(h.Snapshot())
+		checkKeys(t, name+" original", h, []int{50, 60, 70, 80})
+		checkKeys(t, name+" joined", other, []int{3, 50, 60, 70, 80})
+	}
+
+	// joining a split half back leaves a snapshot of either half alone
+	for _, half := range []string{"left", "right"} {
+		h := NewOrdered[int]()
+		for i := range 20 {
+			h.Insert(i)
+		}
+		right := h.Split(9)
+		var snapshot *Heap[int]
+		want := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+		if half == "left" {
+			snapshot = h.Snapshot()
+		} else {
+			snapshot, want = right.Snapshot(), []int{10, 11, 12, 13, 14, 15, 16, 17, 18, 19}
+		}
+		h.Join(right)
+		h.Delete(14)
+		h.Delete(4)
+		checkKeys(t, half+" snapshot", snapshot, want)
+		if h.Len() != 18 || right.Len() != 0 {
+			t.Errorf("expected 18 and 0 keys, got %d and %d", h.Len(), right.Len())
+		}
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	heaptest.RunEncodingTests(t, NewOrdered[int], (*Heap[int]).MarshalBinary)
+	tr := New()
+	tr.Insert(goheap.Integer(2))
+	tr.Insert(goheap.Integer(1))
+	data, err := tr.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded := New()
+	if err := loaded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if res := loaded.DeleteMin(); res != goheap.Integer(1) {
+		t.Errorf("expected 1, got %v", res)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	heaptest.RunJSONTests(t, NewOrdered[int], func(n *goheap.TreeNode[int]) error {
+		size := 1
+		for _, c := range n.Children {
+			if c == nil {
+				continue
+			}
+			if c.Attrs[0].Value.(goheap.Integer) > n.Attrs[0].Value.(goheap.Integer) {
+				return fmt.Errorf("child %d has a greater priority", c.Item)
+			}
+			size += c.Attrs[1].Value.(int)
+		}
+		if n.Attrs[0].Name != "priority" || n.Attrs[1].Name != "size" || n.Attrs[1].Value != size {
+			return fmt.Errorf("attributes %v, want size %d", n.Attrs, size)
+		}
+		return nil
+	})
+
+	tr := NewOrdered[int]()
+	tr.InsertAll([]int{3, 1, 2})
+	data, err := json.Marshal(tr)
+	if err != nil || string(data) != `{"items":[1,2,3]}` {
+		t.Errorf(`expected {"items":[1,2,3]}, got %s: %v`, data, err)
+	}
+}
@@ -0,0 +1,55 @@
+package treap
+
+import (
+	"fmt"
+
+	goheap "github.com/theodesp/go-heaps"
+)
+
+// Heap implements the Validator interface
+var _ goheap.Validator = (*Heap[int])(nil)
+
+// Validate checks that the keys are in binary search tree order, that no
+// node has a higher priority than its parent and that every subtree size
+// is correct.
+// The complexity is O(n).
+func (h *Heap[T]) Validate() error {
+	count := 0
+	_, err := h.validate(h.Root, nil, nil, &count)
+	return err
+}
+
+// validate checks the subtree rooted at t, whose keys must lie between the
+// keys of lo and hi if they are set, and returns its number of keys.
+func (h *Heap[T]) validate(t, lo, hi *NodeOf[T], count *int) (int, error) {
+	if t == nil {
+		return 0, nil
+	}
+	if *count++; *count > h.Len() {
+		return 0, fmt.Errorf("treap: more than %d nodes or a cycle at %v", h.Len(), t.Key)
+	}
+	if lo != nil && h.compare(t.Key, lo.Key) < 0 {
+		return 0, fmt.Errorf("treap: key %v is in the right subtree of %v", t.Key, lo.Key)
+	}
+	if hi != nil && h.compare(t.Key, hi.Key) > 0 {
+		return 0, fmt.Errorf("treap: key %v is in the left subtree of %v", t.Key, hi.Key)
+	}
+	for _, c := range []*NodeOf[T]{t.Left, t.Right} {
+		if c != nil && c.Priority > t.Priority {
+			return 0, fmt.Errorf("treap: key %v has priority %d above its parent %v with %d",
+				c.Key, c.Priority, t.Key, t.Priority)
+		}
+	}
+	left, err := h.validate(t.Left, lo, t, count)
+	if err != nil {
+		return 0, err
+	}
+	right, err := h.validate(t.Right, t, hi, count)
+	if err != nil {
+		return 0, err
+	}
+	if n := 1 + left + right; t.Size != n {
+		return 0, fmt.Errorf("treap: key %v has size %d but %d keys in its subtree", t.Key, t.Size, n)
+	}
+	return 1 + left + right, nil
+}